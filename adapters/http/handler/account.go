@@ -0,0 +1,216 @@
+package handler
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"golizilla/adapters/http/handler/presenter"
+	"golizilla/adapters/persistence/logger"
+	"golizilla/config"
+	"golizilla/core/service"
+	"golizilla/internal/apperrors"
+	logmessages "golizilla/internal/logmessages"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type AccountHandler struct {
+	accountService service.IAccountService
+	config         *config.Config
+}
+
+func NewAccountHandler(accountService service.IAccountService, cfg *config.Config) *AccountHandler {
+	return &AccountHandler{
+		accountService: accountService,
+		config:         cfg,
+	}
+}
+
+// Export streams a zip archive with everything stored about the current user.
+func (h *AccountHandler) Export(c *fiber.Ctx) error {
+	ctx := c.Context()
+
+	logger.GetLogger().LogInfoFromContext(ctx, logger.LogFields{
+		Service: logmessages.LogAccountHandler,
+		Message: logmessages.LogAccountExportBegin,
+	})
+
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogAccountHandler,
+			Message: logmessages.LogCastUserIdError,
+		})
+		return presenter.SendError(c, fiber.StatusUnauthorized, apperrors.ErrInvalidUserID.Error())
+	}
+
+	export, err := h.accountService.Export(ctx, c.UserContext(), userID)
+	if err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogAccountHandler,
+			Message: err.Error(),
+		})
+		return h.handleError(c, err)
+	}
+
+	c.Set(fiber.HeaderContentType, "application/zip")
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", export.FileName()))
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		// runs after the handler returned, so it must not rely on the request context
+		if err := export.WriteZip(context.Background(), w); err != nil {
+			logger.GetLogger().LogErrorFromContext(context.Background(), logger.LogFields{
+				Service: logmessages.LogAccountHandler,
+				Message: fmt.Sprintf("failed to write account export: %v", err),
+			})
+		}
+	})
+	return nil
+}
+
+// Delete anonymizes the current user's account and logs them out.
+func (h *AccountHandler) Delete(c *fiber.Ctx) error {
+	ctx := c.Context()
+
+	logger.GetLogger().LogInfoFromContext(ctx, logger.LogFields{
+		Service: logmessages.LogAccountHandler,
+		Message: logmessages.LogAccountDeleteBegin,
+	})
+
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogAccountHandler,
+			Message: logmessages.LogCastUserIdError,
+		})
+		return presenter.SendError(c, fiber.StatusUnauthorized, apperrors.ErrInvalidUserID.Error())
+	}
+
+	var request presenter.DeleteAccountRequest
+	if err := c.BodyParser(&request); err != nil {
+		return presenter.SendError(c, fiber.StatusBadRequest, apperrors.ErrInvalidInput.Error())
+	}
+	if err := request.Validate(); err != nil {
+		return presenter.SendError(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	if err := h.accountService.Delete(ctx, c.UserContext(), userID, request.Password); err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogAccountHandler,
+			Message: err.Error(),
+		})
+		return h.handleError(c, err)
+	}
+
+	c.Cookie(&fiber.Cookie{
+		Name:     "auth_token",
+		Value:    "",
+		Expires:  time.Now().Add(-time.Hour), // Set expiry in the past
+		HTTPOnly: true,
+		Secure:   h.config.Env == "production",
+		SameSite: "Strict",
+	})
+
+	logger.GetLogger().LogInfoFromContext(ctx, logger.LogFields{
+		Service: logmessages.LogAccountHandler,
+		Message: logmessages.LogAccountDeleteSuccessful,
+	})
+
+	return presenter.Send(c, fiber.StatusOK, true, "Account deleted", nil, nil)
+}
+
+// Archive closes one of the current user's questionnaires for good.
+func (h *AccountHandler) Archive(c *fiber.Ctx) error {
+	ctx := c.Context()
+
+	logger.GetLogger().LogInfoFromContext(ctx, logger.LogFields{
+		Service: logmessages.LogAccountHandler,
+		Message: logmessages.LogAccountArchiveBegin,
+	})
+
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogAccountHandler,
+			Message: logmessages.LogCastUserIdError,
+		})
+		return presenter.SendError(c, fiber.StatusUnauthorized, apperrors.ErrInvalidUserID.Error())
+	}
+
+	questionnaireID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return presenter.SendError(c, fiber.StatusBadRequest, "invalid ID format")
+	}
+
+	if err := h.accountService.ArchiveQuestionnaire(ctx, c.UserContext(), userID, questionnaireID); err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogAccountHandler,
+			Message: err.Error(),
+		})
+		return h.handleError(c, err)
+	}
+
+	return presenter.Send(c, fiber.StatusOK, true, "Questionnaire archived", nil, nil)
+}
+
+// Transfer hands one of the current user's questionnaires over to another user.
+func (h *AccountHandler) Transfer(c *fiber.Ctx) error {
+	ctx := c.Context()
+
+	logger.GetLogger().LogInfoFromContext(ctx, logger.LogFields{
+		Service: logmessages.LogAccountHandler,
+		Message: logmessages.LogAccountTransferBegin,
+	})
+
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogAccountHandler,
+			Message: logmessages.LogCastUserIdError,
+		})
+		return presenter.SendError(c, fiber.StatusUnauthorized, apperrors.ErrInvalidUserID.Error())
+	}
+
+	questionnaireID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return presenter.SendError(c, fiber.StatusBadRequest, "invalid ID format")
+	}
+
+	var request presenter.TransferQuestionnaireRequest
+	if err := c.BodyParser(&request); err != nil {
+		return presenter.SendError(c, fiber.StatusBadRequest, apperrors.ErrInvalidInput.Error())
+	}
+	if err := request.Validate(); err != nil {
+		return presenter.SendError(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	if err := h.accountService.TransferQuestionnaire(ctx, c.UserContext(), userID, questionnaireID, request.Username); err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogAccountHandler,
+			Message: err.Error(),
+		})
+		return h.handleError(c, err)
+	}
+
+	return presenter.Send(c, fiber.StatusOK, true, "Questionnaire transferred", nil, nil)
+}
+
+func (h *AccountHandler) handleError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, apperrors.ErrInvalidCredentials):
+		return presenter.SendError(c, fiber.StatusUnauthorized, err.Error())
+	case errors.Is(err, apperrors.ErrLackOfAuthorization):
+		return presenter.SendError(c, fiber.StatusForbidden, err.Error())
+	case errors.Is(err, apperrors.ErrQuestionnaireNotFound),
+		errors.Is(err, apperrors.ErrUserNotFound):
+		return presenter.SendError(c, fiber.StatusNotFound, err.Error())
+	case errors.Is(err, apperrors.ErrOwnsActiveQuestionnaires):
+		return presenter.SendError(c, fiber.StatusConflict, err.Error())
+	case errors.Is(err, apperrors.ErrTransferToSelf):
+		return presenter.SendError(c, fiber.StatusBadRequest, err.Error())
+	default:
+		return presenter.SendError(c, fiber.StatusInternalServerError, apperrors.ErrInternalServerError.Error())
+	}
+}
@@ -69,7 +69,7 @@ func (h *AdminHandler) GetAllUsers(c *fiber.Ctx) error {
 		fiber.StatusOK,
 		true,
 		"Users successfully fetched",
-		users,
+		presenter.NewPaginatedAdminUsersResponse(users.Data, users.Pages, users.Page),
 		nil,
 	)
 }
@@ -200,8 +200,8 @@ func (h *AdminHandler) GetAllRoles(c *fiber.Ctx) error {
 			"Invalid page size")
 	}
 
-	// Get paginated roles
-	users, err := h.adminService.GetAllRoles(ctx, userCtx, page, pageSize)
+	// Get paginated roles with their effective privileges
+	result, err := h.adminService.GetAllRoles(ctx, userCtx, page, pageSize)
 	if err != nil {
 		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
 			Service: logmessages.LogAdminHandler,
@@ -210,6 +210,14 @@ func (h *AdminHandler) GetAllRoles(c *fiber.Ctx) error {
 			fiber.StatusInternalServerError, 
 			apperrors.ErrInternalServerError.Error())
 	}
+	roles := presenter.PaginatedRolesResponse{
+		Data:  make([]presenter.RoleWithPrivilegesResponse, len(result.Data)),
+		Pages: result.Pages,
+		Page:  result.Page,
+	}
+	for i, role := range result.Data {
+		roles.Data[i] = presenter.NewRoleWithPrivilegesResponse(&role.Role, role.EffectivePrivileges)
+	}
 
 	// TODO: end log
 
@@ -217,7 +225,7 @@ func (h *AdminHandler) GetAllRoles(c *fiber.Ctx) error {
 		fiber.StatusOK,
 		true,
 		"Roles successfully fetched", // TODO: message
-		users,
+		roles,
 		nil,
 	)
 }
@@ -226,7 +234,21 @@ func (h *AdminHandler) GetAnswersByUserIDAndQuestionnaireID(c *fiber.Ctx) error
 	ctx := c.Context()
 	userCtx := c.UserContext()
 
-	// TODO: begin log
+	logger.GetLogger().LogInfoFromContext(ctx, logger.LogFields{
+		Service: logmessages.LogAdminHandler,
+		Message: logmessages.LogAdminGetAnswersBegin,
+	})
+
+	actorID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogAdminHandler,
+			Message: logmessages.LogCastUserIdError,
+		})
+		return presenter.SendError(c,
+			fiber.StatusUnauthorized,
+			apperrors.ErrInvalidUserID.Error())
+	}
 
 	// Get parameters for userID and questionnaireID
 	userID, err := uuid.Parse(c.Params("userID"))
@@ -246,7 +268,7 @@ func (h *AdminHandler) GetAnswersByUserIDAndQuestionnaireID(c *fiber.Ctx) error
 			Message: err.Error()})
 		return presenter.SendError(c,
 			fiber.StatusBadRequest,
-			"Invalid userID")
+			"Invalid questionnaireID")
 	}
 
 	// Get query parameters for page and pageSize
@@ -272,7 +294,7 @@ func (h *AdminHandler) GetAnswersByUserIDAndQuestionnaireID(c *fiber.Ctx) error
 
 	// Get paginated answers
 	users, err := h.adminService.GetAnswersByUserIDAndQuestionnaireID(
-		ctx, userCtx, userID, questionnaireID, page, pageSize)
+		ctx, userCtx, actorID, userID, questionnaireID, page, pageSize)
 	if err != nil {
 		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
 			Service: logmessages.LogAdminHandler,
@@ -292,3 +314,39 @@ func (h *AdminHandler) GetAnswersByUserIDAndQuestionnaireID(c *fiber.Ctx) error
 		nil,
 	)
 }
+
+// GetAccessLogs returns a page of the reads of user data by admins.
+func (h *AdminHandler) GetAccessLogs(c *fiber.Ctx) error {
+	ctx := c.Context()
+	userCtx := c.UserContext()
+
+	logger.GetLogger().LogInfoFromContext(ctx, logger.LogFields{
+		Service: logmessages.LogAdminHandler,
+		Message: logmessages.LogAdminGetAccessLogsBegin,
+	})
+
+	page, pageSize, err := parsePage(c)
+	if err != nil {
+		return presenter.SendError(c,
+			fiber.StatusBadRequest,
+			err.Error())
+	}
+
+	accessLogs, err := h.adminService.GetAccessLogs(ctx, userCtx, page, pageSize)
+	if err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogAdminHandler,
+			Message: err.Error()})
+		return presenter.SendError(c,
+			fiber.StatusInternalServerError,
+			apperrors.ErrInternalServerError.Error())
+	}
+
+	return presenter.Send(c,
+		fiber.StatusOK,
+		true,
+		"Admin access logs successfully fetched",
+		accessLogs,
+		nil,
+	)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"golizilla/adapters/http/handler/presenter"
 	"golizilla/adapters/persistence/logger"
+	"golizilla/core/domain/model"
 	"golizilla/core/service"
 	"golizilla/internal/apperrors"
 	logmessages "golizilla/internal/logmessages"
@@ -77,10 +78,7 @@ func (h *AnswerHandler) Create(c *fiber.Ctx) error {
 			Service: logmessages.LogAnswerHandler,
 			Message: err.Error(),
 		})
-		return presenter.SendError(c,
-			fiber.StatusInternalServerError,
-			apperrors.ErrInternalServerError.Error(),
-		)
+		return h.handleError(c, err)
 	}
 
 	return presenter.Send(c,
@@ -99,6 +97,15 @@ func (h *AnswerHandler) Update(c *fiber.Ctx) error {
 		Message: logmessages.LogAnswerUpdateBegin,
 	})
 
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogAnswerHandler,
+			Message: logmessages.LogCastUserIdError,
+		})
+		return presenter.SendError(c, fiber.StatusUnauthorized, apperrors.ErrInvalidUserID.Error())
+	}
+
 	id, err := uuid.Parse(c.Params("id"))
 	if err != nil {
 		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
@@ -140,29 +147,17 @@ func (h *AnswerHandler) Update(c *fiber.Ctx) error {
 			Service: logmessages.LogAnswerHandler,
 			Message: err.Error(),
 		})
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return presenter.SendError(c,
-				fiber.StatusNotFound,
-				apperrors.ErrNotFound.Error(),
-			)
-		}
-		return presenter.SendError(c,
-			fiber.StatusInternalServerError,
-			apperrors.ErrInternalServerError.Error(),
-		)
+		return h.handleError(c, err)
 	}
 
 	updatedAnswer := request.ToDomain(answer)
-	err = h.answerService.Update(ctx, c.UserContext(), updatedAnswer)
+	err = h.answerService.Update(ctx, c.UserContext(), userID, updatedAnswer)
 	if err != nil {
 		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
 			Service: logmessages.LogAnswerHandler,
 			Message: err.Error(),
 		})
-		return presenter.SendError(c,
-			fiber.StatusInternalServerError,
-			apperrors.ErrInternalServerError.Error(),
-		)
+		return h.handleError(c, err)
 	}
 
 	return presenter.Send(c,
@@ -181,6 +176,15 @@ func (h *AnswerHandler) Delete(c *fiber.Ctx) error {
 		Message: logmessages.LogAnswerDeleteBegin,
 	})
 
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogAnswerHandler,
+			Message: logmessages.LogCastUserIdError,
+		})
+		return presenter.SendError(c, fiber.StatusUnauthorized, apperrors.ErrInvalidUserID.Error())
+	}
+
 	id, err := uuid.Parse(c.Params("id"))
 	if err != nil {
 		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
@@ -193,22 +197,13 @@ func (h *AnswerHandler) Delete(c *fiber.Ctx) error {
 		)
 	}
 
-	err = h.answerService.Delete(ctx, c.UserContext(), id)
+	err = h.answerService.Delete(ctx, c.UserContext(), userID, id)
 	if err != nil {
 		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
 			Service: logmessages.LogAnswerHandler,
 			Message: err.Error(),
 		})
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return presenter.SendError(c,
-				fiber.StatusNotFound,
-				apperrors.ErrNotFound.Error(),
-			)
-		}
-		return presenter.SendError(c,
-			fiber.StatusInternalServerError,
-			apperrors.ErrInternalServerError.Error(),
-		)
+		return h.handleError(c, err)
 	}
 
 	return presenter.Send(c,
@@ -265,7 +260,7 @@ func (h *AnswerHandler) GetByID(c *fiber.Ctx) error {
 		})
 		return presenter.SendError(c, fiber.StatusUnauthorized, apperrors.ErrInvalidUserID.Error())
 	}
-	if answer.UserSubmissionID != userID {
+	if answer.UserID != userID {
 		question, err := h.qustionService.GetByID(ctx, c.UserContext(), answer.QuestionID)
 		if err != nil {
 			logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
@@ -303,11 +298,7 @@ func (h *AnswerHandler) GetByID(c *fiber.Ctx) error {
 			}
 
 			if !hasPrvilege {
-				logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
-					Service: logmessages.LogQuestionnaireHandler,
-					Message: logmessages.LogLackOfAuthorization,
-				})
-				return presenter.SendError(c, fiber.StatusInternalServerError, apperrors.ErrLackOfAuthorization.Error())
+				return h.handleError(c, apperrors.ErrLackOfAuthorization)
 			}
 		}
 	}
@@ -320,3 +311,29 @@ func (h *AnswerHandler) GetByID(c *fiber.Ctx) error {
 		nil,
 	)
 }
+
+func (h *AnswerHandler) handleError(c *fiber.Ctx, err error) error {
+	var validationErr *model.AnswerValidationError
+	switch {
+	case errors.As(err, &validationErr):
+		return presenter.SendError(c, fiber.StatusUnprocessableEntity, err.Error())
+	case errors.Is(err, apperrors.ErrNotFound),
+		errors.Is(err, gorm.ErrRecordNotFound):
+		return presenter.SendError(c, fiber.StatusNotFound, apperrors.ErrNotFound.Error())
+	case errors.Is(err, apperrors.ErrLackOfAuthorization):
+		logForbidden(c, logmessages.LogAnswerHandler)
+		return presenter.SendError(c, fiber.StatusForbidden, err.Error())
+	case errors.Is(err, apperrors.ErrSubmissionNotInProgress),
+		errors.Is(err, apperrors.ErrQuestionTimeUp):
+		return presenter.SendError(c, fiber.StatusConflict, err.Error())
+	case errors.Is(err, apperrors.ErrQuestionnareExpired):
+		return presenter.SendError(c, fiber.StatusForbidden, err.Error())
+	case errors.Is(err, apperrors.ErrPageAnswerNotOnPage),
+		errors.Is(err, apperrors.ErrSubmissionNotFoundQuestion),
+		errors.Is(err, apperrors.ErrAnswerKindMismatch),
+		errors.Is(err, apperrors.ErrOptionNotFound):
+		return presenter.SendError(c, fiber.StatusBadRequest, err.Error())
+	default:
+		return presenter.SendError(c, fiber.StatusInternalServerError, apperrors.ErrInternalServerError.Error())
+	}
+}
@@ -10,9 +10,11 @@ import (
 
 type appContext struct {
 	context.Context
-	db           *gorm.DB
-	shouldCommit bool
-	logger       *slog.Logger
+	db            *gorm.DB
+	shouldCommit  bool
+	logger        *slog.Logger
+	afterCommit   []func()
+	afterRollback []func()
 }
 
 type AppContextOpt func(*appContext) *appContext // option pattern
@@ -60,13 +62,46 @@ func GetDB(ctx context.Context) *gorm.DB {
 	return appCtx.db
 }
 
+// OnCommit runs fn once the transaction of the context is committed and drops it
+// on rollback. Without a transaction to wait for, fn runs right away.
+func OnCommit(ctx context.Context, fn func()) {
+	appCtx, ok := ctx.(*appContext)
+	if !ok || !appCtx.shouldCommit || appCtx.db == nil {
+		fn()
+		return
+	}
+
+	appCtx.afterCommit = append(appCtx.afterCommit, fn)
+}
+
+// OnRollback runs fn once the transaction of the context is rolled back and drops
+// it on commit. Without a transaction there is nothing to roll back.
+func OnRollback(ctx context.Context, fn func()) {
+	appCtx, ok := ctx.(*appContext)
+	if !ok || !appCtx.shouldCommit || appCtx.db == nil {
+		return
+	}
+
+	appCtx.afterRollback = append(appCtx.afterRollback, fn)
+}
+
 func Commit(ctx context.Context) error {
 	appCtx, ok := ctx.(*appContext)
 	if !ok || !appCtx.shouldCommit {
 		return nil
 	}
 
-	return appCtx.db.Commit().Error
+	if err := appCtx.db.Commit().Error; err != nil {
+		return err
+	}
+
+	afterCommit := appCtx.afterCommit
+	appCtx.afterCommit = nil
+	appCtx.afterRollback = nil
+	for _, fn := range afterCommit {
+		fn()
+	}
+	return nil
 }
 
 func Rollback(ctx context.Context) error {
@@ -75,7 +110,15 @@ func Rollback(ctx context.Context) error {
 		return nil
 	}
 
-	return appCtx.db.Rollback().Error
+	appCtx.afterCommit = nil
+	err := appCtx.db.Rollback().Error
+
+	afterRollback := appCtx.afterRollback
+	appCtx.afterRollback = nil
+	for _, fn := range afterRollback {
+		fn()
+	}
+	return err
 }
 
 func CommitOrRollback(ctx context.Context, shouldLog bool) error {
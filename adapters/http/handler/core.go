@@ -4,10 +4,12 @@ import (
 	"errors"
 	"golizilla/adapters/http/handler/presenter"
 	"golizilla/adapters/persistence/logger"
+	"golizilla/core/domain/model"
 	"golizilla/core/service"
 	"golizilla/internal/apperrors"
 	"golizilla/internal/logmessages"
 	privilegeconstants "golizilla/internal/privilege"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
@@ -18,13 +20,15 @@ type CoreHandler struct {
 	coreService          service.ICoreService
 	roleService          service.IRoleService
 	questionnaireService service.IQuestionnaireService
+	translationService   service.ITranslationService
 }
 
-func NewCoreHandler(coreService service.ICoreService, roleService service.IRoleService, questionnaireService service.IQuestionnaireService) *CoreHandler {
+func NewCoreHandler(coreService service.ICoreService, roleService service.IRoleService, questionnaireService service.IQuestionnaireService, translationService service.ITranslationService) *CoreHandler {
 	return &CoreHandler{
 		coreService:          coreService,
 		roleService:          roleService,
 		questionnaireService: questionnaireService,
+		translationService:   translationService,
 	}
 }
 
@@ -102,7 +106,7 @@ func (h *CoreHandler) StartHandler(c *fiber.Ctx) error {
 	}
 
 	// Call core service to start questionnaire
-	submissionID, question, err := h.coreService.Start(ctx, c.UserContext(), req.UserID, req.QuestionnaireID)
+	submissionID, question, err := h.coreService.Start(ctx, c.UserContext(), req.UserID, req.QuestionnaireID, req.Variables)
 	if err != nil {
 		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
 			Service: logmessages.LogQuestionnaireHandler,
@@ -117,11 +121,41 @@ func (h *CoreHandler) StartHandler(c *fiber.Ctx) error {
 		if errors.Is(err, apperrors.ErrSubmissionLimit) {
 			return presenter.SendError(c, fiber.StatusForbidden, apperrors.ErrSubmissionLimit.Error())
 		}
+		if errors.Is(err, apperrors.ErrNotEligible) {
+			return presenter.SendError(c, fiber.StatusForbidden, err.Error())
+		}
+		return presenter.SendError(c, fiber.StatusInternalServerError, apperrors.ErrInternalServerError.Error())
+	}
+
+	// Translate the question for the respondent
+	if err := h.localizeQuestion(c, question); err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogQuestionnaireHandler,
+			Message: err.Error(),
+		})
+		return presenter.SendError(c, fiber.StatusInternalServerError, apperrors.ErrInternalServerError.Error())
+	}
+
+	// Pipe earlier answers and hidden variables into the translated texts
+	if err := h.coreService.RenderQuestion(ctx, c.UserContext(), req.UserID, submissionID, question); err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogQuestionnaireHandler,
+			Message: err.Error(),
+		})
+		return presenter.SendError(c, fiber.StatusInternalServerError, apperrors.ErrInternalServerError.Error())
+	}
+
+	remaining, err := h.coreService.TimeRemaining(ctx, c.UserContext(), submissionID, question)
+	if err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogQuestionnaireHandler,
+			Message: err.Error(),
+		})
 		return presenter.SendError(c, fiber.StatusInternalServerError, apperrors.ErrInternalServerError.Error())
 	}
 
 	// Prepare and send response
-	resp := presenter.NewStartResponse(submissionID, question)
+	resp := presenter.NewStartResponse(submissionID, question, remaining)
 	return presenter.Send(c, fiber.StatusOK, true, "Questionnaire started", resp, nil)
 }
 
@@ -172,6 +206,16 @@ func (h *CoreHandler) SubmitHandler(c *fiber.Ctx) error {
 		if err == apperrors.ErrSubmissionNotInProgress || err == apperrors.ErrSubmissionNoQuestion || err == apperrors.ErrSubmissionNotFoundQuestion {
 			return presenter.SendError(c, fiber.StatusNotFound, err.Error())
 		}
+		var validationErr *model.AnswerValidationError
+		if errors.As(err, &validationErr) {
+			return presenter.SendError(c, fiber.StatusUnprocessableEntity, validationErr.Error())
+		}
+		if errors.Is(err, apperrors.ErrAnswerKindMismatch) {
+			return presenter.SendError(c, fiber.StatusBadRequest, err.Error())
+		}
+		if errors.Is(err, apperrors.ErrQuestionTimeUp) {
+			return presenter.SendError(c, fiber.StatusConflict, err.Error())
+		}
 		return presenter.SendError(c, fiber.StatusInternalServerError, apperrors.ErrInternalServerError.Error())
 	}
 
@@ -182,6 +226,15 @@ func (h *CoreHandler) SubmitHandler(c *fiber.Ctx) error {
 func (h *CoreHandler) BackHandler(c *fiber.Ctx) error {
 	ctx := c.Context()
 
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogQuestionnaireHandler,
+			Message: "user_id not found in context",
+		})
+		return presenter.SendError(c, fiber.StatusUnauthorized, "Unauthorized: User ID missing")
+	}
+
 	// Parse and validate request
 	req := &presenter.NavigationRequest{}
 	if err := req.ParseAndValidate(c); err != nil {
@@ -189,20 +242,51 @@ func (h *CoreHandler) BackHandler(c *fiber.Ctx) error {
 	}
 
 	// Call service to move back
-	question, err := h.coreService.Back(ctx, c.UserContext(), req.SubmissionID)
+	question, err := h.coreService.Back(ctx, c.UserContext(), userID, req.SubmissionID)
 	if err != nil {
 		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
 			Service: logmessages.LogQuestionnaireHandler,
 			Message: err.Error(),
 		})
+		if errors.Is(err, apperrors.ErrLackOfAuthorization) {
+			return presenter.SendError(c, fiber.StatusForbidden, err.Error())
+		}
 		if errors.Is(err, apperrors.ErrBackIsNotAllowed) {
 			presenter.SendError(c, fiber.StatusMethodNotAllowed, apperrors.ErrBackIsNotAllowed.Error())
 		}
 		return presenter.SendError(c, fiber.StatusBadRequest, err.Error())
 	}
 
+	// Translate the question for the respondent
+	if err := h.localizeQuestion(c, question); err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogQuestionnaireHandler,
+			Message: err.Error(),
+		})
+		return presenter.SendError(c, fiber.StatusInternalServerError, apperrors.ErrInternalServerError.Error())
+	}
+
+	// Pipe earlier answers and hidden variables into the translated texts
+	if err := h.coreService.RenderQuestion(ctx, c.UserContext(), userID, req.SubmissionID, question); err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogQuestionnaireHandler,
+			Message: err.Error(),
+		})
+		return presenter.SendError(c, fiber.StatusInternalServerError, apperrors.ErrInternalServerError.Error())
+	}
+
+	remaining, err := h.coreService.TimeRemaining(ctx, c.UserContext(), req.SubmissionID, question)
+	if err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogQuestionnaireHandler,
+			Message: err.Error(),
+		})
+		return presenter.SendError(c, fiber.StatusInternalServerError, apperrors.ErrInternalServerError.Error())
+	}
+
 	// Prepare and send response
 	resp := presenter.NewGetQuestionResponse(question)
+	resp.SetTimeRemaining(remaining)
 	return presenter.Send(c, fiber.StatusOK, true, "Moved back successfully", resp, nil)
 }
 
@@ -210,6 +294,15 @@ func (h *CoreHandler) BackHandler(c *fiber.Ctx) error {
 func (h *CoreHandler) NextHandler(c *fiber.Ctx) error {
 	ctx := c.Context()
 
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogQuestionnaireHandler,
+			Message: "user_id not found in context",
+		})
+		return presenter.SendError(c, fiber.StatusUnauthorized, "Unauthorized: User ID missing")
+	}
+
 	// Parse and validate request
 	req := &presenter.NavigationRequest{}
 	if err := req.ParseAndValidate(c); err != nil {
@@ -217,17 +310,48 @@ func (h *CoreHandler) NextHandler(c *fiber.Ctx) error {
 	}
 
 	// Call service to move next
-	question, err := h.coreService.Next(ctx, c.UserContext(), req.SubmissionID)
+	question, err := h.coreService.Next(ctx, c.UserContext(), userID, req.SubmissionID)
 	if err != nil {
 		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
 			Service: logmessages.LogQuestionnaireHandler,
 			Message: err.Error(),
 		})
+		if errors.Is(err, apperrors.ErrLackOfAuthorization) {
+			return presenter.SendError(c, fiber.StatusForbidden, err.Error())
+		}
 		return presenter.SendError(c, fiber.StatusBadRequest, err.Error())
 	}
 
+	// Translate the question for the respondent
+	if err := h.localizeQuestion(c, question); err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogQuestionnaireHandler,
+			Message: err.Error(),
+		})
+		return presenter.SendError(c, fiber.StatusInternalServerError, apperrors.ErrInternalServerError.Error())
+	}
+
+	// Pipe earlier answers and hidden variables into the translated texts
+	if err := h.coreService.RenderQuestion(ctx, c.UserContext(), userID, req.SubmissionID, question); err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogQuestionnaireHandler,
+			Message: err.Error(),
+		})
+		return presenter.SendError(c, fiber.StatusInternalServerError, apperrors.ErrInternalServerError.Error())
+	}
+
+	remaining, err := h.coreService.TimeRemaining(ctx, c.UserContext(), req.SubmissionID, question)
+	if err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogQuestionnaireHandler,
+			Message: err.Error(),
+		})
+		return presenter.SendError(c, fiber.StatusInternalServerError, apperrors.ErrInternalServerError.Error())
+	}
+
 	// Prepare and send response
 	resp := presenter.NewGetQuestionResponse(question)
+	resp.SetTimeRemaining(remaining)
 	return presenter.Send(c, fiber.StatusOK, true, "Moved to next question", resp, nil)
 }
 
@@ -252,3 +376,399 @@ func (h *CoreHandler) EndHandler(c *fiber.Ctx) error {
 
 	return presenter.Send(c, fiber.StatusOK, true, "Questionnaire ended successfully", nil, nil)
 }
+
+// SubmitFileHandler answers a file upload question with a multipart form holding
+// submission_id, question_id and file.
+func (h *CoreHandler) SubmitFileHandler(c *fiber.Ctx) error {
+	ctx := c.Context()
+	logger.GetLogger().LogInfoFromContext(ctx, logger.LogFields{
+		Service: logmessages.LogQuestionnaireHandler,
+		Message: "submitting a file answer",
+	})
+
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		return presenter.SendError(c, fiber.StatusUnauthorized, "Unauthorized: User ID missing")
+	}
+
+	req := &presenter.SubmitFileRequest{}
+	if err := req.ParseAndValidate(c); err != nil {
+		return presenter.SendError(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	if err := h.coreService.CheckExpire(ctx, c.UserContext(), req.SubmissionID); err != nil {
+		if errors.Is(err, apperrors.ErrQuestionnareExpired) {
+			return presenter.SendError(c, fiber.StatusForbidden, apperrors.ErrQuestionnareExpired.Error())
+		}
+		return presenter.SendError(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	file, err := req.File.Open()
+	if err != nil {
+		return presenter.SendError(c, fiber.StatusBadRequest, apperrors.ErrInvalidInput.Error())
+	}
+	defer file.Close()
+
+	err = h.coreService.SubmitFile(ctx, c.UserContext(), userID, req.SubmissionID, req.QuestionID, req.File.Filename, req.File.Size, file)
+	if err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogQuestionnaireHandler,
+			Message: err.Error(),
+		})
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return presenter.SendError(c, fiber.StatusNotFound, apperrors.ErrNotFound.Error())
+		case errors.Is(err, apperrors.ErrSubmissionNotInProgress),
+			errors.Is(err, apperrors.ErrSubmissionNoQuestion),
+			errors.Is(err, apperrors.ErrSubmissionNotFoundQuestion):
+			return presenter.SendError(c, fiber.StatusNotFound, err.Error())
+		case errors.Is(err, apperrors.ErrLackOfAuthorization):
+			return presenter.SendError(c, fiber.StatusForbidden, err.Error())
+		case errors.Is(err, apperrors.ErrAnswerKindMismatch),
+			errors.Is(err, apperrors.ErrFileInfected):
+			return presenter.SendError(c, fiber.StatusBadRequest, err.Error())
+		case errors.Is(err, apperrors.ErrQuestionTimeUp):
+			return presenter.SendError(c, fiber.StatusConflict, err.Error())
+		case errors.Is(err, apperrors.ErrFileTooLarge):
+			return presenter.SendError(c, fiber.StatusRequestEntityTooLarge, err.Error())
+		case errors.Is(err, apperrors.ErrUnsupportedMediaType):
+			return presenter.SendError(c, fiber.StatusUnsupportedMediaType, err.Error())
+		}
+		return presenter.SendError(c, fiber.StatusInternalServerError, apperrors.ErrInternalServerError.Error())
+	}
+
+	return presenter.Send(c, fiber.StatusOK, true, "File submitted successfully", nil, nil)
+}
+
+// DeleteSubmissionHandler deletes a submission with all its answers and files.
+func (h *CoreHandler) DeleteSubmissionHandler(c *fiber.Ctx) error {
+	ctx := c.Context()
+
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		return presenter.SendError(c, fiber.StatusUnauthorized, "Unauthorized: User ID missing")
+	}
+
+	submissionID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return presenter.SendError(c, fiber.StatusBadRequest, "invalid submission id format")
+	}
+
+	if err := h.coreService.DeleteSubmission(ctx, c.UserContext(), userID, submissionID); err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogQuestionnaireHandler,
+			Message: err.Error(),
+		})
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound),
+			errors.Is(err, apperrors.ErrQuestionnaireNotFound):
+			return presenter.SendError(c, fiber.StatusNotFound, apperrors.ErrNotFound.Error())
+		case errors.Is(err, apperrors.ErrLackOfAuthorization):
+			return presenter.SendError(c, fiber.StatusForbidden, err.Error())
+		}
+		return presenter.SendError(c, fiber.StatusInternalServerError, apperrors.ErrInternalServerError.Error())
+	}
+
+	return presenter.Send(c, fiber.StatusOK, true, "Submission deleted successfully", nil, nil)
+}
+
+// SubmitOfflineHandler stores a complete answer set recorded by an offline client.
+// Sending the same submission_id again returns the stored submission.
+func (h *CoreHandler) SubmitOfflineHandler(c *fiber.Ctx) error {
+	ctx := c.Context()
+	logger.GetLogger().LogInfoFromContext(ctx, logger.LogFields{
+		Service: logmessages.LogQuestionnaireHandler,
+		Message: "submitting offline answers",
+	})
+
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogQuestionnaireHandler,
+			Message: "user_id not found in context",
+		})
+		return presenter.SendError(c, fiber.StatusUnauthorized, "Unauthorized: User ID missing")
+	}
+
+	req := &presenter.OfflineSubmitRequest{}
+	if err := req.ParseAndValidate(c); err != nil {
+		return presenter.SendError(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	isAnonymous, err := h.questionnaireService.IsQuestionnaireAnonymous(ctx, c.UserContext(), req.QuestionnaireID)
+	if err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogQuestionnaireHandler,
+			Message: err.Error(),
+		})
+		if errors.Is(err, apperrors.ErrQuestionnaireNotFound) {
+			return presenter.SendError(c, fiber.StatusNotFound, apperrors.ErrQuestionnaireNotFound.Error())
+		}
+		return presenter.SendError(c, fiber.StatusInternalServerError, apperrors.ErrInternalServerError.Error())
+	}
+	if isAnonymous {
+		hasPrivilege, err := h.roleService.HasPrivilegesOnInsance(ctx, c.UserContext(), userID, req.QuestionnaireID, privilegeconstants.StartQuestionnariInsance)
+		if err != nil {
+			logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+				Service: logmessages.LogQuestionnaireHandler,
+				Message: err.Error(),
+			})
+			return presenter.SendError(c, fiber.StatusInternalServerError, apperrors.ErrInternalServerError.Error())
+		}
+		if !hasPrivilege {
+			return presenter.SendError(c, fiber.StatusForbidden, apperrors.ErrLackOfAuthorization.Error())
+		}
+	}
+
+	offline := &service.OfflineSubmission{
+		ID:              req.SubmissionID,
+		QuestionnaireID: req.QuestionnaireID,
+		StartedAt:       req.StartedAt,
+		FinishedAt:      req.FinishedAt,
+		Answers:         req.AnswersToDomain(),
+		Variables:       req.Variables,
+	}
+	submission, replayed, err := h.coreService.SubmitOffline(ctx, c.UserContext(), userID, offline)
+	if err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogQuestionnaireHandler,
+			Message: err.Error(),
+		})
+		var validationErr *model.AnswerValidationError
+		switch {
+		case errors.As(err, &validationErr):
+			return presenter.SendError(c, fiber.StatusUnprocessableEntity, err.Error())
+		case errors.Is(err, apperrors.ErrSubmissionIDConflict):
+			return presenter.SendError(c, fiber.StatusConflict, apperrors.ErrSubmissionIDConflict.Error())
+		case errors.Is(err, apperrors.ErrQuestionnaireNotFound):
+			return presenter.SendError(c, fiber.StatusNotFound, err.Error())
+		case errors.Is(err, apperrors.ErrOfflineTimeWindow),
+			errors.Is(err, apperrors.ErrQuestionnaireArchived),
+			errors.Is(err, apperrors.ErrSubmissionLimit),
+			errors.Is(err, apperrors.ErrNotEligible):
+			return presenter.SendError(c, fiber.StatusForbidden, err.Error())
+		case errors.Is(err, apperrors.ErrOfflineAnswerOrder),
+			errors.Is(err, apperrors.ErrOfflinePoolsUnsupported),
+			errors.Is(err, apperrors.ErrRequiredQuestionUnanswered),
+			errors.Is(err, apperrors.ErrQuestionNotInQuestionnaire),
+			errors.Is(err, apperrors.ErrQuestionsNotFound),
+			errors.Is(err, apperrors.ErrAnswerKindMismatch),
+			errors.Is(err, apperrors.ErrOptionNotFound):
+			return presenter.SendError(c, fiber.StatusBadRequest, err.Error())
+		default:
+			return presenter.SendError(c, fiber.StatusInternalServerError, apperrors.ErrInternalServerError.Error())
+		}
+	}
+
+	if replayed {
+		return presenter.Send(c, fiber.StatusOK, true, "Submission was already stored", presenter.NewOfflineSubmitResponse(submission, true), nil)
+	}
+	return presenter.Send(c, fiber.StatusCreated, true, "Answers submitted successfully", presenter.NewOfflineSubmitResponse(submission, false), nil)
+}
+
+// GetPageHandler returns the page the respondent is currently on.
+func (h *CoreHandler) GetPageHandler(c *fiber.Ctx) error {
+	ctx := c.Context()
+
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogQuestionnaireHandler,
+			Message: "user_id not found in context",
+		})
+		return presenter.SendError(c, fiber.StatusUnauthorized, "Unauthorized: User ID missing")
+	}
+
+	submissionID, err := uuid.Parse(c.Params("submission_id"))
+	if err != nil {
+		return presenter.SendError(c, fiber.StatusBadRequest, "invalid submission_id format")
+	}
+
+	page, err := h.coreService.GetPage(ctx, c.UserContext(), userID, submissionID)
+	if err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogQuestionnaireHandler,
+			Message: err.Error(),
+		})
+		return h.handlePageError(c, err)
+	}
+
+	return h.sendPage(c, userID, submissionID, page, "Page fetched successfully")
+}
+
+// SubmitPageHandler answers all questions of the current page at once.
+func (h *CoreHandler) SubmitPageHandler(c *fiber.Ctx) error {
+	ctx := c.Context()
+	logger.GetLogger().LogInfoFromContext(ctx, logger.LogFields{
+		Service: logmessages.LogQuestionnaireHandler,
+		Message: "submitting a page",
+	})
+
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogQuestionnaireHandler,
+			Message: "user_id not found in context",
+		})
+		return presenter.SendError(c, fiber.StatusUnauthorized, "Unauthorized: User ID missing")
+	}
+
+	req := &presenter.SubmitPageRequest{}
+	if err := req.ParseAndValidate(c); err != nil {
+		return presenter.SendError(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	if err := h.coreService.CheckExpire(ctx, c.UserContext(), req.SubmissionID); err != nil {
+		if errors.Is(err, apperrors.ErrQuestionnareExpired) {
+			return presenter.SendError(c, fiber.StatusForbidden, apperrors.ErrQuestionnareExpired.Error())
+		}
+		return presenter.SendError(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	if err := h.coreService.SubmitPage(ctx, c.UserContext(), userID, req.SubmissionID, req.ToDomain()); err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogQuestionnaireHandler,
+			Message: err.Error(),
+		})
+		return h.handlePageError(c, err)
+	}
+
+	return presenter.Send(c, fiber.StatusOK, true, "Page submitted successfully", nil, nil)
+}
+
+// NextPageHandler moves to the next page, following the branching of the answers.
+func (h *CoreHandler) NextPageHandler(c *fiber.Ctx) error {
+	ctx := c.Context()
+
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogQuestionnaireHandler,
+			Message: "user_id not found in context",
+		})
+		return presenter.SendError(c, fiber.StatusUnauthorized, "Unauthorized: User ID missing")
+	}
+
+	req := &presenter.NavigationRequest{}
+	if err := req.ParseAndValidate(c); err != nil {
+		return presenter.SendError(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	page, err := h.coreService.NextPage(ctx, c.UserContext(), userID, req.SubmissionID)
+	if err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogQuestionnaireHandler,
+			Message: err.Error(),
+		})
+		return h.handlePageError(c, err)
+	}
+
+	return h.sendPage(c, userID, req.SubmissionID, page, "Moved to next page")
+}
+
+// BackPageHandler returns to the previously seen page (if allowed).
+func (h *CoreHandler) BackPageHandler(c *fiber.Ctx) error {
+	ctx := c.Context()
+
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogQuestionnaireHandler,
+			Message: "user_id not found in context",
+		})
+		return presenter.SendError(c, fiber.StatusUnauthorized, "Unauthorized: User ID missing")
+	}
+
+	req := &presenter.NavigationRequest{}
+	if err := req.ParseAndValidate(c); err != nil {
+		return presenter.SendError(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	page, err := h.coreService.BackPage(ctx, c.UserContext(), userID, req.SubmissionID)
+	if err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogQuestionnaireHandler,
+			Message: err.Error(),
+		})
+		return h.handlePageError(c, err)
+	}
+
+	return h.sendPage(c, userID, req.SubmissionID, page, "Moved back successfully")
+}
+
+// sendPage translates and pipes the questions of the page and sends them with the
+// time left on each.
+func (h *CoreHandler) sendPage(c *fiber.Ctx, userID, submissionID uuid.UUID, page *service.Page, message string) error {
+	ctx := c.Context()
+
+	timeRemaining := make(map[uuid.UUID]*time.Duration, len(page.Questions))
+	for _, question := range page.Questions {
+		if err := h.localizeQuestion(c, question); err != nil {
+			logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+				Service: logmessages.LogQuestionnaireHandler,
+				Message: err.Error(),
+			})
+			return presenter.SendError(c, fiber.StatusInternalServerError, apperrors.ErrInternalServerError.Error())
+		}
+		if err := h.coreService.RenderQuestion(ctx, c.UserContext(), userID, submissionID, question); err != nil {
+			logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+				Service: logmessages.LogQuestionnaireHandler,
+				Message: err.Error(),
+			})
+			return presenter.SendError(c, fiber.StatusInternalServerError, apperrors.ErrInternalServerError.Error())
+		}
+
+		remaining, err := h.coreService.TimeRemaining(ctx, c.UserContext(), submissionID, question)
+		if err != nil {
+			logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+				Service: logmessages.LogQuestionnaireHandler,
+				Message: err.Error(),
+			})
+			return presenter.SendError(c, fiber.StatusInternalServerError, apperrors.ErrInternalServerError.Error())
+		}
+		timeRemaining[question.ID] = remaining
+	}
+
+	resp := presenter.NewPageResponse(submissionID, page.Number, page.Total, page.Section, page.Questions, timeRemaining)
+	return presenter.Send(c, fiber.StatusOK, true, message, resp, nil)
+}
+
+func (h *CoreHandler) handlePageError(c *fiber.Ctx, err error) error {
+	var validationErr *model.AnswerValidationError
+	switch {
+	case errors.As(err, &validationErr):
+		return presenter.SendError(c, fiber.StatusUnprocessableEntity, err.Error())
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return presenter.SendError(c, fiber.StatusNotFound, apperrors.ErrNotFound.Error())
+	case errors.Is(err, apperrors.ErrSubmissionNotInProgress),
+		errors.Is(err, apperrors.ErrSubmissionNoQuestion),
+		errors.Is(err, apperrors.ErrSubmissionNotFoundQuestion):
+		return presenter.SendError(c, fiber.StatusNotFound, err.Error())
+	case errors.Is(err, apperrors.ErrAnswerKindMismatch),
+		errors.Is(err, apperrors.ErrPageAnswerNotOnPage),
+		errors.Is(err, apperrors.ErrOptionNotFound),
+		errors.Is(err, apperrors.ErrNoMorePages),
+		errors.Is(err, apperrors.ErrRequiredQuestionUnanswered):
+		return presenter.SendError(c, fiber.StatusBadRequest, err.Error())
+	case errors.Is(err, apperrors.ErrQuestionTimeUp):
+		return presenter.SendError(c, fiber.StatusConflict, err.Error())
+	case errors.Is(err, apperrors.ErrLackOfAuthorization):
+		return presenter.SendError(c, fiber.StatusForbidden, err.Error())
+	case errors.Is(err, apperrors.ErrBackIsNotAllowed):
+		return presenter.SendError(c, fiber.StatusMethodNotAllowed, err.Error())
+	default:
+		return presenter.SendError(c, fiber.StatusInternalServerError, apperrors.ErrInternalServerError.Error())
+	}
+}
+
+// localizeQuestion translates the question into the respondent's language, picked
+// from the profile preference or Accept-Language, and sets Content-Language.
+func (h *CoreHandler) localizeQuestion(c *fiber.Ctx, question *model.Question) error {
+	userID, _ := c.Locals("user_id").(uuid.UUID)
+	locale, err := h.translationService.LocalizeQuestion(c.Context(), c.UserContext(), userID, question, c.Get(fiber.HeaderAcceptLanguage))
+	if err != nil {
+		return err
+	}
+	c.Set(fiber.HeaderContentLanguage, locale)
+	return nil
+}
@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"golizilla/adapters/http/handler/presenter"
+	"golizilla/adapters/payment"
+	"golizilla/adapters/persistence/logger"
+	"golizilla/core/port/gateway"
+	"golizilla/core/service"
+	"golizilla/internal/apperrors"
+	logmessages "golizilla/internal/logmessages"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// FakePaymentHandler stands in for the checkout page of a real provider. It is only
+// mounted when the fake gateway is configured outside production.
+type FakePaymentHandler struct {
+	gateway       *payment.FakeGateway
+	walletService service.IWalletService
+}
+
+func NewFakePaymentHandler(gateway *payment.FakeGateway, walletService service.IWalletService) *FakePaymentHandler {
+	return &FakePaymentHandler{
+		gateway:       gateway,
+		walletService: walletService,
+	}
+}
+
+// Checkout completes a pending top-up. Pass ?status=failed to simulate a declined payment.
+func (h *FakePaymentHandler) Checkout(c *fiber.Ctx) error {
+	ctx := c.Context()
+
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		return presenter.SendError(c, fiber.StatusUnauthorized, apperrors.ErrInvalidUserID.Error())
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return presenter.SendError(c, fiber.StatusBadRequest, apperrors.ErrInvalidInput.Error())
+	}
+
+	intent, err := h.walletService.GetTopUp(ctx, c.UserContext(), userID, id)
+	if err != nil {
+		return presenter.SendError(c, fiber.StatusNotFound, err.Error())
+	}
+
+	status := gateway.PaymentStatusSucceeded
+	if c.Query("status") == string(gateway.PaymentStatusFailed) {
+		status = gateway.PaymentStatusFailed
+	}
+
+	payload, signature, err := h.gateway.SimulateCallback(gateway.PaymentCallback{
+		Reference: intent.Reference,
+		IntentID:  intent.ID,
+		Amount:    intent.Amount,
+		Status:    status,
+	})
+	if err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogWalletHandler,
+			Message: err.Error(),
+		})
+		return presenter.SendError(c, fiber.StatusInternalServerError, apperrors.ErrInternalServerError.Error())
+	}
+
+	intent, err = h.walletService.HandlePaymentCallback(ctx, c.UserContext(), payload, signature)
+	if err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogWalletHandler,
+			Message: err.Error(),
+		})
+		return presenter.SendError(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	return presenter.Send(c, fiber.StatusOK, true, "Fake payment processed", presenter.NewTopUpResponse(intent), nil)
+}
@@ -1 +1,56 @@
 package presenter
+
+import (
+	"golizilla/core/domain/model"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AdminUserResponse is the account overview shown to admins. Credentials,
+// verification and 2FA codes are never part of it.
+type AdminUserResponse struct {
+	ID             uuid.UUID  `json:"id"`
+	Username       string     `json:"username"`
+	Email          string     `json:"email"`
+	FirstName      string     `json:"first_name"`
+	LastName       string     `json:"last_name"`
+	City           string     `json:"city"`
+	IsActive       bool       `json:"is_active"`
+	IsTwoFAEnabled bool       `json:"is_two_fa_enabled"`
+	AccountLocked  bool       `json:"account_locked"`
+	RoleID         uuid.UUID  `json:"role_id"`
+	CreatedAt      time.Time  `json:"created_at"`
+	AnonymizedAt   *time.Time `json:"anonymized_at,omitempty"`
+}
+
+type PaginatedAdminUsersResponse struct {
+	Data  []AdminUserResponse `json:"data"`
+	Pages int                 `json:"pages"`
+	Page  int                 `json:"page"`
+}
+
+func NewPaginatedAdminUsersResponse(users []model.User, pages, page int) PaginatedAdminUsersResponse {
+	data := make([]AdminUserResponse, len(users))
+	for i, user := range users {
+		data[i] = AdminUserResponse{
+			ID:             user.ID,
+			Username:       user.Username,
+			Email:          user.Email,
+			FirstName:      user.FirstName,
+			LastName:       user.LastName,
+			City:           user.City,
+			IsActive:       user.IsActive,
+			IsTwoFAEnabled: user.IsTwoFAEnabled,
+			AccountLocked:  user.AccountLocked,
+			RoleID:         user.RoleId,
+			CreatedAt:      user.CreatedAt,
+			AnonymizedAt:   user.AnonymizedAt,
+		}
+	}
+	return PaginatedAdminUsersResponse{
+		Data:  data,
+		Pages: pages,
+		Page:  page,
+	}
+}
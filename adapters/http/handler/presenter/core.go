@@ -2,16 +2,27 @@ package presenter
 
 import (
 	"errors"
+	"fmt"
 	"golizilla/core/domain/model"
+	"mime/multipart"
+	"time"
+	"unicode/utf8"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 )
 
+// Limits on the hidden variables passed in the query string of start.
+const (
+	maxStartVariables     = 20
+	maxVariableValueRunes = 255
+)
+
 // StartRequest represents the request structure for starting a questionnaire.
 type StartRequest struct {
 	QuestionnaireID uuid.UUID
 	UserID          uuid.UUID
+	Variables       map[string]string // hidden variables, e.g. ?campaign=spring
 }
 
 func (r *StartRequest) ParseAndValidate(c *fiber.Ctx) error {
@@ -23,8 +34,28 @@ func (r *StartRequest) ParseAndValidate(c *fiber.Ctx) error {
 	if !ok {
 		return errors.New("user_id not found or invalid")
 	}
+	variables := c.Queries()
+	if err := validateVariables(variables); err != nil {
+		return err
+	}
 	r.QuestionnaireID = qID
 	r.UserID = userID
+	r.Variables = variables
+	return nil
+}
+
+func validateVariables(variables map[string]string) error {
+	if len(variables) > maxStartVariables {
+		return fmt.Errorf("at most %d hidden variables are allowed", maxStartVariables)
+	}
+	for name, value := range variables {
+		if !model.IsValidVariableName(name) {
+			return fmt.Errorf("invalid hidden variable name %q", name)
+		}
+		if utf8.RuneCountInString(value) > maxVariableValueRunes {
+			return fmt.Errorf("hidden variable %q is longer than %d characters", name, maxVariableValueRunes)
+		}
+	}
 	return nil
 }
 
@@ -71,6 +102,33 @@ func (r *SubmitRequest) ToDomain() *model.Answer {
 	}
 }
 
+// SubmitFileRequest represents the multipart form for answering a file upload question.
+type SubmitFileRequest struct {
+	SubmissionID uuid.UUID
+	QuestionID   uuid.UUID
+	File         *multipart.FileHeader
+}
+
+func (r *SubmitFileRequest) ParseAndValidate(c *fiber.Ctx) error {
+	sID, err := uuid.Parse(c.FormValue("submission_id"))
+	if err != nil {
+		return errors.New("invalid submission_id format")
+	}
+	qID, err := uuid.Parse(c.FormValue("question_id"))
+	if err != nil {
+		return errors.New("invalid question_id format")
+	}
+	file, err := c.FormFile("file")
+	if err != nil {
+		return errors.New("file is required")
+	}
+
+	r.SubmissionID = sID
+	r.QuestionID = qID
+	r.File = file
+	return nil
+}
+
 // NavigationRequest represents a request to navigate within a submission.
 type NavigationRequest struct {
 	SubmissionID uuid.UUID
@@ -116,14 +174,162 @@ func (r *EndRequest) ParseAndValidate(c *fiber.Ctx) error {
 }
 
 // StartResponse prepares the response structure for starting a questionnaire.
-func NewStartResponse(submissionID uuid.UUID, question *model.Question) map[string]interface{} {
+func NewStartResponse(submissionID uuid.UUID, question *model.Question, timeRemaining *time.Duration) map[string]interface{} {
 	return map[string]interface{}{
-		"submission_id":    submissionID,
-		"id":               question.ID,
-		"questionnaire_id": question.QuestionnaireId,
-		"index":            question.Index,
-		"question_text":    question.QuestionText,
-		"descriptive":      question.Descriptive,
-		"options":          question.Options,
+		"submission_id":     submissionID,
+		"id":                question.ID,
+		"questionnaire_id":  question.QuestionnaireId,
+		"index":             question.Index,
+		"question_text":     question.QuestionText,
+		"descriptive":       question.Descriptive,
+		"file_upload":       question.FileUpload,
+		"answer_rules":      newAnswerRulesResponse(question.AnswerRules),
+		"time_limit":        question.TimeLimit,
+		"time_limit_action": question.TimeLimitAction,
+		"time_remaining":    timeRemainingSeconds(timeRemaining),
+		"options":           question.Options,
+	}
+}
+
+// SubmitPageRequest holds the answers to the questions of the current page.
+type SubmitPageRequest struct {
+	SubmissionID uuid.UUID           `json:"submission_id"`
+	Answers      []PageAnswerRequest `json:"answers"`
+}
+
+type PageAnswerRequest struct {
+	QuestionID  uuid.UUID  `json:"question_id"`
+	Descriptive bool       `json:"descriptive"`
+	Text        *string    `json:"text,omitempty"`
+	OptionID    *uuid.UUID `json:"option_id,omitempty"`
+}
+
+func (r *SubmitPageRequest) ParseAndValidate(c *fiber.Ctx) error {
+	if err := c.BodyParser(r); err != nil {
+		return errors.New("invalid request format")
+	}
+	if r.SubmissionID == uuid.Nil {
+		return errors.New("submission_id is required")
+	}
+	return validatePageAnswers(r.Answers)
+}
+
+func (r *SubmitPageRequest) ToDomain() []*model.Answer {
+	return pageAnswersToDomain(r.Answers)
+}
+
+func validatePageAnswers(answers []PageAnswerRequest) error {
+	if len(answers) == 0 {
+		return errors.New("answers cannot be empty")
+	}
+	for _, answer := range answers {
+		if answer.QuestionID == uuid.Nil {
+			return errors.New("question_id is required")
+		}
+		if !answer.Descriptive && answer.OptionID == nil {
+			return errors.New("option_id is required for non-descriptive answers")
+		}
+	}
+	return nil
+}
+
+func pageAnswersToDomain(requests []PageAnswerRequest) []*model.Answer {
+	answers := make([]*model.Answer, len(requests))
+	for i, answer := range requests {
+		answers[i] = &model.Answer{
+			QuestionID:  answer.QuestionID,
+			Descriptive: answer.Descriptive,
+			Text:        answer.Text,
+			OptionID:    answer.OptionID,
+		}
+	}
+	return answers
+}
+
+// OfflineSubmitRequest is a complete answer set recorded by a client without a
+// connection. submission_id is generated by the client and makes retries safe,
+// answers are listed in the order they were given.
+type OfflineSubmitRequest struct {
+	SubmissionID    uuid.UUID           `json:"submission_id"`
+	QuestionnaireID uuid.UUID           `json:"questionnaire_id"`
+	StartedAt       time.Time           `json:"started_at"`
+	FinishedAt      time.Time           `json:"finished_at"`
+	Answers         []PageAnswerRequest `json:"answers"`
+	Variables       map[string]string   `json:"variables,omitempty"`
+}
+
+func (r *OfflineSubmitRequest) ParseAndValidate(c *fiber.Ctx) error {
+	if err := c.BodyParser(r); err != nil {
+		return errors.New("invalid request format")
+	}
+	if r.SubmissionID == uuid.Nil {
+		return errors.New("submission_id is required")
+	}
+	if r.QuestionnaireID == uuid.Nil {
+		return errors.New("questionnaire_id is required")
+	}
+	if r.StartedAt.IsZero() || r.FinishedAt.IsZero() {
+		return errors.New("started_at and finished_at are required")
+	}
+	if err := validateVariables(r.Variables); err != nil {
+		return err
+	}
+	return validatePageAnswers(r.Answers)
+}
+
+func (r *OfflineSubmitRequest) AnswersToDomain() []*model.Answer {
+	return pageAnswersToDomain(r.Answers)
+}
+
+type OfflineSubmitResponse struct {
+	SubmissionID uuid.UUID              `json:"submission_id"`
+	Status       model.SubmissionStatus `json:"status"`
+	Answers      int                    `json:"answers"`
+	Replayed     bool                   `json:"replayed"` // the submission was stored by an earlier request
+}
+
+func NewOfflineSubmitResponse(submission *model.UserSubmission, replayed bool) *OfflineSubmitResponse {
+	return &OfflineSubmitResponse{
+		SubmissionID: submission.ID,
+		Status:       submission.Status,
+		Answers:      len(submission.Answers),
+		Replayed:     replayed,
+	}
+}
+
+type PageSectionResponse struct {
+	ID          uuid.UUID `json:"id"`
+	Title       string    `json:"title"`
+	Description string    `json:"description,omitempty"`
+}
+
+type PageResponse struct {
+	SubmissionID uuid.UUID              `json:"submission_id"`
+	Number       int                    `json:"number"`
+	Total        int                    `json:"total"`
+	Section      *PageSectionResponse   `json:"section,omitempty"`
+	Questions    []*GetQuestionResponse `json:"questions"`
+}
+
+// NewPageResponse builds the response for a page, timeRemaining holds the time left
+// on each question with a time limit.
+func NewPageResponse(submissionID uuid.UUID, number, total int, section *model.Section, questions []*model.Question, timeRemaining map[uuid.UUID]*time.Duration) *PageResponse {
+	response := &PageResponse{
+		SubmissionID: submissionID,
+		Number:       number,
+		Total:        total,
+		Questions:    make([]*GetQuestionResponse, len(questions)),
+	}
+	if section != nil {
+		response.Section = &PageSectionResponse{
+			ID:          section.ID,
+			Title:       section.Title,
+			Description: section.Description,
+		}
+	}
+	for i, question := range questions {
+		response.Questions[i] = NewGetQuestionResponse(question)
+		response.Questions[i].SetTimeRemaining(timeRemaining[question.ID])
 	}
+	return response
 }
@@ -0,0 +1,21 @@
+package presenter
+
+import "golizilla/core/domain/model"
+
+type PrivilegeResponse struct {
+	ID               string `json:"id"`
+	Description      string `json:"description"`
+	CanSetOnInstance bool   `json:"can_set_on_instance"`
+}
+
+func NewPrivilegesResponse(privileges []model.Privilege) []PrivilegeResponse {
+	data := make([]PrivilegeResponse, len(privileges))
+	for i, privilege := range privileges {
+		data[i] = PrivilegeResponse{
+			ID:               privilege.Id,
+			Description:      privilege.Description,
+			CanSetOnInstance: privilege.CanSetOnQuestionnaire,
+		}
+	}
+	return data
+}
@@ -0,0 +1,48 @@
+package presenter
+
+import (
+	"golizilla/core/domain/model"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PrivilegeAuditLogResponse struct {
+	ID              uuid.UUID  `json:"id"`
+	QuestionnaireID uuid.UUID  `json:"questionnaire_id"`
+	ActorID         uuid.UUID  `json:"actor_id"`
+	TargetUserID    *uuid.UUID `json:"target_user_id,omitempty"`
+	TargetRoleID    *uuid.UUID `json:"target_role_id,omitempty"`
+	Privilege       string     `json:"privilege"`
+	Action          string     `json:"action"`
+	TraceID         string     `json:"trace_id,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+type PaginatedPrivilegeAuditLogsResponse struct {
+	Data  []PrivilegeAuditLogResponse `json:"data"`
+	Pages int                         `json:"pages"`
+	Page  int                         `json:"page"`
+}
+
+func NewPaginatedPrivilegeAuditLogsResponse(auditLogs []model.PrivilegeAuditLog, pages, page int) PaginatedPrivilegeAuditLogsResponse {
+	data := make([]PrivilegeAuditLogResponse, len(auditLogs))
+	for i, auditLog := range auditLogs {
+		data[i] = PrivilegeAuditLogResponse{
+			ID:              auditLog.ID,
+			QuestionnaireID: auditLog.QuestionnaireId,
+			ActorID:         auditLog.ActorId,
+			TargetUserID:    auditLog.TargetUserId,
+			TargetRoleID:    auditLog.TargetRoleId,
+			Privilege:       auditLog.Privilege,
+			Action:          string(auditLog.Action),
+			TraceID:         auditLog.TraceID,
+			CreatedAt:       auditLog.CreatedAt,
+		}
+	}
+	return PaginatedPrivilegeAuditLogsResponse{
+		Data:  data,
+		Pages: pages,
+		Page:  page,
+	}
+}
@@ -4,7 +4,9 @@ import (
 	"errors"
 	"fmt"
 	"golizilla/core/domain/model"
+	"mime"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -13,12 +15,67 @@ type CreateQuestionRequest struct {
 	QuestionnaireId uuid.UUID  `json:"questionnaire_id"`
 	QuestionText    string     `json:"question_text"`
 	Descriptive     bool       `json:"descriptive"`
+	Required        bool       `json:"required"`
 	MetaDataPath    string     `json:"meta_data_path,omitempty"`
 	CorrectOptionID *uuid.UUID `json:"correct_option_id,omitempty"`
 	Options         []string   `json:"options,omitempty"`
+
+	FileUpload       bool     `json:"file_upload"`
+	FileMaxSize      int64    `json:"file_max_size,omitempty"`      // in bytes
+	FileAllowedTypes []string `json:"file_allowed_types,omitempty"` // MIME types
+
+	AnswerRules *AnswerRulesRequest `json:"answer_rules,omitempty"` // descriptive questions only
+
+	TimeLimit       uint   `json:"time_limit,omitempty"`        // in seconds, 0 means no limit
+	TimeLimitAction string `json:"time_limit_action,omitempty"` // lock (default) or advance
+
+	// Groups used by stratified question pools
+	Tag        string `json:"tag,omitempty"`
+	Difficulty string `json:"difficulty,omitempty"`
+}
+
+// AnswerRulesRequest restricts the text of descriptive answers, omitted fields are
+// not checked.
+type AnswerRulesRequest struct {
+	MinLength uint     `json:"min_length,omitempty"`
+	MaxLength uint     `json:"max_length,omitempty"`
+	Pattern   string   `json:"pattern,omitempty"`
+	Format    string   `json:"format,omitempty"` // email, phone, national_id or number
+	MinValue  *float64 `json:"min_value,omitempty"`
+	MaxValue  *float64 `json:"max_value,omitempty"`
+	Charset   string   `json:"charset,omitempty"` // digits, letters, alphanumeric, persian or latin
+}
+
+func (r *AnswerRulesRequest) ToDomain() model.AnswerRules {
+	return model.AnswerRules{
+		MinLength: r.MinLength,
+		MaxLength: r.MaxLength,
+		Pattern:   r.Pattern,
+		Format:    r.Format,
+		MinValue:  r.MinValue,
+		MaxValue:  r.MaxValue,
+		Charset:   r.Charset,
+	}
+}
+
+func newAnswerRulesResponse(rules model.AnswerRules) *AnswerRulesRequest {
+	if rules.IsZero() {
+		return nil
+	}
+	return &AnswerRulesRequest{
+		MinLength: rules.MinLength,
+		MaxLength: rules.MaxLength,
+		Pattern:   rules.Pattern,
+		Format:    rules.Format,
+		MinValue:  rules.MinValue,
+		MaxValue:  rules.MaxValue,
+		Charset:   rules.Charset,
+	}
 }
 
-func (req *CreateQuestionRequest) Validate() error {
+// Validate checks the request, fileMaxSize is the largest answer file the server
+// accepts.
+func (req *CreateQuestionRequest) Validate(fileMaxSize int64) error {
 	if req.QuestionnaireId == uuid.Nil {
 		return errors.New("questionnaire_id cannot be empty")
 	}
@@ -27,6 +84,23 @@ func (req *CreateQuestionRequest) Validate() error {
 		return errors.New("question text cannot be empty")
 	}
 
+	if req.AnswerRules != nil {
+		if !req.Descriptive {
+			return errors.New("answer rules are only allowed on descriptive questions")
+		}
+		if err := req.AnswerRules.ToDomain().Validate(); err != nil {
+			return err
+		}
+	}
+
+	if err := validateTimeLimit(req.TimeLimit, req.TimeLimitAction); err != nil {
+		return err
+	}
+
+	if req.FileUpload {
+		return validateFileUploadQuestion(req.Descriptive, len(req.Options), req.FileMaxSize, fileMaxSize, req.FileAllowedTypes)
+	}
+
 	// If not descriptive, should have at least one option
 	if !req.Descriptive && len(req.Options) == 0 {
 		return errors.New("non-descriptive question must have at least one option")
@@ -40,12 +114,24 @@ func (req *CreateQuestionRequest) ToDomain() *model.Question {
 		QuestionnaireId: req.QuestionnaireId,
 		QuestionText:    req.QuestionText,
 		Descriptive:     req.Descriptive,
+		Required:        req.Required,
 		MetaDataPath:    req.MetaDataPath,
 		CorrectOptionID: req.CorrectOptionID,
+
+		FileUpload:       req.FileUpload,
+		FileMaxSize:      req.FileMaxSize,
+		FileAllowedTypes: strings.Join(req.FileAllowedTypes, ","),
+
+		Tag:        strings.TrimSpace(req.Tag),
+		Difficulty: strings.TrimSpace(req.Difficulty),
+	}
+	if req.AnswerRules != nil {
+		q.AnswerRules = req.AnswerRules.ToDomain()
 	}
+	q.TimeLimit, q.TimeLimitAction = timeLimitToDomain(req.TimeLimit, req.TimeLimitAction)
 
 	// If it's a multiple-choice question, create options
-	if !req.Descriptive && len(req.Options) > 0 {
+	if !req.Descriptive && !req.FileUpload && len(req.Options) > 0 {
 		opts := make([]model.Option, len(req.Options))
 		for i, text := range req.Options {
 			opts[i] = model.Option{
@@ -71,9 +157,10 @@ func NewCreateQuestionResponse(id uuid.UUID) CreateQuestionResponse {
 }
 
 type OptionResponse struct {
-	ID    uuid.UUID `json:"id"`
-	Index uint      `json:"index"`
-	Text  string    `json:"text"`
+	ID            uuid.UUID  `json:"id"`
+	Index         uint       `json:"index"`
+	Text          string     `json:"text"`
+	JumpSectionID *uuid.UUID `json:"jump_section_id,omitempty"`
 }
 
 type GetQuestionResponse struct {
@@ -82,18 +169,41 @@ type GetQuestionResponse struct {
 	Index           uint             `json:"index"`
 	QuestionText    string           `json:"question_text"`
 	Descriptive     bool             `json:"descriptive"`
+	Required        bool             `json:"required"`
 	MetaDataPath    string           `json:"meta_data_path,omitempty"`
+	MetaDataType    string           `json:"meta_data_type,omitempty"`
+	MetaDataSize    int64            `json:"meta_data_size,omitempty"`
 	CorrectOptionID *uuid.UUID       `json:"correct_option_id,omitempty"`
 	Options         []OptionResponse `json:"options,omitempty"`
+
+	FileUpload       bool     `json:"file_upload"`
+	FileMaxSize      int64    `json:"file_max_size,omitempty"`
+	FileAllowedTypes []string `json:"file_allowed_types,omitempty"`
+
+	AnswerRules *AnswerRulesRequest `json:"answer_rules,omitempty"`
+
+	BankQuestionID *uuid.UUID `json:"bank_question_id,omitempty"`
+	BankVersion    uint       `json:"bank_version,omitempty"`
+	BankLinked     bool       `json:"bank_linked,omitempty"`
+
+	TimeLimit       uint   `json:"time_limit,omitempty"`
+	TimeLimitAction string `json:"time_limit_action,omitempty"`
+	TimeRemaining   *int64 `json:"time_remaining,omitempty"` // seconds left for the respondent
+
+	SectionID  *uuid.UUID `json:"section_id,omitempty"`
+	PoolID     *uuid.UUID `json:"pool_id,omitempty"`
+	Tag        string     `json:"tag,omitempty"`
+	Difficulty string     `json:"difficulty,omitempty"`
 }
 
 func NewGetQuestionResponse(q *model.Question) *GetQuestionResponse {
 	opts := make([]OptionResponse, len(q.Options))
 	for i, o := range q.Options {
 		opts[i] = OptionResponse{
-			ID:    o.ID,
-			Index: o.Index,
-			Text:  o.Text,
+			ID:            o.ID,
+			Index:         o.Index,
+			Text:          o.Text,
+			JumpSectionID: o.JumpSectionID,
 		}
 	}
 
@@ -103,23 +213,90 @@ func NewGetQuestionResponse(q *model.Question) *GetQuestionResponse {
 		Index:           q.Index,
 		QuestionText:    q.QuestionText,
 		Descriptive:     q.Descriptive,
+		Required:        q.Required,
 		MetaDataPath:    q.MetaDataPath,
+		MetaDataType:    q.MetaDataContentType,
+		MetaDataSize:    q.MetaDataSize,
 		CorrectOptionID: q.CorrectOptionID,
 		Options:         opts,
+
+		FileUpload:       q.FileUpload,
+		FileMaxSize:      q.FileMaxSize,
+		FileAllowedTypes: splitFileAllowedTypes(q.FileAllowedTypes),
+
+		AnswerRules: newAnswerRulesResponse(q.AnswerRules),
+
+		BankQuestionID: q.BankQuestionID,
+		BankVersion:    q.BankVersion,
+		BankLinked:     q.BankLinked,
+
+		TimeLimit:       q.TimeLimit,
+		TimeLimitAction: q.TimeLimitAction,
+
+		SectionID:  q.SectionID,
+		PoolID:     q.PoolID,
+		Tag:        q.Tag,
+		Difficulty: q.Difficulty,
+	}
+}
+
+// SetTimeRemaining adds the seconds the respondent has left on the question, if it
+// has a time limit.
+func (r *GetQuestionResponse) SetTimeRemaining(remaining *time.Duration) {
+	r.TimeRemaining = timeRemainingSeconds(remaining)
+}
+
+func timeRemainingSeconds(remaining *time.Duration) *int64 {
+	if remaining == nil {
+		return nil
 	}
+	seconds := int64(remaining.Round(time.Second) / time.Second)
+	return &seconds
 }
 
 type UpdateQuestionRequest struct {
 	QuestionText    *string    `json:"question_text,omitempty"`
 	Descriptive     *bool      `json:"descriptive,omitempty"`
+	Required        *bool      `json:"required,omitempty"`
 	MetaDataPath    *string    `json:"meta_data_path,omitempty"`
 	CorrectOptionID *uuid.UUID `json:"correct_option_id,omitempty"`
 	Options         *[]string  `json:"options,omitempty"`
+
+	FileMaxSize      *int64    `json:"file_max_size,omitempty"`
+	FileAllowedTypes *[]string `json:"file_allowed_types,omitempty"`
+
+	// Replaces all answer rules, send an empty object to remove them
+	AnswerRules *AnswerRulesRequest `json:"answer_rules,omitempty"`
+
+	// Send 0 to remove the time limit
+	TimeLimit       *uint   `json:"time_limit,omitempty"`
+	TimeLimitAction *string `json:"time_limit_action,omitempty"`
+
+	Tag        *string `json:"tag,omitempty"`
+	Difficulty *string `json:"difficulty,omitempty"`
 }
 
-func (req *UpdateQuestionRequest) Validate() error {
-	// If you need validation for updates, you can add here.
-	// For now, it's optional fields.
+// Validate checks the request, fileMaxSize is the largest answer file the server
+// accepts.
+func (req *UpdateQuestionRequest) Validate(fileMaxSize int64) error {
+	if req.FileMaxSize != nil {
+		if err := validateFileMaxSize(*req.FileMaxSize, fileMaxSize); err != nil {
+			return err
+		}
+	}
+	if req.AnswerRules != nil {
+		if err := req.AnswerRules.ToDomain().Validate(); err != nil {
+			return err
+		}
+	}
+	if req.TimeLimitAction != nil {
+		if err := validateTimeLimit(1, *req.TimeLimitAction); err != nil {
+			return err
+		}
+	}
+	if req.FileAllowedTypes != nil {
+		return validateFileAllowedTypes(*req.FileAllowedTypes)
+	}
 	return nil
 }
 
@@ -130,12 +307,40 @@ func (req *UpdateQuestionRequest) ToDomain(q *model.Question) *model.Question {
 	if req.Descriptive != nil {
 		q.Descriptive = *req.Descriptive
 	}
+	if req.Required != nil {
+		q.Required = *req.Required
+	}
 	if req.MetaDataPath != nil {
 		q.MetaDataPath = *req.MetaDataPath
 	}
 	if req.CorrectOptionID != nil {
 		q.CorrectOptionID = req.CorrectOptionID
 	}
+	if req.FileMaxSize != nil {
+		q.FileMaxSize = *req.FileMaxSize
+	}
+	if req.FileAllowedTypes != nil {
+		q.FileAllowedTypes = strings.Join(*req.FileAllowedTypes, ",")
+	}
+	if req.AnswerRules != nil {
+		q.AnswerRules = req.AnswerRules.ToDomain()
+	}
+	if req.Tag != nil {
+		q.Tag = strings.TrimSpace(*req.Tag)
+	}
+	if req.Difficulty != nil {
+		q.Difficulty = strings.TrimSpace(*req.Difficulty)
+	}
+	if req.TimeLimit != nil || req.TimeLimitAction != nil {
+		limit, action := q.TimeLimit, q.TimeLimitAction
+		if req.TimeLimit != nil {
+			limit = *req.TimeLimit
+		}
+		if req.TimeLimitAction != nil {
+			action = *req.TimeLimitAction
+		}
+		q.TimeLimit, q.TimeLimitAction = timeLimitToDomain(limit, action)
+	}
 
 	if req.Options != nil && !q.Descriptive {
 		opts := make([]model.Option, len(*req.Options))
@@ -155,3 +360,65 @@ func (req *UpdateQuestionRequest) ToDomain(q *model.Question) *model.Question {
 
 	return q
 }
+
+func validateTimeLimit(limit uint, action string) error {
+	if action != "" && action != model.TimeLimitActionLock && action != model.TimeLimitActionAdvance {
+		return fmt.Errorf("time limit action must be %q or %q", model.TimeLimitActionLock, model.TimeLimitActionAdvance)
+	}
+	if limit == 0 && action != "" {
+		return errors.New("time limit action requires a time limit")
+	}
+	return nil
+}
+
+// timeLimitToDomain defaults the action of a time limit to lock and drops it when
+// there is no limit.
+func timeLimitToDomain(limit uint, action string) (uint, string) {
+	if limit == 0 {
+		return 0, ""
+	}
+	if action == "" {
+		action = model.TimeLimitActionLock
+	}
+	return limit, action
+}
+
+func validateFileUploadQuestion(descriptive bool, options int, maxSize, serverMaxSize int64, allowedTypes []string) error {
+	if descriptive {
+		return errors.New("a question cannot be both descriptive and file upload")
+	}
+	if options > 0 {
+		return errors.New("file upload question cannot have options")
+	}
+	if err := validateFileMaxSize(maxSize, serverMaxSize); err != nil {
+		return err
+	}
+	return validateFileAllowedTypes(allowedTypes)
+}
+
+// validateFileMaxSize rejects limits the server would never let an upload reach.
+func validateFileMaxSize(maxSize, serverMaxSize int64) error {
+	if maxSize < 0 {
+		return errors.New("file max size cannot be negative")
+	}
+	if maxSize > serverMaxSize {
+		return fmt.Errorf("file max size cannot be more than %d bytes", serverMaxSize)
+	}
+	return nil
+}
+
+func validateFileAllowedTypes(allowedTypes []string) error {
+	for _, contentType := range allowedTypes {
+		if _, _, err := mime.ParseMediaType(contentType); err != nil || strings.Contains(contentType, ",") {
+			return fmt.Errorf("invalid MIME type: %q", contentType)
+		}
+	}
+	return nil
+}
+
+func splitFileAllowedTypes(allowedTypes string) []string {
+	if allowedTypes == "" {
+		return nil
+	}
+	return strings.Split(allowedTypes, ",")
+}
@@ -0,0 +1,133 @@
+package presenter
+
+import (
+	"errors"
+	"golizilla/core/domain/model"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BankOptionRequest is an option of a bank question. Sending the ID of an existing
+// option keeps it, so answers given before the update stay comparable.
+type BankOptionRequest struct {
+	ID   *uuid.UUID `json:"id,omitempty"`
+	Text string     `json:"text"`
+}
+
+type BankQuestionRequest struct {
+	QuestionText string              `json:"question_text"`
+	Descriptive  bool                `json:"descriptive"`
+	Organization bool                `json:"organization"` // shared with every user instead of the owner only
+	Options      []BankOptionRequest `json:"options,omitempty"`
+}
+
+type UseBankQuestionRequest struct {
+	QuestionnaireID uuid.UUID `json:"questionnaire_id"`
+	Index           uint      `json:"index"`
+	Linked          bool      `json:"linked"` // linked questions can pull later versions
+}
+
+type BankOptionResponse struct {
+	ID    uuid.UUID `json:"id"`
+	Index uint      `json:"index"`
+	Text  string    `json:"text"`
+}
+
+type BankQuestionResponse struct {
+	ID           uuid.UUID            `json:"id"`
+	OwnerID      uuid.UUID            `json:"owner_id"`
+	Scope        string               `json:"scope"`
+	QuestionText string               `json:"question_text"`
+	Descriptive  bool                 `json:"descriptive"`
+	Version      uint                 `json:"version"`
+	Options      []BankOptionResponse `json:"options,omitempty"`
+	UpdatedAt    time.Time            `json:"updated_at"`
+}
+
+type PaginatedBankQuestionsResponse struct {
+	Data  []BankQuestionResponse `json:"data"`
+	Pages int                    `json:"pages"`
+	Page  int                    `json:"page"`
+}
+
+func (r *BankQuestionRequest) Validate() error {
+	if strings.TrimSpace(r.QuestionText) == "" {
+		return errors.New("question text cannot be empty")
+	}
+	if r.Descriptive && len(r.Options) > 0 {
+		return errors.New("descriptive question cannot have options")
+	}
+	if !r.Descriptive && len(r.Options) == 0 {
+		return errors.New("non-descriptive question must have at least one option")
+	}
+	for _, option := range r.Options {
+		if strings.TrimSpace(option.Text) == "" {
+			return errors.New("option text cannot be empty")
+		}
+	}
+	return nil
+}
+
+func (r *BankQuestionRequest) ToDomain() *model.BankQuestion {
+	scope := model.BankQuestionScopePersonal
+	if r.Organization {
+		scope = model.BankQuestionScopeOrganization
+	}
+
+	bankQuestion := &model.BankQuestion{
+		Scope:        scope,
+		QuestionText: r.QuestionText,
+		Descriptive:  r.Descriptive,
+	}
+	for _, option := range r.Options {
+		bankOption := model.BankOption{Text: option.Text}
+		if option.ID != nil {
+			bankOption.ID = *option.ID
+		}
+		bankQuestion.Options = append(bankQuestion.Options, bankOption)
+	}
+	return bankQuestion
+}
+
+func (r *UseBankQuestionRequest) Validate() error {
+	if r.QuestionnaireID == uuid.Nil {
+		return errors.New("questionnaire_id cannot be empty")
+	}
+	return nil
+}
+
+func NewBankQuestionResponse(bankQuestion *model.BankQuestion) BankQuestionResponse {
+	options := make([]BankOptionResponse, len(bankQuestion.Options))
+	for i, option := range bankQuestion.Options {
+		options[i] = BankOptionResponse{
+			ID:    option.ID,
+			Index: option.Index,
+			Text:  option.Text,
+		}
+	}
+
+	return BankQuestionResponse{
+		ID:           bankQuestion.ID,
+		OwnerID:      bankQuestion.OwnerId,
+		Scope:        string(bankQuestion.Scope),
+		QuestionText: bankQuestion.QuestionText,
+		Descriptive:  bankQuestion.Descriptive,
+		Version:      bankQuestion.Version,
+		Options:      options,
+		UpdatedAt:    bankQuestion.UpdatedAt,
+	}
+}
+
+func NewPaginatedBankQuestionsResponse(bankQuestions []model.BankQuestion, pages, page int) PaginatedBankQuestionsResponse {
+	data := make([]BankQuestionResponse, len(bankQuestions))
+	for i := range bankQuestions {
+		data[i] = NewBankQuestionResponse(&bankQuestions[i])
+	}
+	return PaginatedBankQuestionsResponse{
+		Data:  data,
+		Pages: pages,
+		Page:  page,
+	}
+}
@@ -0,0 +1,79 @@
+package presenter
+
+import (
+	"errors"
+	"golizilla/core/domain/model"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// QuestionPoolRequest defines a pool and the questions in it, DrawCount of them are
+// asked to each respondent.
+type QuestionPoolRequest struct {
+	Name        string      `json:"name"`
+	DrawCount   uint        `json:"draw_count"`
+	StratifyBy  string      `json:"stratify_by,omitempty"` // tag or difficulty
+	QuestionIDs []uuid.UUID `json:"question_ids"`
+}
+
+type QuestionPoolResponse struct {
+	ID          uuid.UUID   `json:"id"`
+	Name        string      `json:"name"`
+	DrawCount   uint        `json:"draw_count"`
+	StratifyBy  string      `json:"stratify_by,omitempty"`
+	QuestionIDs []uuid.UUID `json:"question_ids"`
+}
+
+func (r *QuestionPoolRequest) Validate() error {
+	if strings.TrimSpace(r.Name) == "" {
+		return errors.New("name cannot be empty")
+	}
+	if r.StratifyBy != "" && r.StratifyBy != model.PoolStratifyTag && r.StratifyBy != model.PoolStratifyDifficulty {
+		return errors.New("stratify_by must be tag or difficulty")
+	}
+	if len(r.QuestionIDs) == 0 {
+		return errors.New("question_ids cannot be empty")
+	}
+	seen := make(map[uuid.UUID]bool, len(r.QuestionIDs))
+	for _, id := range r.QuestionIDs {
+		if seen[id] {
+			return errors.New("question_ids cannot contain duplicates")
+		}
+		seen[id] = true
+	}
+	if r.DrawCount == 0 || int(r.DrawCount) > len(r.QuestionIDs) {
+		return errors.New("draw_count must be between 1 and the number of questions")
+	}
+	return nil
+}
+
+func (r *QuestionPoolRequest) ToDomain() *model.QuestionPool {
+	return &model.QuestionPool{
+		Name:       strings.TrimSpace(r.Name),
+		DrawCount:  r.DrawCount,
+		StratifyBy: r.StratifyBy,
+	}
+}
+
+func NewQuestionPoolResponse(pool *model.QuestionPool) *QuestionPoolResponse {
+	response := &QuestionPoolResponse{
+		ID:          pool.ID,
+		Name:        pool.Name,
+		DrawCount:   pool.DrawCount,
+		StratifyBy:  pool.StratifyBy,
+		QuestionIDs: make([]uuid.UUID, len(pool.Questions)),
+	}
+	for i, question := range pool.Questions {
+		response.QuestionIDs[i] = question.ID
+	}
+	return response
+}
+
+func NewQuestionPoolsResponse(pools []model.QuestionPool) []*QuestionPoolResponse {
+	response := make([]*QuestionPoolResponse, len(pools))
+	for i := range pools {
+		response[i] = NewQuestionPoolResponse(&pools[i])
+	}
+	return response
+}
@@ -3,6 +3,7 @@ package presenter
 import (
 	"errors"
 	"golizilla/core/domain/model"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -17,6 +18,8 @@ type CreateQuestionnaireRequest struct {
 	AnswerTime     uint      `json:"answer_time"`
 	Anonymous      bool      `json:"anonymous"`
 	SubmitLimit    uint      `json:"submit_limit,omitempty"`
+	DefaultLocale  string    `json:"default_locale,omitempty"`
+	AnswerReview   bool      `json:"answer_review"`
 	//TODO: Questions
 }
 
@@ -30,6 +33,13 @@ type DeleteAcessRequest struct {
 	Privileges []string    `json:"privileges"`
 }
 
+// GiveRoleAcessRequest grants or revokes privileges on a questionnaire for every
+// user of the roles.
+type GiveRoleAcessRequest struct {
+	RoleIDs    []uuid.UUID `json:"role_ids"`
+	Privileges []string    `json:"privileges"`
+}
+
 type UpdateQuestionnaireRequest struct {
 	ID             uuid.UUID      `json:"id"` // Mandatory for updates
 	StartTime      time.Time      `json:"start_time,omitempty"`
@@ -39,6 +49,7 @@ type UpdateQuestionnaireRequest struct {
 	Title          *string        `json:"title,omitempty"`
 	AnswerTime     *time.Duration `json:"answer_time,omitempty"`
 	Anonymous      *bool          `json:"anonymous,omitempty"`
+	AnswerReview   *bool          `json:"answer_review,omitempty"`
 }
 
 type CreateQuestionnaireResponseData struct {
@@ -46,17 +57,90 @@ type CreateQuestionnaireResponseData struct {
 }
 
 type GetQuestionnaireResponseData struct {
-	Id                 uuid.UUID `json:"id"`
-	OwnerId            uuid.UUID `json:"owner_id"`
-	CreatedTime        time.Time `json:"created_time"`
-	StartTime          time.Time `json:"start_time"`
-	EndTime            time.Time `json:"end_time"`
-	Random             bool      `json:"random"`
-	BackCompatible     bool      `json:"back_compatible"`
-	Title              string    `json:"title"`
-	AnswerTime         uint      `json:"answer_time"`
-	ParticipationCount uint      `json:"particpation_count"`
-	Anonymous          bool      `json:"anonymous"`
+	Id                 uuid.UUID  `json:"id"`
+	OwnerId            uuid.UUID  `json:"owner_id"`
+	CreatedTime        time.Time  `json:"created_time"`
+	StartTime          time.Time  `json:"start_time"`
+	EndTime            time.Time  `json:"end_time"`
+	Random             bool       `json:"random"`
+	BackCompatible     bool       `json:"back_compatible"`
+	Title              string     `json:"title"`
+	DefaultLocale      string     `json:"default_locale"`
+	AnswerTime         uint       `json:"answer_time"`
+	ParticipationCount uint       `json:"particpation_count"`
+	Anonymous          bool       `json:"anonymous"`
+	SubmitLimit        uint       `json:"submit_limit"`
+	SlotTradingEnabled bool       `json:"slot_trading_enabled"`
+	AnswerReview       bool       `json:"answer_review"`
+	ArchivedAt         *time.Time `json:"archived_at,omitempty"`
+
+	Eligibility      *EligibilityRulesRequest `json:"eligibility,omitempty"`
+	Eligible         *bool                    `json:"eligible,omitempty"` // set in listings, for the requesting user
+	IneligibleReason string                   `json:"ineligible_reason,omitempty"`
+}
+
+// EligibilityRulesRequest restricts who may answer a questionnaire, omitted fields
+// are not checked. A user matches a list when they match any of its values.
+type EligibilityRulesRequest struct {
+	MinAge            uint        `json:"min_age,omitempty"`
+	MaxAge            uint        `json:"max_age,omitempty"`
+	Cities            []string    `json:"cities,omitempty"`
+	RoleIDs           []uuid.UUID `json:"role_ids,omitempty"`      // the role of the user or one it inherits from
+	EmailDomains      []string    `json:"email_domains,omitempty"` // the email must be verified
+	MinAccountAgeDays uint        `json:"min_account_age_days,omitempty"`
+}
+
+func (r *EligibilityRulesRequest) Validate() error {
+	for _, city := range r.Cities {
+		if strings.TrimSpace(city) == "" || strings.Contains(city, ",") {
+			return errors.New("cities cannot be empty or contain commas")
+		}
+	}
+	for _, domain := range r.EmailDomains {
+		if strings.Contains(domain, ",") {
+			return errors.New("email domains cannot contain commas")
+		}
+	}
+	return r.ToDomain().Validate()
+}
+
+func (r *EligibilityRulesRequest) ToDomain() model.EligibilityRules {
+	roleIDs := make([]string, len(r.RoleIDs))
+	for i, roleID := range r.RoleIDs {
+		roleIDs[i] = roleID.String()
+	}
+	domains := make([]string, len(r.EmailDomains))
+	for i, domain := range r.EmailDomains {
+		domains[i] = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(domain), "@"))
+	}
+	return model.EligibilityRules{
+		MinAge:            r.MinAge,
+		MaxAge:            r.MaxAge,
+		Cities:            strings.Join(r.Cities, ","),
+		RoleIDs:           strings.Join(roleIDs, ","),
+		EmailDomains:      strings.Join(domains, ","),
+		MinAccountAgeDays: r.MinAccountAgeDays,
+	}
+}
+
+func newEligibilityRulesResponse(rules model.EligibilityRules) *EligibilityRulesRequest {
+	if rules.IsZero() {
+		return nil
+	}
+	var roleIDs []uuid.UUID
+	for _, roleID := range model.SplitList(rules.RoleIDs) {
+		if id, err := uuid.Parse(roleID); err == nil {
+			roleIDs = append(roleIDs, id)
+		}
+	}
+	return &EligibilityRulesRequest{
+		MinAge:            rules.MinAge,
+		MaxAge:            rules.MaxAge,
+		Cities:            model.SplitList(rules.Cities),
+		RoleIDs:           roleIDs,
+		EmailDomains:      model.SplitList(rules.EmailDomains),
+		MinAccountAgeDays: rules.MinAccountAgeDays,
+	}
 }
 
 func (req *CreateQuestionnaireRequest) Validate() error {
@@ -77,10 +161,21 @@ func (req *CreateQuestionnaireRequest) Validate() error {
 		return errors.New("end time must be in the future")
 	}
 
+	if req.DefaultLocale != "" {
+		if _, ok := model.NormalizeLocale(req.DefaultLocale); !ok {
+			return errors.New("default locale is invalid")
+		}
+	}
+
 	return nil
 }
 
 func (req *CreateQuestionnaireRequest) ToDomain() *model.Questionnaire {
+	defaultLocale := model.DefaultLocale
+	if req.DefaultLocale != "" {
+		defaultLocale, _ = model.NormalizeLocale(req.DefaultLocale)
+	}
+
 	return &model.Questionnaire{
 		StartTime:      req.StartTime,
 		EndTime:        req.EndTime,
@@ -88,8 +183,10 @@ func (req *CreateQuestionnaireRequest) ToDomain() *model.Questionnaire {
 		Random:         req.Random,
 		BackCompatible: req.BackCompatible,
 		Title:          req.Title,
+		DefaultLocale:  defaultLocale,
 		AnswerTime:     req.AnswerTime,
 		Anonymous:      req.Anonymous,
+		AnswerReview:   req.AnswerReview,
 	}
 }
 
@@ -118,6 +215,9 @@ func (r *UpdateQuestionnaireRequest) ToDomain() map[string]interface{} {
 	if r.Anonymous != nil {
 		updateFields["anonymous"] = *r.Anonymous
 	}
+	if r.AnswerReview != nil {
+		updateFields["answer_review"] = *r.AnswerReview
+	}
 
 	return updateFields
 }
@@ -143,17 +243,27 @@ func NewGetQuestionnaireResponse(data *model.Questionnaire) Response {
 			Random:             data.Random,
 			BackCompatible:     data.BackCompatible,
 			Title:              data.Title,
+			DefaultLocale:      data.DefaultLocale,
 			AnswerTime:         data.AnswerTime,
 			ParticipationCount: data.ParticipationCount,
 			Anonymous:          data.Anonymous,
+			SubmitLimit:        data.SubmitLimit,
+			SlotTradingEnabled: data.SlotTradingEnabled,
+			AnswerReview:       data.AnswerReview,
+			ArchivedAt:         data.ArchivedAt,
+			Eligibility:        newEligibilityRulesResponse(data.Eligibility),
 		},
 	}
 }
 
-func NewGetQuestionnairesResponse(data []model.Questionnaire) Response {
+// NewGetQuestionnairesResponse lists the questionnaires, ineligible holds the
+// reason for those the requesting user may not answer.
+func NewGetQuestionnairesResponse(data []model.Questionnaire, ineligible map[uuid.UUID]string) Response {
 	var resultData []GetQuestionnaireResponseData
 
 	for _, item := range data {
+		reason, isIneligible := ineligible[item.Id]
+		eligible := !isIneligible
 		resultData = append(resultData, GetQuestionnaireResponseData{
 			Id:                 item.Id,
 			OwnerId:            item.OwnerId,
@@ -166,6 +276,13 @@ func NewGetQuestionnairesResponse(data []model.Questionnaire) Response {
 			AnswerTime:         item.AnswerTime,
 			ParticipationCount: item.ParticipationCount,
 			Anonymous:          item.Anonymous,
+			SubmitLimit:        item.SubmitLimit,
+			SlotTradingEnabled: item.SlotTradingEnabled,
+			AnswerReview:       item.AnswerReview,
+			ArchivedAt:         item.ArchivedAt,
+			Eligibility:        newEligibilityRulesResponse(item.Eligibility),
+			Eligible:           &eligible,
+			IneligibleReason:   reason,
 		})
 	}
 	return Response{
@@ -173,3 +290,15 @@ func NewGetQuestionnairesResponse(data []model.Questionnaire) Response {
 		Data:    resultData,
 	}
 }
+
+// TransferQuestionnaireRequest names the user who becomes the new owner.
+type TransferQuestionnaireRequest struct {
+	Username string `json:"username"`
+}
+
+func (r *TransferQuestionnaireRequest) Validate() error {
+	if r.Username == "" {
+		return errors.New("username is required")
+	}
+	return nil
+}
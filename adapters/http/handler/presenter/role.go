@@ -0,0 +1,119 @@
+package presenter
+
+import (
+	"errors"
+	"golizilla/core/domain/model"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+type RoleRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type RolePrivilegesRequest struct {
+	Privileges []string `json:"privileges"`
+}
+
+type AssignRoleRequest struct {
+	UserIDs []uuid.UUID `json:"user_ids"`
+}
+
+// SetParentRoleRequest sets the role to inherit from, null detaches the role.
+type SetParentRoleRequest struct {
+	ParentID *uuid.UUID `json:"parent_id"`
+}
+
+type RoleResponse struct {
+	ID          uuid.UUID  `json:"id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	ParentID    *uuid.UUID `json:"parent_id,omitempty"`
+}
+
+// RoleWithPrivilegesResponse shows a role with the privileges it holds directly
+// or inherits.
+type RoleWithPrivilegesResponse struct {
+	RoleResponse
+	EffectivePrivileges []string `json:"effective_privileges"`
+}
+
+type PaginatedRolesResponse struct {
+	Data  []RoleWithPrivilegesResponse `json:"data"`
+	Pages int                          `json:"pages"`
+	Page  int                          `json:"page"`
+}
+
+// RoleMemberResponse exposes only the public profile of a role member.
+type RoleMemberResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Username  string    `json:"username"`
+	FirstName string    `json:"first_name"`
+	LastName  string    `json:"last_name"`
+}
+
+type PaginatedRoleMembersResponse struct {
+	Data  []RoleMemberResponse `json:"data"`
+	Pages int                  `json:"pages"`
+	Page  int                  `json:"page"`
+}
+
+func (r *RoleRequest) Validate() error {
+	r.Name = strings.TrimSpace(r.Name)
+	if r.Name == "" {
+		return errors.New("name is required")
+	}
+	if r.Name == model.SuperAdminRoleName {
+		return errors.New("name is reserved")
+	}
+	return nil
+}
+
+func (r *RolePrivilegesRequest) Validate() error {
+	if len(r.Privileges) == 0 {
+		return errors.New("privileges are required")
+	}
+	return nil
+}
+
+func (r *AssignRoleRequest) Validate() error {
+	if len(r.UserIDs) == 0 {
+		return errors.New("user_ids are required")
+	}
+	return nil
+}
+
+func NewRoleResponse(role *model.Role) RoleResponse {
+	return RoleResponse{
+		ID:          role.ID,
+		Name:        role.Name,
+		Description: role.Description,
+		ParentID:    role.ParentId,
+	}
+}
+
+func NewPaginatedRoleMembersResponse(users []model.User, pages, page int) PaginatedRoleMembersResponse {
+	data := make([]RoleMemberResponse, len(users))
+	for i, user := range users {
+		data[i] = RoleMemberResponse{
+			ID:        user.ID,
+			Username:  user.Username,
+			FirstName: user.FirstName,
+			LastName:  user.LastName,
+		}
+	}
+	return PaginatedRoleMembersResponse{
+		Data:  data,
+		Pages: pages,
+		Page:  page,
+	}
+}
+
+func NewRoleWithPrivilegesResponse(role *model.Role, effectivePrivileges []string) RoleWithPrivilegesResponse {
+	return RoleWithPrivilegesResponse{
+		RoleResponse:        NewRoleResponse(role),
+		EffectivePrivileges: effectivePrivileges,
+	}
+}
@@ -0,0 +1,78 @@
+package presenter
+
+import (
+	"errors"
+	"golizilla/core/domain/model"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// SectionRequest defines a page of a questionnaire and the questions shown on it.
+type SectionRequest struct {
+	Title       string      `json:"title"`
+	Description string      `json:"description,omitempty"`
+	QuestionIDs []uuid.UUID `json:"question_ids"`
+}
+
+// BranchingRequest maps options of a question to the section they jump to, null
+// removes the jump of an option.
+type BranchingRequest struct {
+	Jumps map[uuid.UUID]*uuid.UUID `json:"jumps"`
+}
+
+type SectionResponse struct {
+	ID          uuid.UUID   `json:"id"`
+	Title       string      `json:"title"`
+	Description string      `json:"description,omitempty"`
+	QuestionIDs []uuid.UUID `json:"question_ids"`
+}
+
+func (r *SectionRequest) Validate() error {
+	if strings.TrimSpace(r.Title) == "" {
+		return errors.New("title cannot be empty")
+	}
+	seen := make(map[uuid.UUID]bool, len(r.QuestionIDs))
+	for _, id := range r.QuestionIDs {
+		if seen[id] {
+			return errors.New("question_ids cannot contain duplicates")
+		}
+		seen[id] = true
+	}
+	return nil
+}
+
+func (r *SectionRequest) ToDomain() *model.Section {
+	return &model.Section{
+		Title:       strings.TrimSpace(r.Title),
+		Description: r.Description,
+	}
+}
+
+func (r *BranchingRequest) Validate() error {
+	if len(r.Jumps) == 0 {
+		return errors.New("jumps cannot be empty")
+	}
+	return nil
+}
+
+func NewSectionResponse(section *model.Section) *SectionResponse {
+	response := &SectionResponse{
+		ID:          section.ID,
+		Title:       section.Title,
+		Description: section.Description,
+		QuestionIDs: make([]uuid.UUID, len(section.Questions)),
+	}
+	for i, question := range section.Questions {
+		response.QuestionIDs[i] = question.ID
+	}
+	return response
+}
+
+func NewSectionsResponse(sections []model.Section) []*SectionResponse {
+	response := make([]*SectionResponse, len(sections))
+	for i := range sections {
+		response[i] = NewSectionResponse(&sections[i])
+	}
+	return response
+}
@@ -0,0 +1,111 @@
+package presenter
+
+import (
+	"errors"
+	"golizilla/core/domain/model"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SetSlotTradingRequest struct {
+	Enabled *bool `json:"enabled"`
+}
+
+func (r *SetSlotTradingRequest) Validate() error {
+	if r.Enabled == nil {
+		return errors.New("enabled is required")
+	}
+	return nil
+}
+
+type CreateSlotListingRequest struct {
+	Price uint `json:"price"`
+}
+
+func (r *CreateSlotListingRequest) Validate() error {
+	if r.Price == 0 {
+		return errors.New("price must be greater than zero")
+	}
+	return nil
+}
+
+type SlotListingResponse struct {
+	ID              uuid.UUID  `json:"id"`
+	QuestionnaireID uuid.UUID  `json:"questionnaire_id"`
+	SellerID        uuid.UUID  `json:"seller_id"`
+	BuyerID         *uuid.UUID `json:"buyer_id,omitempty"`
+	Price           uint       `json:"price"`
+	Status          string     `json:"status"`
+	TransactionID   *uuid.UUID `json:"transaction_id,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+	SoldAt          *time.Time `json:"sold_at,omitempty"`
+}
+
+type PaginatedSlotListingsResponse struct {
+	Data  []SlotListingResponse `json:"data"`
+	Pages int                   `json:"pages"`
+	Page  int                   `json:"page"`
+}
+
+func NewSlotListingResponse(listing *model.SubmitSlotListing) SlotListingResponse {
+	return SlotListingResponse{
+		ID:              listing.ID,
+		QuestionnaireID: listing.QuestionnaireId,
+		SellerID:        listing.SellerId,
+		BuyerID:         listing.BuyerId,
+		Price:           listing.Price,
+		Status:          string(listing.Status),
+		TransactionID:   listing.TransactionID,
+		CreatedAt:       listing.CreatedAt,
+		SoldAt:          listing.SoldAt,
+	}
+}
+
+func NewPaginatedSlotListingsResponse(listings []model.SubmitSlotListing, pages, page int) PaginatedSlotListingsResponse {
+	data := make([]SlotListingResponse, len(listings))
+	for i := range listings {
+		data[i] = NewSlotListingResponse(&listings[i])
+	}
+	return PaginatedSlotListingsResponse{
+		Data:  data,
+		Pages: pages,
+		Page:  page,
+	}
+}
+
+type SlotAuditLogResponse struct {
+	ID            uuid.UUID  `json:"id"`
+	ActorID       uuid.UUID  `json:"actor_id"`
+	Action        string     `json:"action"`
+	ListingID     *uuid.UUID `json:"listing_id,omitempty"`
+	TransactionID *uuid.UUID `json:"transaction_id,omitempty"`
+	Price         uint       `json:"price,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+type PaginatedSlotAuditLogsResponse struct {
+	Data  []SlotAuditLogResponse `json:"data"`
+	Pages int                    `json:"pages"`
+	Page  int                    `json:"page"`
+}
+
+func NewPaginatedSlotAuditLogsResponse(auditLogs []model.SubmitSlotAuditLog, pages, page int) PaginatedSlotAuditLogsResponse {
+	data := make([]SlotAuditLogResponse, len(auditLogs))
+	for i, auditLog := range auditLogs {
+		data[i] = SlotAuditLogResponse{
+			ID:            auditLog.ID,
+			ActorID:       auditLog.ActorId,
+			Action:        string(auditLog.Action),
+			ListingID:     auditLog.ListingID,
+			TransactionID: auditLog.TransactionID,
+			Price:         auditLog.Price,
+			CreatedAt:     auditLog.CreatedAt,
+		}
+	}
+	return PaginatedSlotAuditLogsResponse{
+		Data:  data,
+		Pages: pages,
+		Page:  page,
+	}
+}
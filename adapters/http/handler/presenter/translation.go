@@ -0,0 +1,68 @@
+package presenter
+
+import (
+	"errors"
+	"golizilla/core/domain/model"
+
+	"github.com/google/uuid"
+)
+
+type SetQuestionnaireTranslationRequest struct {
+	Title string `json:"title"`
+}
+
+// SetQuestionTranslationRequest holds the text of a question and of its options in
+// one locale, options are keyed by their ID.
+type SetQuestionTranslationRequest struct {
+	QuestionText string               `json:"question_text"`
+	Options      map[uuid.UUID]string `json:"options,omitempty"`
+}
+
+type TranslationResponse struct {
+	EntityType string    `json:"entity_type"`
+	EntityID   uuid.UUID `json:"entity_id"`
+	Locale     string    `json:"locale"`
+	Text       string    `json:"text"`
+}
+
+type QuestionnaireTranslationsResponse struct {
+	DefaultLocale string                 `json:"default_locale"`
+	Locales       []string               `json:"locales"`
+	Translations  []*TranslationResponse `json:"translations"`
+}
+
+func (r *SetQuestionnaireTranslationRequest) Validate() error {
+	if r.Title == "" {
+		return errors.New("title can't be empty")
+	}
+	return nil
+}
+
+func (r *SetQuestionTranslationRequest) Validate() error {
+	if r.QuestionText == "" && len(r.Options) == 0 {
+		return errors.New("question_text or options is required")
+	}
+	for _, text := range r.Options {
+		if text == "" {
+			return errors.New("option text can't be empty")
+		}
+	}
+	return nil
+}
+
+func NewQuestionnaireTranslationsResponse(defaultLocale string, locales []string, translations []model.Translation) *QuestionnaireTranslationsResponse {
+	response := &QuestionnaireTranslationsResponse{
+		DefaultLocale: defaultLocale,
+		Locales:       locales,
+		Translations:  make([]*TranslationResponse, 0, len(translations)),
+	}
+	for _, translation := range translations {
+		response.Translations = append(response.Translations, &TranslationResponse{
+			EntityType: translation.EntityType,
+			EntityID:   translation.EntityID,
+			Locale:     translation.Locale,
+			Text:       translation.Text,
+		})
+	}
+	return response
+}
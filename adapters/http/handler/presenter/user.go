@@ -34,11 +34,18 @@ type Verify2FARequest struct {
 	Code  string `json:"code"`
 }
 
+// DeleteAccountRequest confirms the deletion with the current password.
+type DeleteAccountRequest struct {
+	Password string `json:"password"`
+}
+
 type UpdateProfileRequest struct {
 	FirstName   *string    `json:"first_name,omitempty"`
 	LastName    *string    `json:"last_name,omitempty"`
 	City        *string    `json:"city,omitempty"`
 	DateOfBirth *time.Time `json:"date_of_birth,omitempty"`
+	// PreferredLocale picks the questionnaire language, ahead of Accept-Language
+	PreferredLocale *string `json:"preferred_locale,omitempty"`
 }
 
 // Validate validates the CreateUserRequest fields.
@@ -70,6 +77,13 @@ func (r *CreateUserRequest) Validate() error {
 	return nil
 }
 
+func (r *DeleteAccountRequest) Validate() error {
+	if r.Password == "" {
+		return errors.New("password is required")
+	}
+	return nil
+}
+
 func (r *VerifyEmailRequest) Validate() error {
 	if r.Email == "" {
 		return errors.New("email is required")
@@ -115,6 +129,11 @@ func (r *UpdateProfileRequest) Validate() error {
 			return errors.New("date of birth cant be in future")
 		}
 	}
+	if r.PreferredLocale != nil && *r.PreferredLocale != "" {
+		if _, ok := model.NormalizeLocale(*r.PreferredLocale); !ok {
+			return errors.New("preferred locale is invalid")
+		}
+	}
 	return nil
 }
 
@@ -146,29 +165,41 @@ func (r *UpdateProfileRequest) ToDomain() *model.User {
 	return updateFields
 }
 
+// ToPreferredLocale returns the normalized locale to store, an empty one to clear
+// it, or nil when the request leaves it unchanged.
+func (r *UpdateProfileRequest) ToPreferredLocale() *string {
+	if r.PreferredLocale == nil {
+		return nil
+	}
+	locale, _ := model.NormalizeLocale(*r.PreferredLocale)
+	return &locale
+}
+
 // UserResponse defines the structure of the User object returned to the client.
 type UserResponse struct {
-	ID          uuid.UUID `json:"id"`
-	Username    string    `json:"username"`
-	Email       string    `json:"email"`
-	FirstName   string    `json:"firstName"`
-	LastName    string    `json:"lastName"`
-	City        string    `json:"city"`
-	Wallet      uint      `json:"wallet"`
-	DateOfBirth string    `json:"dateOfBirth"`
+	ID              uuid.UUID `json:"id"`
+	Username        string    `json:"username"`
+	Email           string    `json:"email"`
+	FirstName       string    `json:"firstName"`
+	LastName        string    `json:"lastName"`
+	City            string    `json:"city"`
+	PreferredLocale string    `json:"preferredLocale"`
+	Wallet          uint      `json:"wallet"`
+	DateOfBirth     string    `json:"dateOfBirth"`
 }
 
 // NewUserResponse transforms a single User domain model into a UserResponse.
 func NewUserResponse(user *model.User) *UserResponse {
 	return &UserResponse{
-		ID:          user.ID,
-		Username:    user.Username,
-		Email:       user.Email,
-		FirstName:   user.FirstName,
-		LastName:    user.LastName,
-		City:        user.City,
-		Wallet:      user.Wallet,
-		DateOfBirth: user.DateOfBirth.UTC().Format("2006-01-02"),
+		ID:              user.ID,
+		Username:        user.Username,
+		Email:           user.Email,
+		FirstName:       user.FirstName,
+		LastName:        user.LastName,
+		City:            user.City,
+		PreferredLocale: user.PreferredLocale,
+		Wallet:          user.Wallet,
+		DateOfBirth:     user.DateOfBirth.UTC().Format("2006-01-02"),
 	}
 }
 
@@ -0,0 +1,139 @@
+package presenter
+
+import (
+	"errors"
+	"golizilla/core/domain/model"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TransferRequest struct {
+	Recipient   string `json:"recipient"` // username or email
+	Amount      uint   `json:"amount"`
+	Description string `json:"description,omitempty"`
+}
+
+func (r *TransferRequest) Validate() error {
+	r.Recipient = strings.TrimSpace(r.Recipient)
+	if r.Recipient == "" {
+		return errors.New("recipient is required")
+	}
+	if r.Amount == 0 {
+		return errors.New("amount must be greater than zero")
+	}
+	if len(r.Description) > 255 {
+		return errors.New("description must be at most 255 characters")
+	}
+	return nil
+}
+
+type WalletBalanceResponse struct {
+	Balance uint `json:"balance"`
+}
+
+func NewWalletBalanceResponse(balance uint) WalletBalanceResponse {
+	return WalletBalanceResponse{Balance: balance}
+}
+
+type TransferResponse struct {
+	TransactionID uuid.UUID `json:"transaction_id"`
+	Balance       int64     `json:"balance"`
+}
+
+func NewTransferResponse(transaction *model.WalletTransaction, userID uuid.UUID) TransferResponse {
+	response := TransferResponse{TransactionID: transaction.ID}
+	for _, entry := range transaction.Entries {
+		if entry.AccountID == userID {
+			response.Balance = entry.BalanceAfter
+		}
+	}
+	return response
+}
+
+type LedgerEntryResponse struct {
+	ID            uuid.UUID   `json:"id"`
+	TransactionID uuid.UUID   `json:"transaction_id"`
+	Type          string      `json:"type"`
+	Description   string      `json:"description,omitempty"`
+	Amount        int64       `json:"amount"`
+	BalanceAfter  int64       `json:"balance_after"`
+	Counterparty  []uuid.UUID `json:"counterparty,omitempty"`
+	CreatedAt     time.Time   `json:"created_at"`
+}
+
+type PaginatedLedgerEntriesResponse struct {
+	Data  []LedgerEntryResponse `json:"data"`
+	Pages int                   `json:"pages"`
+	Page  int                   `json:"page"`
+}
+
+func NewLedgerEntryResponse(entry *model.LedgerEntry) LedgerEntryResponse {
+	response := LedgerEntryResponse{
+		ID:            entry.ID,
+		TransactionID: entry.TransactionID,
+		Amount:        entry.Amount,
+		BalanceAfter:  entry.BalanceAfter,
+		CreatedAt:     entry.CreatedAt,
+	}
+	if entry.Transaction != nil {
+		response.Type = string(entry.Transaction.Type)
+		response.Description = entry.Transaction.Description
+		for _, other := range entry.Transaction.Entries {
+			if other.AccountID != entry.AccountID {
+				response.Counterparty = append(response.Counterparty, other.AccountID)
+			}
+		}
+	}
+	return response
+}
+
+func NewPaginatedLedgerEntriesResponse(entries []model.LedgerEntry, pages, page int) PaginatedLedgerEntriesResponse {
+	data := make([]LedgerEntryResponse, len(entries))
+	for i := range entries {
+		data[i] = NewLedgerEntryResponse(&entries[i])
+	}
+	return PaginatedLedgerEntriesResponse{
+		Data:  data,
+		Pages: pages,
+		Page:  page,
+	}
+}
+
+type TopUpRequest struct {
+	Amount uint `json:"amount"`
+}
+
+func (r *TopUpRequest) Validate() error {
+	if r.Amount == 0 {
+		return errors.New("amount must be greater than zero")
+	}
+	return nil
+}
+
+type TopUpResponse struct {
+	ID            uuid.UUID  `json:"id"`
+	Amount        uint       `json:"amount"`
+	Status        string     `json:"status"`
+	Gateway       string     `json:"gateway"`
+	Reference     string     `json:"reference"`
+	CheckoutURL   string     `json:"checkout_url,omitempty"`
+	TransactionID *uuid.UUID `json:"transaction_id,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	CompletedAt   *time.Time `json:"completed_at,omitempty"`
+}
+
+func NewTopUpResponse(intent *model.TopUpIntent) TopUpResponse {
+	return TopUpResponse{
+		ID:            intent.ID,
+		Amount:        intent.Amount,
+		Status:        string(intent.Status),
+		Gateway:       intent.Gateway,
+		Reference:     intent.Reference,
+		CheckoutURL:   intent.CheckoutURL,
+		TransactionID: intent.TransactionID,
+		CreatedAt:     intent.CreatedAt,
+		CompletedAt:   intent.CompletedAt,
+	}
+}
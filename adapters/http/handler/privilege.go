@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"golizilla/adapters/http/handler/presenter"
+	"golizilla/adapters/persistence/logger"
+	"golizilla/core/service"
+	"golizilla/internal/apperrors"
+	logmessages "golizilla/internal/logmessages"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type PrivilegeHandler struct {
+	privilegeService service.IPrivilegeService
+}
+
+func NewPrivilegeHandler(privilegeService service.IPrivilegeService) *PrivilegeHandler {
+	return &PrivilegeHandler{
+		privilegeService: privilegeService,
+	}
+}
+
+// List returns all privileges with their descriptions.
+func (h *PrivilegeHandler) List(c *fiber.Ctx) error {
+	ctx := c.Context()
+
+	logger.GetLogger().LogInfoFromContext(ctx, logger.LogFields{
+		Service: logmessages.LogPrivilegeHandler,
+		Message: logmessages.LogPrivilegeListBegin,
+	})
+
+	privileges, err := h.privilegeService.List(ctx, c.UserContext())
+	if err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogPrivilegeHandler,
+			Message: err.Error(),
+		})
+		return presenter.SendError(c, fiber.StatusInternalServerError, apperrors.ErrInternalServerError.Error())
+	}
+
+	return presenter.Send(c, fiber.StatusOK, true, "Privileges fetched successfully", presenter.NewPrivilegesResponse(privileges), nil)
+}
@@ -0,0 +1,258 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"golizilla/adapters/http/handler/presenter"
+	"golizilla/adapters/persistence/logger"
+	"golizilla/core/domain/model"
+	"golizilla/core/service"
+	"golizilla/internal/apperrors"
+	logmessages "golizilla/internal/logmessages"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type PrivilegeAuditHandler struct {
+	privilegeAuditService service.IPrivilegeAuditService
+}
+
+func NewPrivilegeAuditHandler(privilegeAuditService service.IPrivilegeAuditService) *PrivilegeAuditHandler {
+	return &PrivilegeAuditHandler{
+		privilegeAuditService: privilegeAuditService,
+	}
+}
+
+// ListForQuestionnaire returns a page of the privilege changes on one of the
+// current user's questionnaires.
+func (h *PrivilegeAuditHandler) ListForQuestionnaire(c *fiber.Ctx) error {
+	ctx := c.Context()
+
+	logger.GetLogger().LogInfoFromContext(ctx, logger.LogFields{
+		Service: logmessages.LogPrivilegeAuditHandler,
+		Message: logmessages.LogPrivilegeAuditListBegin,
+	})
+
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogPrivilegeAuditHandler,
+			Message: logmessages.LogCastUserIdError,
+		})
+		return presenter.SendError(c, fiber.StatusUnauthorized, apperrors.ErrInvalidUserID.Error())
+	}
+
+	questionnaireID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return presenter.SendError(c, fiber.StatusBadRequest, "invalid ID format")
+	}
+
+	page, pageSize, err := parsePage(c)
+	if err != nil {
+		return presenter.SendError(c, fiber.StatusBadRequest, err.Error())
+	}
+	filter, err := parsePrivilegeAuditFilter(c)
+	if err != nil {
+		return presenter.SendError(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	auditLogs, err := h.privilegeAuditService.ListForQuestionnaire(ctx, c.UserContext(), userID, questionnaireID, filter, page, pageSize)
+	if err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogPrivilegeAuditHandler,
+			Message: err.Error(),
+		})
+		return h.handleError(c, err)
+	}
+
+	return presenter.Send(c, fiber.StatusOK, true, "Privilege audit logs fetched successfully",
+		presenter.NewPaginatedPrivilegeAuditLogsResponse(auditLogs.Data, auditLogs.Pages, auditLogs.Page), nil)
+}
+
+// ExportForQuestionnaire sends the privilege changes on one of the current user's
+// questionnaires as CSV.
+func (h *PrivilegeAuditHandler) ExportForQuestionnaire(c *fiber.Ctx) error {
+	ctx := c.Context()
+
+	logger.GetLogger().LogInfoFromContext(ctx, logger.LogFields{
+		Service: logmessages.LogPrivilegeAuditHandler,
+		Message: logmessages.LogPrivilegeAuditExportBegin,
+	})
+
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogPrivilegeAuditHandler,
+			Message: logmessages.LogCastUserIdError,
+		})
+		return presenter.SendError(c, fiber.StatusUnauthorized, apperrors.ErrInvalidUserID.Error())
+	}
+
+	questionnaireID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return presenter.SendError(c, fiber.StatusBadRequest, "invalid ID format")
+	}
+
+	filter, err := parsePrivilegeAuditFilter(c)
+	if err != nil {
+		return presenter.SendError(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	data, err := h.privilegeAuditService.ExportForQuestionnaire(ctx, c.UserContext(), userID, questionnaireID, filter)
+	if err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogPrivilegeAuditHandler,
+			Message: err.Error(),
+		})
+		return h.handleError(c, err)
+	}
+
+	return sendPrivilegeAuditCSV(c, fmt.Sprintf("privilege-audit-%s.csv", questionnaireID), data)
+}
+
+// List returns a page of the privilege changes on all questionnaires.
+func (h *PrivilegeAuditHandler) List(c *fiber.Ctx) error {
+	ctx := c.Context()
+
+	logger.GetLogger().LogInfoFromContext(ctx, logger.LogFields{
+		Service: logmessages.LogPrivilegeAuditHandler,
+		Message: logmessages.LogPrivilegeAuditListBegin,
+	})
+
+	page, pageSize, err := parsePage(c)
+	if err != nil {
+		return presenter.SendError(c, fiber.StatusBadRequest, err.Error())
+	}
+	filter, err := parsePrivilegeAuditFilter(c)
+	if err != nil {
+		return presenter.SendError(c, fiber.StatusBadRequest, err.Error())
+	}
+	if filter.QuestionnaireID, err = parseOptionalUUID(c, "questionnaireId"); err != nil {
+		return presenter.SendError(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	auditLogs, err := h.privilegeAuditService.List(ctx, c.UserContext(), filter, page, pageSize)
+	if err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogPrivilegeAuditHandler,
+			Message: err.Error(),
+		})
+		return h.handleError(c, err)
+	}
+
+	return presenter.Send(c, fiber.StatusOK, true, "Privilege audit logs fetched successfully",
+		presenter.NewPaginatedPrivilegeAuditLogsResponse(auditLogs.Data, auditLogs.Pages, auditLogs.Page), nil)
+}
+
+// Export sends the privilege changes on all questionnaires as CSV.
+func (h *PrivilegeAuditHandler) Export(c *fiber.Ctx) error {
+	ctx := c.Context()
+
+	logger.GetLogger().LogInfoFromContext(ctx, logger.LogFields{
+		Service: logmessages.LogPrivilegeAuditHandler,
+		Message: logmessages.LogPrivilegeAuditExportBegin,
+	})
+
+	filter, err := parsePrivilegeAuditFilter(c)
+	if err != nil {
+		return presenter.SendError(c, fiber.StatusBadRequest, err.Error())
+	}
+	if filter.QuestionnaireID, err = parseOptionalUUID(c, "questionnaireId"); err != nil {
+		return presenter.SendError(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	data, err := h.privilegeAuditService.Export(ctx, c.UserContext(), filter)
+	if err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogPrivilegeAuditHandler,
+			Message: err.Error(),
+		})
+		return h.handleError(c, err)
+	}
+
+	return sendPrivilegeAuditCSV(c, "privilege-audit.csv", data)
+}
+
+func (h *PrivilegeAuditHandler) handleError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, apperrors.ErrQuestionnaireNotFound):
+		return presenter.SendError(c, fiber.StatusNotFound, err.Error())
+	case errors.Is(err, apperrors.ErrLackOfAuthorization):
+		return presenter.SendError(c, fiber.StatusForbidden, err.Error())
+	default:
+		return presenter.SendError(c, fiber.StatusInternalServerError, apperrors.ErrInternalServerError.Error())
+	}
+}
+
+func sendPrivilegeAuditCSV(c *fiber.Ctx, fileName string, data []byte) error {
+	c.Set(fiber.HeaderContentType, "text/csv")
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", fileName))
+	return c.Status(fiber.StatusOK).Send(data)
+}
+
+func parsePage(c *fiber.Ctx) (int, int, error) {
+	// Get query parameters for page and pageSize
+	page, err := strconv.Atoi(c.Query("page", "1")) // Default to page 1 if not provided
+	if err != nil || page < 1 {
+		return 0, 0, errors.New("Invalid page number")
+	}
+
+	pageSize, err := strconv.Atoi(c.Query("pageSize", "10")) // Default to 10 items per page
+	if err != nil || pageSize < 1 {
+		return 0, 0, errors.New("Invalid page size")
+	}
+	return page, pageSize, nil
+}
+
+func parsePrivilegeAuditFilter(c *fiber.Ctx) (model.PrivilegeAuditFilter, error) {
+	var filter model.PrivilegeAuditFilter
+	var err error
+
+	switch action := model.PrivilegeAuditAction(c.Query("action")); action {
+	case "":
+	case model.PrivilegeAuditGrant, model.PrivilegeAuditRevoke:
+		filter.Action = action
+	default:
+		return filter, errors.New("Invalid action")
+	}
+	filter.Privilege = c.Query("privilege")
+	if filter.ActorID, err = parseOptionalUUID(c, "actorId"); err != nil {
+		return filter, err
+	}
+	if filter.TargetUserID, err = parseOptionalUUID(c, "targetUserId"); err != nil {
+		return filter, err
+	}
+	if filter.TargetRoleID, err = parseOptionalUUID(c, "targetRoleId"); err != nil {
+		return filter, err
+	}
+	if from := c.Query("from"); from != "" {
+		t, err := time.Parse(time.RFC3339, from)
+		if err != nil {
+			return filter, errors.New("Invalid from time, expected RFC3339")
+		}
+		filter.From = &t
+	}
+	if to := c.Query("to"); to != "" {
+		t, err := time.Parse(time.RFC3339, to)
+		if err != nil {
+			return filter, errors.New("Invalid to time, expected RFC3339")
+		}
+		filter.To = &t
+	}
+	return filter, nil
+}
+
+func parseOptionalUUID(c *fiber.Ctx, key string) (*uuid.UUID, error) {
+	value := c.Query(key)
+	if value == "" {
+		return nil, nil
+	}
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid %s", key)
+	}
+	return &id, nil
+}
@@ -16,11 +16,13 @@ import (
 
 type QuestionHandler struct {
 	QuestionService service.IQuestionService
+	fileMaxSize     int64
 }
 
-func NewQuestionHandler(questionService service.IQuestionService) *QuestionHandler {
+func NewQuestionHandler(questionService service.IQuestionService, fileMaxSize int64) *QuestionHandler {
 	return &QuestionHandler{
 		QuestionService: questionService,
+		fileMaxSize:     fileMaxSize,
 	}
 }
 
@@ -32,6 +34,15 @@ func (h *QuestionHandler) Create(c *fiber.Ctx) error {
 		Message: logmessages.LogQuestionCreateBegin,
 	})
 
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogQuestionHandler,
+			Message: logmessages.LogCastUserIdError,
+		})
+		return presenter.SendError(c, fiber.StatusUnauthorized, apperrors.ErrInvalidUserID.Error())
+	}
+
 	var request presenter.CreateQuestionRequest
 	if err := c.BodyParser(&request); err != nil {
 		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
@@ -44,7 +55,7 @@ func (h *QuestionHandler) Create(c *fiber.Ctx) error {
 		)
 	}
 
-	if err := request.Validate(); err != nil {
+	if err := request.Validate(h.fileMaxSize); err != nil {
 		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
 			Service: logmessages.LogQuestionHandler,
 			Message: err.Error(),
@@ -57,16 +68,13 @@ func (h *QuestionHandler) Create(c *fiber.Ctx) error {
 
 	question := request.ToDomain()
 
-	id, err := h.QuestionService.Create(ctx, c.UserContext(), question)
+	id, err := h.QuestionService.Create(ctx, c.UserContext(), userID, question)
 	if err != nil {
 		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
 			Service: logmessages.LogQuestionHandler,
 			Message: err.Error(),
 		})
-		return presenter.SendError(c,
-			fiber.StatusInternalServerError,
-			apperrors.ErrInternalServerError.Error(),
-		)
+		return h.handleError(c, err)
 	}
 
 	return presenter.Send(c,
@@ -85,6 +93,15 @@ func (h *QuestionHandler) Update(c *fiber.Ctx) error {
 		Message: logmessages.LogQuestionUpdateBegin,
 	})
 
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogQuestionHandler,
+			Message: logmessages.LogCastUserIdError,
+		})
+		return presenter.SendError(c, fiber.StatusUnauthorized, apperrors.ErrInvalidUserID.Error())
+	}
+
 	id, err := uuid.Parse(c.Params("id"))
 	if err != nil {
 		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
@@ -109,7 +126,7 @@ func (h *QuestionHandler) Update(c *fiber.Ctx) error {
 		)
 	}
 
-	if err := request.Validate(); err != nil {
+	if err := request.Validate(h.fileMaxSize); err != nil {
 		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
 			Service: logmessages.LogQuestionHandler,
 			Message: err.Error(),
@@ -126,30 +143,29 @@ func (h *QuestionHandler) Update(c *fiber.Ctx) error {
 			Service: logmessages.LogQuestionHandler,
 			Message: err.Error(),
 		})
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return presenter.SendError(c,
-				fiber.StatusNotFound,
-				apperrors.ErrNotFound.Error(),
-			)
-		}
-		return presenter.SendError(c,
-			fiber.StatusInternalServerError,
-			apperrors.ErrInternalServerError.Error(),
-		)
+		return h.handleError(c, err)
 	}
 
 	updatedQuestion := request.ToDomain(question)
-	err = h.QuestionService.Update(ctx, c.UserContext(), updatedQuestion)
-	if err != nil {
+	// the request may change the kind without touching the stored rules
+	if !updatedQuestion.Descriptive && !updatedQuestion.AnswerRules.IsZero() {
 		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
 			Service: logmessages.LogQuestionHandler,
-			Message: err.Error(),
+			Message: "answer rules are only allowed on descriptive questions",
 		})
 		return presenter.SendError(c,
-			fiber.StatusInternalServerError,
-			apperrors.ErrInternalServerError.Error(),
+			fiber.StatusBadRequest,
+			apperrors.ErrInvalidInput.Error(),
 		)
 	}
+	err = h.QuestionService.Update(ctx, c.UserContext(), userID, updatedQuestion)
+	if err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogQuestionHandler,
+			Message: err.Error(),
+		})
+		return h.handleError(c, err)
+	}
 
 	return presenter.Send(c,
 		fiber.StatusOK, true,
@@ -167,6 +183,15 @@ func (h *QuestionHandler) Delete(c *fiber.Ctx) error {
 		Message: logmessages.LogQuestionDeleteBegin,
 	})
 
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogQuestionHandler,
+			Message: logmessages.LogCastUserIdError,
+		})
+		return presenter.SendError(c, fiber.StatusUnauthorized, apperrors.ErrInvalidUserID.Error())
+	}
+
 	id, err := uuid.Parse(c.Params("id"))
 	if err != nil {
 		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
@@ -179,22 +204,13 @@ func (h *QuestionHandler) Delete(c *fiber.Ctx) error {
 		)
 	}
 
-	err = h.QuestionService.Delete(ctx, c.UserContext(), id)
+	err = h.QuestionService.Delete(ctx, c.UserContext(), userID, id)
 	if err != nil {
 		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
 			Service: logmessages.LogQuestionHandler,
 			Message: err.Error(),
 		})
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return presenter.SendError(c,
-				fiber.StatusNotFound,
-				apperrors.ErrNotFound.Error(),
-			)
-		}
-		return presenter.SendError(c,
-			fiber.StatusInternalServerError,
-			apperrors.ErrInternalServerError.Error(),
-		)
+		return h.handleError(c, err)
 	}
 
 	return presenter.Send(c,
@@ -214,6 +230,15 @@ func (h *QuestionHandler) GetByID(c *fiber.Ctx) error {
 		Message: logmessages.LogQuestionGetByIDBegin,
 	})
 
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogQuestionHandler,
+			Message: logmessages.LogCastUserIdError,
+		})
+		return presenter.SendError(c, fiber.StatusUnauthorized, apperrors.ErrInvalidUserID.Error())
+	}
+
 	id, err := uuid.Parse(c.Params("id"))
 	if err != nil {
 		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
@@ -226,22 +251,13 @@ func (h *QuestionHandler) GetByID(c *fiber.Ctx) error {
 		)
 	}
 
-	question, err := h.QuestionService.GetByID(ctx, c.UserContext(), id)
+	question, err := h.QuestionService.GetForUser(ctx, c.UserContext(), userID, id)
 	if err != nil {
 		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
 			Service: logmessages.LogQuestionHandler,
 			Message: err.Error(),
 		})
-		if errors.Is(err, apperrors.ErrQuestionsNotFound) {
-			return presenter.SendError(c,
-				fiber.StatusNotFound,
-				apperrors.ErrNotFound.Error(),
-			)
-		}
-		return presenter.SendError(c,
-			fiber.StatusInternalServerError,
-			apperrors.ErrInternalServerError.Error(),
-		)
+		return h.handleError(c, err)
 	}
 
 	return presenter.Send(c,
@@ -252,3 +268,27 @@ func (h *QuestionHandler) GetByID(c *fiber.Ctx) error {
 		nil,
 	)
 }
+
+func (h *QuestionHandler) handleError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, apperrors.ErrNotFound),
+		errors.Is(err, apperrors.ErrQuestionsNotFound),
+		errors.Is(err, apperrors.ErrQuestionnaireNotFound),
+		errors.Is(err, gorm.ErrRecordNotFound):
+		return presenter.SendError(c, fiber.StatusNotFound, apperrors.ErrNotFound.Error())
+	case errors.Is(err, apperrors.ErrLackOfAuthorization):
+		logForbidden(c, logmessages.LogQuestionHandler)
+		return presenter.SendError(c, fiber.StatusForbidden, err.Error())
+	default:
+		return presenter.SendError(c, fiber.StatusInternalServerError, apperrors.ErrInternalServerError.Error())
+	}
+}
+
+// logForbidden records who was refused which request, so attempts to touch other
+// users' questionnaires and answers show up in the logs.
+func logForbidden(c *fiber.Ctx, service string) {
+	logger.GetLogger().LogWarningFromContext(c.Context(), logger.LogFields{
+		Service: service,
+		Message: fmt.Sprintf("%s: user %v on %s %s", logmessages.LogLackOfAuthorization, c.Locals("user_id"), c.Method(), c.Path()),
+	})
+}
@@ -0,0 +1,349 @@
+package handler
+
+import (
+	"errors"
+	"golizilla/adapters/http/handler/presenter"
+	"golizilla/adapters/persistence/logger"
+	"golizilla/core/service"
+	"golizilla/internal/apperrors"
+	logmessages "golizilla/internal/logmessages"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type QuestionBankHandler struct {
+	questionBankService service.IQuestionBankService
+}
+
+func NewQuestionBankHandler(questionBankService service.IQuestionBankService) *QuestionBankHandler {
+	return &QuestionBankHandler{
+		questionBankService: questionBankService,
+	}
+}
+
+// Create adds a question to the bank of the user, or to the organisation-wide bank.
+func (h *QuestionBankHandler) Create(c *fiber.Ctx) error {
+	ctx := c.Context()
+
+	logger.GetLogger().LogInfoFromContext(ctx, logger.LogFields{
+		Service: logmessages.LogQuestionBankHandler,
+		Message: logmessages.LogQuestionBankCreateBegin,
+	})
+
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogQuestionBankHandler,
+			Message: logmessages.LogCastUserIdError,
+		})
+		return presenter.SendError(c, fiber.StatusUnauthorized, apperrors.ErrInvalidUserID.Error())
+	}
+
+	var request presenter.BankQuestionRequest
+	if err := c.BodyParser(&request); err != nil {
+		return presenter.SendError(c, fiber.StatusBadRequest, apperrors.ErrInvalidInput.Error())
+	}
+	if err := request.Validate(); err != nil {
+		return presenter.SendError(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	bankQuestion := request.ToDomain()
+	if err := h.questionBankService.Create(ctx, c.UserContext(), userID, bankQuestion); err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogQuestionBankHandler,
+			Message: err.Error(),
+		})
+		return h.handleError(c, err)
+	}
+
+	return presenter.Send(c, fiber.StatusCreated, true, "Bank question created successfully", presenter.NewBankQuestionResponse(bankQuestion), nil)
+}
+
+// List returns the bank questions of the user and the organisation-wide ones.
+func (h *QuestionBankHandler) List(c *fiber.Ctx) error {
+	ctx := c.Context()
+
+	logger.GetLogger().LogInfoFromContext(ctx, logger.LogFields{
+		Service: logmessages.LogQuestionBankHandler,
+		Message: logmessages.LogQuestionBankListBegin,
+	})
+
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogQuestionBankHandler,
+			Message: logmessages.LogCastUserIdError,
+		})
+		return presenter.SendError(c, fiber.StatusUnauthorized, apperrors.ErrInvalidUserID.Error())
+	}
+
+	// Get query parameters for page and pageSize
+	page, err := strconv.Atoi(c.Query("page", "1")) // Default to page 1 if not provided
+	if err != nil || page < 1 {
+		return presenter.SendError(c, fiber.StatusBadRequest, "Invalid page number")
+	}
+
+	pageSize, err := strconv.Atoi(c.Query("pageSize", "10")) // Default to 10 items per page
+	if err != nil || pageSize < 1 {
+		return presenter.SendError(c, fiber.StatusBadRequest, "Invalid page size")
+	}
+
+	bankQuestions, err := h.questionBankService.List(ctx, c.UserContext(), userID, page, pageSize)
+	if err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogQuestionBankHandler,
+			Message: err.Error(),
+		})
+		return h.handleError(c, err)
+	}
+
+	return presenter.Send(c,
+		fiber.StatusOK,
+		true,
+		"Bank questions fetched successfully",
+		presenter.NewPaginatedBankQuestionsResponse(bankQuestions.Data, bankQuestions.Pages, bankQuestions.Page),
+		nil,
+	)
+}
+
+func (h *QuestionBankHandler) GetByID(c *fiber.Ctx) error {
+	ctx := c.Context()
+
+	logger.GetLogger().LogInfoFromContext(ctx, logger.LogFields{
+		Service: logmessages.LogQuestionBankHandler,
+		Message: logmessages.LogQuestionBankGetBegin,
+	})
+
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogQuestionBankHandler,
+			Message: logmessages.LogCastUserIdError,
+		})
+		return presenter.SendError(c, fiber.StatusUnauthorized, apperrors.ErrInvalidUserID.Error())
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return presenter.SendError(c, fiber.StatusBadRequest, "invalid ID format")
+	}
+
+	bankQuestion, err := h.questionBankService.GetByID(ctx, c.UserContext(), userID, id)
+	if err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogQuestionBankHandler,
+			Message: err.Error(),
+		})
+		return h.handleError(c, err)
+	}
+
+	return presenter.Send(c, fiber.StatusOK, true, "Bank question fetched successfully", presenter.NewBankQuestionResponse(bankQuestion), nil)
+}
+
+// Update publishes a new version of a bank question.
+func (h *QuestionBankHandler) Update(c *fiber.Ctx) error {
+	ctx := c.Context()
+
+	logger.GetLogger().LogInfoFromContext(ctx, logger.LogFields{
+		Service: logmessages.LogQuestionBankHandler,
+		Message: logmessages.LogQuestionBankUpdateBegin,
+	})
+
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogQuestionBankHandler,
+			Message: logmessages.LogCastUserIdError,
+		})
+		return presenter.SendError(c, fiber.StatusUnauthorized, apperrors.ErrInvalidUserID.Error())
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return presenter.SendError(c, fiber.StatusBadRequest, "invalid ID format")
+	}
+
+	var request presenter.BankQuestionRequest
+	if err := c.BodyParser(&request); err != nil {
+		return presenter.SendError(c, fiber.StatusBadRequest, apperrors.ErrInvalidInput.Error())
+	}
+	if err := request.Validate(); err != nil {
+		return presenter.SendError(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	bankQuestion, err := h.questionBankService.Update(ctx, c.UserContext(), userID, id, request.ToDomain())
+	if err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogQuestionBankHandler,
+			Message: err.Error(),
+		})
+		return h.handleError(c, err)
+	}
+
+	return presenter.Send(c, fiber.StatusOK, true, "Bank question updated successfully", presenter.NewBankQuestionResponse(bankQuestion), nil)
+}
+
+func (h *QuestionBankHandler) Delete(c *fiber.Ctx) error {
+	ctx := c.Context()
+
+	logger.GetLogger().LogInfoFromContext(ctx, logger.LogFields{
+		Service: logmessages.LogQuestionBankHandler,
+		Message: logmessages.LogQuestionBankDeleteBegin,
+	})
+
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogQuestionBankHandler,
+			Message: logmessages.LogCastUserIdError,
+		})
+		return presenter.SendError(c, fiber.StatusUnauthorized, apperrors.ErrInvalidUserID.Error())
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return presenter.SendError(c, fiber.StatusBadRequest, "invalid ID format")
+	}
+
+	if err := h.questionBankService.Delete(ctx, c.UserContext(), userID, id); err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogQuestionBankHandler,
+			Message: err.Error(),
+		})
+		return h.handleError(c, err)
+	}
+
+	return presenter.Send(c, fiber.StatusOK, true, "Bank question deleted successfully", nil, nil)
+}
+
+// Use links or copies a bank question into a questionnaire.
+func (h *QuestionBankHandler) Use(c *fiber.Ctx) error {
+	ctx := c.Context()
+
+	logger.GetLogger().LogInfoFromContext(ctx, logger.LogFields{
+		Service: logmessages.LogQuestionBankHandler,
+		Message: logmessages.LogQuestionBankUseBegin,
+	})
+
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogQuestionBankHandler,
+			Message: logmessages.LogCastUserIdError,
+		})
+		return presenter.SendError(c, fiber.StatusUnauthorized, apperrors.ErrInvalidUserID.Error())
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return presenter.SendError(c, fiber.StatusBadRequest, "invalid ID format")
+	}
+
+	var request presenter.UseBankQuestionRequest
+	if err := c.BodyParser(&request); err != nil {
+		return presenter.SendError(c, fiber.StatusBadRequest, apperrors.ErrInvalidInput.Error())
+	}
+	if err := request.Validate(); err != nil {
+		return presenter.SendError(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	question, err := h.questionBankService.UseInQuestionnaire(ctx, c.UserContext(), userID, id, request.QuestionnaireID, request.Index, request.Linked)
+	if err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogQuestionBankHandler,
+			Message: err.Error(),
+		})
+		return h.handleError(c, err)
+	}
+
+	return presenter.Send(c, fiber.StatusCreated, true, "Bank question added to questionnaire", presenter.NewGetQuestionResponse(question), nil)
+}
+
+// Sync pulls the latest bank version into a linked questionnaire question, :id is
+// the questionnaire question.
+func (h *QuestionBankHandler) Sync(c *fiber.Ctx) error {
+	ctx := c.Context()
+
+	logger.GetLogger().LogInfoFromContext(ctx, logger.LogFields{
+		Service: logmessages.LogQuestionBankHandler,
+		Message: logmessages.LogQuestionBankSyncBegin,
+	})
+
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogQuestionBankHandler,
+			Message: logmessages.LogCastUserIdError,
+		})
+		return presenter.SendError(c, fiber.StatusUnauthorized, apperrors.ErrInvalidUserID.Error())
+	}
+
+	questionID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return presenter.SendError(c, fiber.StatusBadRequest, "invalid ID format")
+	}
+
+	question, err := h.questionBankService.SyncQuestion(ctx, c.UserContext(), userID, questionID)
+	if err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogQuestionBankHandler,
+			Message: err.Error(),
+		})
+		return h.handleError(c, err)
+	}
+
+	return presenter.Send(c, fiber.StatusOK, true, "Question synced with the question bank", presenter.NewGetQuestionResponse(question), nil)
+}
+
+// Results compares the answers to a bank question across questionnaires.
+func (h *QuestionBankHandler) Results(c *fiber.Ctx) error {
+	ctx := c.Context()
+
+	logger.GetLogger().LogInfoFromContext(ctx, logger.LogFields{
+		Service: logmessages.LogQuestionBankHandler,
+		Message: logmessages.LogQuestionBankResultBegin,
+	})
+
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogQuestionBankHandler,
+			Message: logmessages.LogCastUserIdError,
+		})
+		return presenter.SendError(c, fiber.StatusUnauthorized, apperrors.ErrInvalidUserID.Error())
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return presenter.SendError(c, fiber.StatusBadRequest, "invalid ID format")
+	}
+
+	results, err := h.questionBankService.GetResults(ctx, c.UserContext(), userID, id)
+	if err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogQuestionBankHandler,
+			Message: err.Error(),
+		})
+		return h.handleError(c, err)
+	}
+
+	return presenter.Send(c, fiber.StatusOK, true, "Bank question results fetched successfully", results, nil)
+}
+
+func (h *QuestionBankHandler) handleError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, apperrors.ErrBankQuestionNotFound),
+		errors.Is(err, apperrors.ErrQuestionnaireNotFound),
+		errors.Is(err, apperrors.ErrNotFound),
+		errors.Is(err, apperrors.ErrOptionNotFound):
+		return presenter.SendError(c, fiber.StatusNotFound, err.Error())
+	case errors.Is(err, apperrors.ErrLackOfAuthorization):
+		return presenter.SendError(c, fiber.StatusForbidden, err.Error())
+	case errors.Is(err, apperrors.ErrQuestionNotLinked):
+		return presenter.SendError(c, fiber.StatusBadRequest, err.Error())
+	default:
+		return presenter.SendError(c, fiber.StatusInternalServerError, apperrors.ErrInternalServerError.Error())
+	}
+}
@@ -0,0 +1,149 @@
+package handler
+
+import (
+	"errors"
+	"golizilla/adapters/http/handler/presenter"
+	"golizilla/adapters/persistence/logger"
+	"golizilla/core/service"
+	"golizilla/internal/apperrors"
+	"golizilla/internal/logmessages"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type QuestionMediaHandler struct {
+	questionMediaService service.IQuestionMediaService
+}
+
+func NewQuestionMediaHandler(questionMediaService service.IQuestionMediaService) *QuestionMediaHandler {
+	return &QuestionMediaHandler{
+		questionMediaService: questionMediaService,
+	}
+}
+
+// Upload expects a multipart form with the media in the "file" field.
+func (h *QuestionMediaHandler) Upload(c *fiber.Ctx) error {
+	ctx := c.Context()
+
+	logger.GetLogger().LogInfoFromContext(ctx, logger.LogFields{
+		Service: logmessages.LogQuestionMediaHandler,
+		Message: logmessages.LogQuestionMediaUploadBegin,
+	})
+
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		return presenter.SendError(c, fiber.StatusUnauthorized, apperrors.ErrInvalidUserID.Error())
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return presenter.SendError(c, fiber.StatusBadRequest, "invalid ID format")
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		logger.GetLogger().LogWarningFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogQuestionMediaHandler,
+			Message: err.Error(),
+		})
+		return presenter.SendError(c, fiber.StatusBadRequest, "file is required")
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		return presenter.SendError(c, fiber.StatusBadRequest, apperrors.ErrInvalidInput.Error())
+	}
+	defer file.Close()
+
+	question, err := h.questionMediaService.Upload(ctx, c.UserContext(), userID, id, fileHeader.Size, file)
+	if err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogQuestionMediaHandler,
+			Message: err.Error(),
+		})
+		return h.handleError(c, err)
+	}
+
+	return presenter.Send(c, fiber.StatusOK, true, "question media uploaded successfully", presenter.NewGetQuestionResponse(question), nil)
+}
+
+// Download streams the media of a question instead of buffering it in memory.
+func (h *QuestionMediaHandler) Download(c *fiber.Ctx) error {
+	ctx := c.Context()
+
+	logger.GetLogger().LogInfoFromContext(ctx, logger.LogFields{
+		Service: logmessages.LogQuestionMediaHandler,
+		Message: logmessages.LogQuestionMediaDownloadBegin,
+	})
+
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		return presenter.SendError(c, fiber.StatusUnauthorized, apperrors.ErrInvalidUserID.Error())
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return presenter.SendError(c, fiber.StatusBadRequest, "invalid ID format")
+	}
+
+	reader, question, err := h.questionMediaService.Open(ctx, c.UserContext(), userID, id)
+	if err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogQuestionMediaHandler,
+			Message: err.Error(),
+		})
+		return h.handleError(c, err)
+	}
+
+	c.Set(fiber.HeaderContentType, question.MetaDataContentType)
+	c.Set(fiber.HeaderContentDisposition, "inline")
+	c.Set("X-Content-Type-Options", "nosniff")
+	// the reader is closed by fasthttp once the body has been written
+	return c.SendStream(reader, int(question.MetaDataSize))
+}
+
+func (h *QuestionMediaHandler) Delete(c *fiber.Ctx) error {
+	ctx := c.Context()
+
+	logger.GetLogger().LogInfoFromContext(ctx, logger.LogFields{
+		Service: logmessages.LogQuestionMediaHandler,
+		Message: logmessages.LogQuestionMediaDeleteBegin,
+	})
+
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		return presenter.SendError(c, fiber.StatusUnauthorized, apperrors.ErrInvalidUserID.Error())
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return presenter.SendError(c, fiber.StatusBadRequest, "invalid ID format")
+	}
+
+	if err := h.questionMediaService.Delete(ctx, c.UserContext(), userID, id); err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogQuestionMediaHandler,
+			Message: err.Error(),
+		})
+		return h.handleError(c, err)
+	}
+
+	return presenter.Send(c, fiber.StatusOK, true, "question media deleted successfully", nil, nil)
+}
+
+func (h *QuestionMediaHandler) handleError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, apperrors.ErrNotFound),
+		errors.Is(err, apperrors.ErrQuestionnaireNotFound),
+		errors.Is(err, apperrors.ErrFileNotFound):
+		return presenter.SendError(c, fiber.StatusNotFound, err.Error())
+	case errors.Is(err, apperrors.ErrLackOfAuthorization):
+		return presenter.SendError(c, fiber.StatusForbidden, err.Error())
+	case errors.Is(err, apperrors.ErrFileTooLarge):
+		return presenter.SendError(c, fiber.StatusRequestEntityTooLarge, err.Error())
+	case errors.Is(err, apperrors.ErrUnsupportedMediaType):
+		return presenter.SendError(c, fiber.StatusUnsupportedMediaType, err.Error())
+	default:
+		return presenter.SendError(c, fiber.StatusInternalServerError, apperrors.ErrInternalServerError.Error())
+	}
+}
@@ -0,0 +1,237 @@
+package handler
+
+import (
+	"errors"
+	"golizilla/adapters/http/handler/presenter"
+	"golizilla/adapters/persistence/logger"
+	"golizilla/core/service"
+	"golizilla/internal/apperrors"
+	logmessages "golizilla/internal/logmessages"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type QuestionPoolHandler struct {
+	questionPoolService service.IQuestionPoolService
+}
+
+func NewQuestionPoolHandler(questionPoolService service.IQuestionPoolService) *QuestionPoolHandler {
+	return &QuestionPoolHandler{
+		questionPoolService: questionPoolService,
+	}
+}
+
+// Create adds a question pool to the questionnaire of the path.
+func (h *QuestionPoolHandler) Create(c *fiber.Ctx) error {
+	ctx := c.Context()
+
+	logger.GetLogger().LogInfoFromContext(ctx, logger.LogFields{
+		Service: logmessages.LogQuestionPoolHandler,
+		Message: logmessages.LogQuestionPoolCreateBegin,
+	})
+
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogQuestionPoolHandler,
+			Message: logmessages.LogCastUserIdError,
+		})
+		return presenter.SendError(c, fiber.StatusUnauthorized, apperrors.ErrInvalidUserID.Error())
+	}
+
+	questionnaireID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return presenter.SendError(c, fiber.StatusBadRequest, "invalid ID format")
+	}
+
+	var request presenter.QuestionPoolRequest
+	if err := c.BodyParser(&request); err != nil {
+		return presenter.SendError(c, fiber.StatusBadRequest, apperrors.ErrInvalidInput.Error())
+	}
+	if err := request.Validate(); err != nil {
+		return presenter.SendError(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	pool, err := h.questionPoolService.Create(ctx, c.UserContext(), userID, questionnaireID, request.ToDomain(), request.QuestionIDs)
+	if err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogQuestionPoolHandler,
+			Message: err.Error(),
+		})
+		return h.handleError(c, err)
+	}
+
+	return presenter.Send(c, fiber.StatusCreated, true, "Question pool created successfully", presenter.NewQuestionPoolResponse(pool), nil)
+}
+
+// List returns the question pools of a questionnaire.
+func (h *QuestionPoolHandler) List(c *fiber.Ctx) error {
+	ctx := c.Context()
+
+	logger.GetLogger().LogInfoFromContext(ctx, logger.LogFields{
+		Service: logmessages.LogQuestionPoolHandler,
+		Message: logmessages.LogQuestionPoolListBegin,
+	})
+
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogQuestionPoolHandler,
+			Message: logmessages.LogCastUserIdError,
+		})
+		return presenter.SendError(c, fiber.StatusUnauthorized, apperrors.ErrInvalidUserID.Error())
+	}
+
+	questionnaireID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return presenter.SendError(c, fiber.StatusBadRequest, "invalid ID format")
+	}
+
+	pools, err := h.questionPoolService.List(ctx, c.UserContext(), userID, questionnaireID)
+	if err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogQuestionPoolHandler,
+			Message: err.Error(),
+		})
+		return h.handleError(c, err)
+	}
+
+	return presenter.Send(c, fiber.StatusOK, true, "Question pools fetched successfully", presenter.NewQuestionPoolsResponse(pools), nil)
+}
+
+// Update replaces the settings and questions of a pool.
+func (h *QuestionPoolHandler) Update(c *fiber.Ctx) error {
+	ctx := c.Context()
+
+	logger.GetLogger().LogInfoFromContext(ctx, logger.LogFields{
+		Service: logmessages.LogQuestionPoolHandler,
+		Message: logmessages.LogQuestionPoolUpdateBegin,
+	})
+
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogQuestionPoolHandler,
+			Message: logmessages.LogCastUserIdError,
+		})
+		return presenter.SendError(c, fiber.StatusUnauthorized, apperrors.ErrInvalidUserID.Error())
+	}
+
+	questionnaireID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return presenter.SendError(c, fiber.StatusBadRequest, "invalid ID format")
+	}
+	poolID, err := uuid.Parse(c.Params("poolId"))
+	if err != nil {
+		return presenter.SendError(c, fiber.StatusBadRequest, "invalid ID format")
+	}
+
+	var request presenter.QuestionPoolRequest
+	if err := c.BodyParser(&request); err != nil {
+		return presenter.SendError(c, fiber.StatusBadRequest, apperrors.ErrInvalidInput.Error())
+	}
+	if err := request.Validate(); err != nil {
+		return presenter.SendError(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	pool, err := h.questionPoolService.Update(ctx, c.UserContext(), userID, questionnaireID, poolID, request.ToDomain(), request.QuestionIDs)
+	if err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogQuestionPoolHandler,
+			Message: err.Error(),
+		})
+		return h.handleError(c, err)
+	}
+
+	return presenter.Send(c, fiber.StatusOK, true, "Question pool updated successfully", presenter.NewQuestionPoolResponse(pool), nil)
+}
+
+// Delete removes a pool, its questions are asked to every respondent again.
+func (h *QuestionPoolHandler) Delete(c *fiber.Ctx) error {
+	ctx := c.Context()
+
+	logger.GetLogger().LogInfoFromContext(ctx, logger.LogFields{
+		Service: logmessages.LogQuestionPoolHandler,
+		Message: logmessages.LogQuestionPoolDeleteBegin,
+	})
+
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogQuestionPoolHandler,
+			Message: logmessages.LogCastUserIdError,
+		})
+		return presenter.SendError(c, fiber.StatusUnauthorized, apperrors.ErrInvalidUserID.Error())
+	}
+
+	questionnaireID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return presenter.SendError(c, fiber.StatusBadRequest, "invalid ID format")
+	}
+	poolID, err := uuid.Parse(c.Params("poolId"))
+	if err != nil {
+		return presenter.SendError(c, fiber.StatusBadRequest, "invalid ID format")
+	}
+
+	if err := h.questionPoolService.Delete(ctx, c.UserContext(), userID, questionnaireID, poolID); err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogQuestionPoolHandler,
+			Message: err.Error(),
+		})
+		return h.handleError(c, err)
+	}
+
+	return presenter.Send(c, fiber.StatusOK, true, "Question pool deleted successfully", nil, nil)
+}
+
+// GetExamResults grades every submission on the questions drawn for it.
+func (h *QuestionPoolHandler) GetExamResults(c *fiber.Ctx) error {
+	ctx := c.Context()
+
+	logger.GetLogger().LogInfoFromContext(ctx, logger.LogFields{
+		Service: logmessages.LogQuestionPoolHandler,
+		Message: logmessages.LogQuestionPoolResultsBegin,
+	})
+
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogQuestionPoolHandler,
+			Message: logmessages.LogCastUserIdError,
+		})
+		return presenter.SendError(c, fiber.StatusUnauthorized, apperrors.ErrInvalidUserID.Error())
+	}
+
+	questionnaireID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return presenter.SendError(c, fiber.StatusBadRequest, "invalid ID format")
+	}
+
+	results, err := h.questionPoolService.GetExamResults(ctx, c.UserContext(), userID, questionnaireID)
+	if err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogQuestionPoolHandler,
+			Message: err.Error(),
+		})
+		return h.handleError(c, err)
+	}
+
+	return presenter.Send(c, fiber.StatusOK, true, "Exam results fetched successfully", results, nil)
+}
+
+func (h *QuestionPoolHandler) handleError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, apperrors.ErrQuestionPoolNotFound),
+		errors.Is(err, apperrors.ErrQuestionnaireNotFound),
+		errors.Is(err, apperrors.ErrNotFound):
+		return presenter.SendError(c, fiber.StatusNotFound, err.Error())
+	case errors.Is(err, apperrors.ErrLackOfAuthorization):
+		return presenter.SendError(c, fiber.StatusForbidden, err.Error())
+	case errors.Is(err, apperrors.ErrQuestionNotInQuestionnaire),
+		errors.Is(err, apperrors.ErrInvalidDrawCount):
+		return presenter.SendError(c, fiber.StatusBadRequest, err.Error())
+	default:
+		return presenter.SendError(c, fiber.StatusInternalServerError, apperrors.ErrInternalServerError.Error())
+	}
+}
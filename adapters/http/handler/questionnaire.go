@@ -2,9 +2,11 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"golizilla/adapters/http/handler/presenter"
 	"golizilla/adapters/persistence/logger"
+	"golizilla/core/domain/model"
 	"golizilla/core/service"
 	"golizilla/internal/apperrors"
 	"golizilla/internal/logmessages"
@@ -18,23 +20,32 @@ import (
 )
 
 type QuestionnaireHandler struct {
-	questionnaireService service.IQuestionnaireService
-	roleService          service.IRoleService
-	userService          service.IUserService
-	answerService        service.IAnswerService
-	questionService      service.IQuestionService
+	questionnaireService  service.IQuestionnaireService
+	roleService           service.IRoleService
+	userService           service.IUserService
+	answerService         service.IAnswerService
+	questionService       service.IQuestionService
+	translationService    service.ITranslationService
+	privilegeAuditService service.IPrivilegeAuditService
+	eligibilityService    service.IEligibilityService
 }
 
 func NewQuestionnaireHandler(
 	questionnaireService service.IQuestionnaireService,
 	roleService service.IRoleService,
 	userService service.IUserService,
-	questionService service.IQuestionService) *QuestionnaireHandler {
+	questionService service.IQuestionService,
+	translationService service.ITranslationService,
+	privilegeAuditService service.IPrivilegeAuditService,
+	eligibilityService service.IEligibilityService) *QuestionnaireHandler {
 	return &QuestionnaireHandler{
-		questionnaireService: questionnaireService,
-		roleService:          roleService,
-		userService:          userService,
-		questionService:      questionService,
+		questionnaireService:  questionnaireService,
+		roleService:           roleService,
+		userService:           userService,
+		questionService:       questionService,
+		translationService:    translationService,
+		privilegeAuditService: privilegeAuditService,
+		eligibilityService:    eligibilityService,
 	}
 }
 
@@ -296,6 +307,15 @@ func (q *QuestionnaireHandler) GetById(c *fiber.Ctx) error {
 			return presenter.SendError(c, fiber.StatusInternalServerError, apperrors.ErrLackOfAuthorization.Error())
 		}
 	}
+	locale, err := q.translationService.LocalizeQuestionnaire(ctx, c.UserContext(), userID, questionnaire, c.Get(fiber.HeaderAcceptLanguage))
+	if err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogQuestionnaireHandler,
+			Message: err.Error(),
+		})
+		return presenter.SendError(c, fiber.StatusInternalServerError, apperrors.ErrInternalServerError.Error())
+	}
+	c.Set(fiber.HeaderContentLanguage, locale)
 	err = presenter.Send(c, fiber.StatusOK, true, "", presenter.NewGetQuestionnaireResponse(questionnaire), nil)
 	if err != nil {
 		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
@@ -346,12 +366,82 @@ func (q *QuestionnaireHandler) GetByOwnerId(c *fiber.Ctx) error {
 		return presenter.SendError(c, fiber.StatusInternalServerError, err.Error())
 	}
 
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogQuestionnaireHandler,
+			Message: logmessages.LogCastUserIdError,
+		})
+		return presenter.SendError(c, fiber.StatusUnauthorized, apperrors.ErrInvalidUserID.Error())
+	}
+	ineligible, err := q.eligibilityService.CheckAll(ctx, c.UserContext(), userID, questionnaires)
+	if err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogQuestionnaireHandler,
+			Message: err.Error(),
+		})
+		return presenter.SendError(c, fiber.StatusInternalServerError, apperrors.ErrInternalServerError.Error())
+	}
+
 	logger.GetLogger().LogInfoFromContext(ctx, logger.LogFields{
 		Service: logmessages.LogQuestionnaireHandler,
 		Message: logmessages.LogQuestionnaireGetByOwnerIdSuccessful,
 	})
 
-	return presenter.Send(c, fiber.StatusOK, true, "", presenter.NewGetQuestionnairesResponse(questionnaires), nil)
+	return presenter.Send(c, fiber.StatusOK, true, "", presenter.NewGetQuestionnairesResponse(questionnaires, ineligible), nil)
+}
+
+// SetEligibility replaces the rules restricting who may answer the questionnaire.
+// An empty body removes every rule.
+func (q *QuestionnaireHandler) SetEligibility(c *fiber.Ctx) error {
+	ctx := c.Context()
+
+	logger.GetLogger().LogInfoFromContext(ctx, logger.LogFields{
+		Service: logmessages.LogQuestionnaireHandler,
+		Message: logmessages.LogQuestionnaireEligibilityBegin,
+	})
+
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogQuestionnaireHandler,
+			Message: logmessages.LogCastUserIdError,
+		})
+		return presenter.SendError(c, fiber.StatusUnauthorized, apperrors.ErrInvalidUserID.Error())
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return presenter.SendError(c, fiber.StatusBadRequest, "invalid ID format")
+	}
+
+	var request presenter.EligibilityRulesRequest
+	if err := c.BodyParser(&request); err != nil {
+		return presenter.SendError(c, fiber.StatusBadRequest, apperrors.ErrInvalidInput.Error())
+	}
+	if err := request.Validate(); err != nil {
+		return presenter.SendError(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	if err := q.eligibilityService.SetRules(ctx, c.UserContext(), userID, id, request.ToDomain()); err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogQuestionnaireHandler,
+			Message: err.Error(),
+		})
+		switch {
+		case errors.Is(err, apperrors.ErrQuestionnaireNotFound), errors.Is(err, gorm.ErrRecordNotFound),
+			errors.Is(err, apperrors.ErrRoleNotFound):
+			return presenter.SendError(c, fiber.StatusNotFound, err.Error())
+		case errors.Is(err, apperrors.ErrInvalidInput):
+			return presenter.SendError(c, fiber.StatusBadRequest, err.Error())
+		case errors.Is(err, apperrors.ErrLackOfAuthorization):
+			return presenter.SendError(c, fiber.StatusForbidden, err.Error())
+		default:
+			return presenter.SendError(c, fiber.StatusInternalServerError, apperrors.ErrInternalServerError.Error())
+		}
+	}
+
+	return presenter.Send(c, fiber.StatusOK, true, "Eligibility rules saved successfully", nil, nil)
 }
 
 func (q *QuestionnaireHandler) GiveAcess(c *fiber.Ctx) error {
@@ -421,21 +511,21 @@ func (q *QuestionnaireHandler) GiveAcess(c *fiber.Ctx) error {
 	}
 
 	for _, user := range request.UserIDs {
-		role, err := q.roleService.GetRoleByUserId(ctx, c.UserContext(), user)
+		err := q.roleService.AddUserPrivilegeOnInstance(ctx, c.UserContext(), user, id, request.Privileges...)
 		if err != nil {
 			logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
 				Service: logmessages.LogQuestionnaireHandler,
 				Message: err.Error(),
 			})
-			return presenter.SendError(c, fiber.StatusBadRequest, "failed to get role")
+			return presenter.SendError(c, fiber.StatusBadRequest, "failed to add privilges on instance")
 		}
-		err = q.roleService.AddPrivilegeOnInstance(ctx, c.UserContext(), role.ID, id, request.Privileges...)
+		err = q.privilegeAuditService.Record(ctx, c.UserContext(), model.PrivilegeAuditGrant, userID, id, user, request.Privileges...)
 		if err != nil {
 			logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
 				Service: logmessages.LogQuestionnaireHandler,
 				Message: err.Error(),
 			})
-			return presenter.SendError(c, fiber.StatusBadRequest, "failed to add privilges on instance")
+			return presenter.SendError(c, fiber.StatusInternalServerError, apperrors.ErrInternalServerError.Error())
 		}
 		err = q.userService.CreateNotification(ctx, c.UserContext(), user, fmt.Sprintf("Your role changed. permissions added : %v", request.Privileges))
 		if err != nil {
@@ -507,21 +597,24 @@ func (q *QuestionnaireHandler) DeleteAcess(c *fiber.Ctx) error {
 	}
 
 	for _, user := range request.UserIDs {
-		role, err := q.roleService.GetRoleByUserId(ctx, c.UserContext(), user)
+		err := q.roleService.DeleteUserPrivilegeOnInstance(ctx, c.UserContext(), user, id, request.Privileges...)
 		if err != nil {
 			logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
 				Service: logmessages.LogQuestionnaireHandler,
 				Message: err.Error(),
 			})
-			return presenter.SendError(c, fiber.StatusBadRequest, "failed to get role")
+			if errors.Is(err, apperrors.ErrPrivilegeGrantedByRole) {
+				return presenter.SendError(c, fiber.StatusConflict, err.Error())
+			}
+			return presenter.SendError(c, fiber.StatusBadRequest, "failed to delelte privilges on instance")
 		}
-		err = q.roleService.DeletePrivilegeOnInstance(ctx, c.UserContext(), role.ID, id, request.Privileges...)
+		err = q.privilegeAuditService.Record(ctx, c.UserContext(), model.PrivilegeAuditRevoke, userID, id, user, request.Privileges...)
 		if err != nil {
 			logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
 				Service: logmessages.LogQuestionnaireHandler,
 				Message: err.Error(),
 			})
-			return presenter.SendError(c, fiber.StatusBadRequest, "failed to delelte privilges on instance")
+			return presenter.SendError(c, fiber.StatusInternalServerError, apperrors.ErrInternalServerError.Error())
 		}
 		err = q.userService.CreateNotification(ctx, c.UserContext(), user, fmt.Sprintf("Your role changed. permissions deleted : %v", request.Privileges))
 		if err != nil {
@@ -540,6 +633,89 @@ func (q *QuestionnaireHandler) DeleteAcess(c *fiber.Ctx) error {
 	return presenter.Send(c, fiber.StatusOK, true, "", nil, nil)
 }
 
+// GiveRoleAcess grants privileges on the questionnaire to every user of the given
+// roles. Unlike GiveAcess it is reserved to the owner.
+func (q *QuestionnaireHandler) GiveRoleAcess(c *fiber.Ctx) error {
+	return q.changeRoleAccess(c, model.PrivilegeAuditGrant)
+}
+
+// DeleteRoleAcess revokes privileges on the questionnaire granted to whole roles.
+func (q *QuestionnaireHandler) DeleteRoleAcess(c *fiber.Ctx) error {
+	return q.changeRoleAccess(c, model.PrivilegeAuditRevoke)
+}
+
+func (q *QuestionnaireHandler) changeRoleAccess(c *fiber.Ctx, action model.PrivilegeAuditAction) error {
+	ctx := c.Context()
+
+	logger.GetLogger().LogInfoFromContext(ctx, logger.LogFields{
+		Service: logmessages.LogQuestionnaireHandler,
+		Message: logmessages.LogQuestionnaireRoleAccessBegin,
+	})
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return presenter.SendError(c, fiber.StatusBadRequest, "invalid ID format")
+	}
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogQuestionnaireHandler,
+			Message: logmessages.LogCastUserIdError,
+		})
+		return presenter.SendError(c, fiber.StatusUnauthorized, apperrors.ErrInvalidUserID.Error())
+	}
+	isOwner, err := q.questionnaireService.IsOwner(ctx, c.UserContext(), userID, id)
+	if err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogQuestionnaireHandler,
+			Message: err.Error(),
+		})
+		if errors.Is(err, apperrors.ErrQuestionnaireNotFound) || errors.Is(err, gorm.ErrRecordNotFound) {
+			return presenter.SendError(c, fiber.StatusNotFound, apperrors.ErrQuestionnaireNotFound.Error())
+		}
+		return presenter.SendError(c, fiber.StatusInternalServerError, apperrors.ErrInternalServerError.Error())
+	}
+	if !isOwner {
+		return presenter.SendError(c, fiber.StatusForbidden, apperrors.ErrLackOfAuthorization.Error())
+	}
+
+	var request presenter.GiveRoleAcessRequest
+	if err := c.BodyParser(&request); err != nil {
+		return presenter.SendError(c, fiber.StatusBadRequest, apperrors.ErrInvalidInput.Error())
+	}
+
+	for _, roleID := range request.RoleIDs {
+		if _, err := q.roleService.GetRoleById(ctx, c.UserContext(), roleID); err != nil {
+			logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+				Service: logmessages.LogQuestionnaireHandler,
+				Message: err.Error(),
+			})
+			return presenter.SendError(c, fiber.StatusBadRequest, "failed to get role")
+		}
+		if action == model.PrivilegeAuditGrant {
+			err = q.roleService.AddPrivilegeOnInstance(ctx, c.UserContext(), roleID, id, request.Privileges...)
+		} else {
+			err = q.roleService.DeletePrivilegeOnInstance(ctx, c.UserContext(), roleID, id, request.Privileges...)
+		}
+		if err != nil {
+			logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+				Service: logmessages.LogQuestionnaireHandler,
+				Message: err.Error(),
+			})
+			return presenter.SendError(c, fiber.StatusBadRequest, "failed to change role privilges on instance")
+		}
+		err = q.privilegeAuditService.RecordForRole(ctx, c.UserContext(), action, userID, id, roleID, request.Privileges...)
+		if err != nil {
+			logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+				Service: logmessages.LogQuestionnaireHandler,
+				Message: err.Error(),
+			})
+			return presenter.SendError(c, fiber.StatusInternalServerError, apperrors.ErrInternalServerError.Error())
+		}
+	}
+
+	return presenter.Send(c, fiber.StatusOK, true, "", nil, nil)
+}
+
 func (q *QuestionnaireHandler) GetResults(c *websocket.Conn) {
 	ctx := context.Background()
 	userID, ok := c.Locals("user_id").(uuid.UUID)
@@ -641,8 +817,9 @@ func (q *QuestionnaireHandler) GetResults(c *websocket.Conn) {
 				if answer.Descriptive {
 					c.WriteMessage(websocket.TextMessage, []byte(fmt.Sprintf("answer is : %v", *answer.Text)))
 				} else {
+					// options keep one ID across translations, so answers map back to them
 					for _, option := range qustion.Options {
-						if option.ID == answer.QuestionID {
+						if answer.OptionID != nil && option.ID == *answer.OptionID {
 							c.WriteMessage(websocket.TextMessage, []byte(fmt.Sprintf("selected answer is : %v", option.Index)))
 						}
 					}
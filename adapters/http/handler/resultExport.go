@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"golizilla/adapters/http/handler/presenter"
+	"golizilla/adapters/persistence/logger"
+	"golizilla/core/service"
+	"golizilla/internal/apperrors"
+	"golizilla/internal/logmessages"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type ResultExportHandler struct {
+	resultExportService service.IResultExportService
+}
+
+func NewResultExportHandler(resultExportService service.IResultExportService) *ResultExportHandler {
+	return &ResultExportHandler{
+		resultExportService: resultExportService,
+	}
+}
+
+// Export streams a zip archive with the results of a questionnaire and all files
+// uploaded by its respondents.
+func (h *ResultExportHandler) Export(c *fiber.Ctx) error {
+	ctx := c.Context()
+
+	logger.GetLogger().LogInfoFromContext(ctx, logger.LogFields{
+		Service: logmessages.LogQuestionnaireHandler,
+		Message: logmessages.LogQuestionnaireExportBegin,
+	})
+
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		return presenter.SendError(c, fiber.StatusUnauthorized, apperrors.ErrInvalidUserID.Error())
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return presenter.SendError(c, fiber.StatusBadRequest, "invalid ID format")
+	}
+
+	export, err := h.resultExportService.Export(ctx, c.UserContext(), userID, id)
+	if err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogQuestionnaireHandler,
+			Message: err.Error(),
+		})
+		switch {
+		case errors.Is(err, apperrors.ErrQuestionnaireNotFound):
+			return presenter.SendError(c, fiber.StatusNotFound, err.Error())
+		case errors.Is(err, apperrors.ErrLackOfAuthorization):
+			return presenter.SendError(c, fiber.StatusForbidden, err.Error())
+		}
+		return presenter.SendError(c, fiber.StatusInternalServerError, apperrors.ErrInternalServerError.Error())
+	}
+
+	c.Set(fiber.HeaderContentType, "application/zip")
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", export.FileName()))
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		// runs after the handler returned, so it must not rely on the request context
+		if err := export.WriteZip(context.Background(), w); err != nil {
+			logger.GetLogger().LogErrorFromContext(context.Background(), logger.LogFields{
+				Service: logmessages.LogQuestionnaireHandler,
+				Message: fmt.Sprintf("failed to write results export: %v", err),
+			})
+		}
+	})
+	return nil
+}
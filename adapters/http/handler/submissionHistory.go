@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"errors"
+	"golizilla/adapters/http/handler/presenter"
+	"golizilla/adapters/persistence/logger"
+	"golizilla/core/service"
+	"golizilla/internal/apperrors"
+	logmessages "golizilla/internal/logmessages"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type SubmissionHistoryHandler struct {
+	submissionHistoryService service.ISubmissionHistoryService
+}
+
+func NewSubmissionHistoryHandler(submissionHistoryService service.ISubmissionHistoryService) *SubmissionHistoryHandler {
+	return &SubmissionHistoryHandler{
+		submissionHistoryService: submissionHistoryService,
+	}
+}
+
+// List returns a page of the current user's submissions.
+func (h *SubmissionHistoryHandler) List(c *fiber.Ctx) error {
+	ctx := c.Context()
+
+	logger.GetLogger().LogInfoFromContext(ctx, logger.LogFields{
+		Service: logmessages.LogSubmissionHistoryHandler,
+		Message: logmessages.LogSubmissionHistoryListBegin,
+	})
+
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogSubmissionHistoryHandler,
+			Message: logmessages.LogCastUserIdError,
+		})
+		return presenter.SendError(c, fiber.StatusUnauthorized, apperrors.ErrInvalidUserID.Error())
+	}
+
+	// Get query parameters for page and pageSize
+	page, err := strconv.Atoi(c.Query("page", "1")) // Default to page 1 if not provided
+	if err != nil || page < 1 {
+		return presenter.SendError(c, fiber.StatusBadRequest, "Invalid page number")
+	}
+
+	pageSize, err := strconv.Atoi(c.Query("pageSize", "10")) // Default to 10 items per page
+	if err != nil || pageSize < 1 {
+		return presenter.SendError(c, fiber.StatusBadRequest, "Invalid page size")
+	}
+
+	history, err := h.submissionHistoryService.List(ctx, c.UserContext(), userID, page, pageSize)
+	if err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogSubmissionHistoryHandler,
+			Message: err.Error(),
+		})
+		return h.handleError(c, err)
+	}
+
+	return presenter.Send(c, fiber.StatusOK, true, "Submissions fetched successfully", history, nil)
+}
+
+// Get returns the current user's answers of one of their submissions.
+func (h *SubmissionHistoryHandler) Get(c *fiber.Ctx) error {
+	ctx := c.Context()
+
+	logger.GetLogger().LogInfoFromContext(ctx, logger.LogFields{
+		Service: logmessages.LogSubmissionHistoryHandler,
+		Message: logmessages.LogSubmissionHistoryGetBegin,
+	})
+
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogSubmissionHistoryHandler,
+			Message: logmessages.LogCastUserIdError,
+		})
+		return presenter.SendError(c, fiber.StatusUnauthorized, apperrors.ErrInvalidUserID.Error())
+	}
+
+	submissionID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return presenter.SendError(c, fiber.StatusBadRequest, "invalid ID format")
+	}
+
+	review, err := h.submissionHistoryService.Get(ctx, c.UserContext(), userID, submissionID)
+	if err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogSubmissionHistoryHandler,
+			Message: err.Error(),
+		})
+		return h.handleError(c, err)
+	}
+
+	return presenter.Send(c, fiber.StatusOK, true, "Submission fetched successfully", review, nil)
+}
+
+func (h *SubmissionHistoryHandler) handleError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, apperrors.ErrSubmissionNotFound),
+		errors.Is(err, apperrors.ErrQuestionnaireNotFound):
+		return presenter.SendError(c, fiber.StatusNotFound, err.Error())
+	case errors.Is(err, apperrors.ErrAnswerReviewDisabled):
+		return presenter.SendError(c, fiber.StatusForbidden, err.Error())
+	default:
+		return presenter.SendError(c, fiber.StatusInternalServerError, apperrors.ErrInternalServerError.Error())
+	}
+}
@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"errors"
+	"golizilla/adapters/http/handler/presenter"
+	"golizilla/adapters/persistence/logger"
+	"golizilla/core/domain/model"
+	"golizilla/core/service"
+	"golizilla/internal/apperrors"
+	logmessages "golizilla/internal/logmessages"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type SubmissionViewHandler struct {
+	submissionViewService service.ISubmissionViewService
+}
+
+func NewSubmissionViewHandler(submissionViewService service.ISubmissionViewService) *SubmissionViewHandler {
+	return &SubmissionViewHandler{
+		submissionViewService: submissionViewService,
+	}
+}
+
+// List returns a page of the questionnaire's submissions, optionally filtered by
+// status and by the time they were started.
+func (h *SubmissionViewHandler) List(c *fiber.Ctx) error {
+	ctx := c.Context()
+
+	logger.GetLogger().LogInfoFromContext(ctx, logger.LogFields{
+		Service: logmessages.LogSubmissionViewHandler,
+		Message: logmessages.LogSubmissionViewListBegin,
+	})
+
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogSubmissionViewHandler,
+			Message: logmessages.LogCastUserIdError,
+		})
+		return presenter.SendError(c, fiber.StatusUnauthorized, apperrors.ErrInvalidUserID.Error())
+	}
+
+	questionnaireID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return presenter.SendError(c, fiber.StatusBadRequest, "invalid ID format")
+	}
+
+	// Get query parameters for page and pageSize
+	page, err := strconv.Atoi(c.Query("page", "1")) // Default to page 1 if not provided
+	if err != nil || page < 1 {
+		return presenter.SendError(c, fiber.StatusBadRequest, "Invalid page number")
+	}
+
+	pageSize, err := strconv.Atoi(c.Query("pageSize", "10")) // Default to 10 items per page
+	if err != nil || pageSize < 1 {
+		return presenter.SendError(c, fiber.StatusBadRequest, "Invalid page size")
+	}
+
+	var filter model.SubmissionFilter
+	switch status := model.SubmissionStatus(c.Query("status")); status {
+	case "":
+	case model.SubmissionsStatusInProgress, model.SubmissionsStatusDone, model.SubmissionsStatusCancelled:
+		filter.Status = status
+	default:
+		return presenter.SendError(c, fiber.StatusBadRequest, "Invalid status")
+	}
+	if from := c.Query("from"); from != "" {
+		t, err := time.Parse(time.RFC3339, from)
+		if err != nil {
+			return presenter.SendError(c, fiber.StatusBadRequest, "Invalid from time, expected RFC3339")
+		}
+		filter.From = &t
+	}
+	if to := c.Query("to"); to != "" {
+		t, err := time.Parse(time.RFC3339, to)
+		if err != nil {
+			return presenter.SendError(c, fiber.StatusBadRequest, "Invalid to time, expected RFC3339")
+		}
+		filter.To = &t
+	}
+
+	submissions, err := h.submissionViewService.List(ctx, c.UserContext(), userID, questionnaireID, filter, page, pageSize)
+	if err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogSubmissionViewHandler,
+			Message: err.Error(),
+		})
+		return h.handleError(c, err)
+	}
+
+	return presenter.Send(c, fiber.StatusOK, true, "Submissions fetched successfully", submissions, nil)
+}
+
+// Get returns one submission of the questionnaire with its answers.
+func (h *SubmissionViewHandler) Get(c *fiber.Ctx) error {
+	ctx := c.Context()
+
+	logger.GetLogger().LogInfoFromContext(ctx, logger.LogFields{
+		Service: logmessages.LogSubmissionViewHandler,
+		Message: logmessages.LogSubmissionViewGetBegin,
+	})
+
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogSubmissionViewHandler,
+			Message: logmessages.LogCastUserIdError,
+		})
+		return presenter.SendError(c, fiber.StatusUnauthorized, apperrors.ErrInvalidUserID.Error())
+	}
+
+	questionnaireID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return presenter.SendError(c, fiber.StatusBadRequest, "invalid ID format")
+	}
+	submissionID, err := uuid.Parse(c.Params("submissionId"))
+	if err != nil {
+		return presenter.SendError(c, fiber.StatusBadRequest, "invalid ID format")
+	}
+
+	submission, err := h.submissionViewService.Get(ctx, c.UserContext(), userID, questionnaireID, submissionID)
+	if err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogSubmissionViewHandler,
+			Message: err.Error(),
+		})
+		return h.handleError(c, err)
+	}
+
+	return presenter.Send(c, fiber.StatusOK, true, "Submission fetched successfully", submission, nil)
+}
+
+func (h *SubmissionViewHandler) handleError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, apperrors.ErrSubmissionNotFound),
+		errors.Is(err, apperrors.ErrQuestionnaireNotFound):
+		return presenter.SendError(c, fiber.StatusNotFound, err.Error())
+	case errors.Is(err, apperrors.ErrLackOfAuthorization):
+		return presenter.SendError(c, fiber.StatusForbidden, err.Error())
+	default:
+		return presenter.SendError(c, fiber.StatusInternalServerError, apperrors.ErrInternalServerError.Error())
+	}
+}
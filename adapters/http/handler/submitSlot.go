@@ -0,0 +1,290 @@
+package handler
+
+import (
+	"errors"
+	"golizilla/adapters/http/handler/presenter"
+	"golizilla/adapters/persistence/logger"
+	"golizilla/core/service"
+	"golizilla/internal/apperrors"
+	logmessages "golizilla/internal/logmessages"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type SubmitSlotHandler struct {
+	submitSlotService service.ISubmitSlotService
+}
+
+func NewSubmitSlotHandler(submitSlotService service.ISubmitSlotService) *SubmitSlotHandler {
+	return &SubmitSlotHandler{
+		submitSlotService: submitSlotService,
+	}
+}
+
+func (h *SubmitSlotHandler) SetTrading(c *fiber.Ctx) error {
+	ctx := c.Context()
+
+	logger.GetLogger().LogInfoFromContext(ctx, logger.LogFields{
+		Service: logmessages.LogSubmitSlotHandler,
+		Message: logmessages.LogSubmitSlotSetTradingBegin,
+	})
+
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogSubmitSlotHandler,
+			Message: logmessages.LogCastUserIdError,
+		})
+		return presenter.SendError(c, fiber.StatusUnauthorized, apperrors.ErrInvalidUserID.Error())
+	}
+
+	questionnaireID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return presenter.SendError(c, fiber.StatusBadRequest, "invalid ID format")
+	}
+
+	var request presenter.SetSlotTradingRequest
+	if err := c.BodyParser(&request); err != nil {
+		return presenter.SendError(c, fiber.StatusBadRequest, apperrors.ErrInvalidInput.Error())
+	}
+	if err := request.Validate(); err != nil {
+		return presenter.SendError(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	if err := h.submitSlotService.SetTradingEnabled(ctx, c.UserContext(), userID, questionnaireID, *request.Enabled); err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogSubmitSlotHandler,
+			Message: err.Error(),
+		})
+		return h.handleError(c, err)
+	}
+
+	return presenter.Send(c, fiber.StatusOK, true, "Slot trading updated successfully", nil, nil)
+}
+
+func (h *SubmitSlotHandler) CreateListing(c *fiber.Ctx) error {
+	ctx := c.Context()
+
+	logger.GetLogger().LogInfoFromContext(ctx, logger.LogFields{
+		Service: logmessages.LogSubmitSlotHandler,
+		Message: logmessages.LogSubmitSlotCreateListingBegin,
+	})
+
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogSubmitSlotHandler,
+			Message: logmessages.LogCastUserIdError,
+		})
+		return presenter.SendError(c, fiber.StatusUnauthorized, apperrors.ErrInvalidUserID.Error())
+	}
+
+	questionnaireID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return presenter.SendError(c, fiber.StatusBadRequest, "invalid ID format")
+	}
+
+	var request presenter.CreateSlotListingRequest
+	if err := c.BodyParser(&request); err != nil {
+		return presenter.SendError(c, fiber.StatusBadRequest, apperrors.ErrInvalidInput.Error())
+	}
+	if err := request.Validate(); err != nil {
+		return presenter.SendError(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	listing, err := h.submitSlotService.CreateListing(ctx, c.UserContext(), userID, questionnaireID, request.Price)
+	if err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogSubmitSlotHandler,
+			Message: err.Error(),
+		})
+		return h.handleError(c, err)
+	}
+
+	return presenter.Send(c, fiber.StatusCreated, true, "Slot listed successfully", presenter.NewSlotListingResponse(listing), nil)
+}
+
+func (h *SubmitSlotHandler) CancelListing(c *fiber.Ctx) error {
+	ctx := c.Context()
+
+	logger.GetLogger().LogInfoFromContext(ctx, logger.LogFields{
+		Service: logmessages.LogSubmitSlotHandler,
+		Message: logmessages.LogSubmitSlotCancelListingBegin,
+	})
+
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogSubmitSlotHandler,
+			Message: logmessages.LogCastUserIdError,
+		})
+		return presenter.SendError(c, fiber.StatusUnauthorized, apperrors.ErrInvalidUserID.Error())
+	}
+
+	listingID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return presenter.SendError(c, fiber.StatusBadRequest, "invalid ID format")
+	}
+
+	listing, err := h.submitSlotService.CancelListing(ctx, c.UserContext(), userID, listingID)
+	if err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogSubmitSlotHandler,
+			Message: err.Error(),
+		})
+		return h.handleError(c, err)
+	}
+
+	return presenter.Send(c, fiber.StatusOK, true, "Slot listing cancelled", presenter.NewSlotListingResponse(listing), nil)
+}
+
+func (h *SubmitSlotHandler) BuyListing(c *fiber.Ctx) error {
+	ctx := c.Context()
+
+	logger.GetLogger().LogInfoFromContext(ctx, logger.LogFields{
+		Service: logmessages.LogSubmitSlotHandler,
+		Message: logmessages.LogSubmitSlotBuyBegin,
+	})
+
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogSubmitSlotHandler,
+			Message: logmessages.LogCastUserIdError,
+		})
+		return presenter.SendError(c, fiber.StatusUnauthorized, apperrors.ErrInvalidUserID.Error())
+	}
+
+	listingID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return presenter.SendError(c, fiber.StatusBadRequest, "invalid ID format")
+	}
+
+	listing, err := h.submitSlotService.BuyListing(ctx, c.UserContext(), userID, listingID)
+	if err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogSubmitSlotHandler,
+			Message: err.Error(),
+		})
+		return h.handleError(c, err)
+	}
+
+	return presenter.Send(c, fiber.StatusOK, true, "Slot bought successfully", presenter.NewSlotListingResponse(listing), nil)
+}
+
+func (h *SubmitSlotHandler) GetListings(c *fiber.Ctx) error {
+	ctx := c.Context()
+
+	logger.GetLogger().LogInfoFromContext(ctx, logger.LogFields{
+		Service: logmessages.LogSubmitSlotHandler,
+		Message: logmessages.LogSubmitSlotGetListingsBegin,
+	})
+
+	questionnaireID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return presenter.SendError(c, fiber.StatusBadRequest, "invalid ID format")
+	}
+
+	// Get query parameters for page and pageSize
+	page, err := strconv.Atoi(c.Query("page", "1")) // Default to page 1 if not provided
+	if err != nil || page < 1 {
+		return presenter.SendError(c, fiber.StatusBadRequest, "Invalid page number")
+	}
+
+	pageSize, err := strconv.Atoi(c.Query("pageSize", "10")) // Default to 10 items per page
+	if err != nil || pageSize < 1 {
+		return presenter.SendError(c, fiber.StatusBadRequest, "Invalid page size")
+	}
+
+	listings, err := h.submitSlotService.GetOpenListings(ctx, c.UserContext(), questionnaireID, page, pageSize)
+	if err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogSubmitSlotHandler,
+			Message: err.Error(),
+		})
+		return h.handleError(c, err)
+	}
+
+	return presenter.Send(c,
+		fiber.StatusOK,
+		true,
+		"Slot listings fetched successfully",
+		presenter.NewPaginatedSlotListingsResponse(listings.Data, listings.Pages, listings.Page),
+		nil,
+	)
+}
+
+func (h *SubmitSlotHandler) GetAuditLogs(c *fiber.Ctx) error {
+	ctx := c.Context()
+
+	logger.GetLogger().LogInfoFromContext(ctx, logger.LogFields{
+		Service: logmessages.LogSubmitSlotHandler,
+		Message: logmessages.LogSubmitSlotGetAuditLogsBegin,
+	})
+
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogSubmitSlotHandler,
+			Message: logmessages.LogCastUserIdError,
+		})
+		return presenter.SendError(c, fiber.StatusUnauthorized, apperrors.ErrInvalidUserID.Error())
+	}
+
+	questionnaireID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return presenter.SendError(c, fiber.StatusBadRequest, "invalid ID format")
+	}
+
+	// Get query parameters for page and pageSize
+	page, err := strconv.Atoi(c.Query("page", "1")) // Default to page 1 if not provided
+	if err != nil || page < 1 {
+		return presenter.SendError(c, fiber.StatusBadRequest, "Invalid page number")
+	}
+
+	pageSize, err := strconv.Atoi(c.Query("pageSize", "10")) // Default to 10 items per page
+	if err != nil || pageSize < 1 {
+		return presenter.SendError(c, fiber.StatusBadRequest, "Invalid page size")
+	}
+
+	auditLogs, err := h.submitSlotService.GetAuditLogs(ctx, c.UserContext(), userID, questionnaireID, page, pageSize)
+	if err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogSubmitSlotHandler,
+			Message: err.Error(),
+		})
+		return h.handleError(c, err)
+	}
+
+	return presenter.Send(c,
+		fiber.StatusOK,
+		true,
+		"Slot audit log fetched successfully",
+		presenter.NewPaginatedSlotAuditLogsResponse(auditLogs.Data, auditLogs.Pages, auditLogs.Page),
+		nil,
+	)
+}
+
+func (h *SubmitSlotHandler) handleError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, apperrors.ErrQuestionnaireNotFound),
+		errors.Is(err, apperrors.ErrSlotListingNotFound):
+		return presenter.SendError(c, fiber.StatusNotFound, err.Error())
+	case errors.Is(err, apperrors.ErrLackOfAuthorization):
+		return presenter.SendError(c, fiber.StatusForbidden, err.Error())
+	case errors.Is(err, apperrors.ErrInsufficientBalance),
+		errors.Is(err, apperrors.ErrSlotListingNotOpen),
+		errors.Is(err, apperrors.ErrNoUnusedSlot):
+		return presenter.SendError(c, fiber.StatusConflict, err.Error())
+	case errors.Is(err, apperrors.ErrSlotTradingDisabled),
+		errors.Is(err, apperrors.ErrSlotTradingUnavailable),
+		errors.Is(err, apperrors.ErrSlotSelfPurchase),
+		errors.Is(err, apperrors.ErrQuestionnareExpired),
+		errors.Is(err, apperrors.ErrInvalidTransferAmount):
+		return presenter.SendError(c, fiber.StatusBadRequest, err.Error())
+	default:
+		return presenter.SendError(c, fiber.StatusInternalServerError, apperrors.ErrInternalServerError.Error())
+	}
+}
@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"errors"
+	"golizilla/adapters/http/handler/presenter"
+	"golizilla/adapters/persistence/logger"
+	"golizilla/core/service"
+	"golizilla/internal/apperrors"
+	"golizilla/internal/logmessages"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// defaultFastRatio flags answers given in under a quarter of the median time.
+const defaultFastRatio = 0.25
+
+type TimingHandler struct {
+	timingService service.ITimingService
+}
+
+func NewTimingHandler(timingService service.ITimingService) *TimingHandler {
+	return &TimingHandler{
+		timingService: timingService,
+	}
+}
+
+// GetAnalytics returns how long respondents spent on each question and the
+// submissions that answered suspiciously fast.
+func (h *TimingHandler) GetAnalytics(c *fiber.Ctx) error {
+	ctx := c.Context()
+
+	logger.GetLogger().LogInfoFromContext(ctx, logger.LogFields{
+		Service: logmessages.LogQuestionnaireHandler,
+		Message: logmessages.LogQuestionnaireTimingBegin,
+	})
+
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		return presenter.SendError(c, fiber.StatusUnauthorized, apperrors.ErrInvalidUserID.Error())
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return presenter.SendError(c, fiber.StatusBadRequest, "invalid ID format")
+	}
+
+	fastRatio := defaultFastRatio
+	if raw := c.Query("fast_ratio"); raw != "" {
+		fastRatio, err = strconv.ParseFloat(raw, 64)
+		if err != nil || fastRatio < 0 || fastRatio > 1 {
+			return presenter.SendError(c, fiber.StatusBadRequest, "fast_ratio must be a number between 0 and 1")
+		}
+	}
+
+	analytics, err := h.timingService.GetAnalytics(ctx, c.UserContext(), userID, id, fastRatio)
+	if err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogQuestionnaireHandler,
+			Message: err.Error(),
+		})
+		switch {
+		case errors.Is(err, apperrors.ErrQuestionnaireNotFound):
+			return presenter.SendError(c, fiber.StatusNotFound, err.Error())
+		case errors.Is(err, apperrors.ErrLackOfAuthorization):
+			return presenter.SendError(c, fiber.StatusForbidden, err.Error())
+		}
+		return presenter.SendError(c, fiber.StatusInternalServerError, apperrors.ErrInternalServerError.Error())
+	}
+
+	return presenter.Send(c, fiber.StatusOK, true, "Timing analytics retrieved", analytics, nil)
+}
@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"golizilla/adapters/http/handler/presenter"
+	"golizilla/adapters/persistence/logger"
+	"golizilla/core/service"
+	"golizilla/internal/apperrors"
+	logmessages "golizilla/internal/logmessages"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type TrashHandler struct {
+	trashService service.ITrashService
+}
+
+func NewTrashHandler(trashService service.ITrashService) *TrashHandler {
+	return &TrashHandler{
+		trashService: trashService,
+	}
+}
+
+// List returns what the current user deleted and can still restore.
+func (h *TrashHandler) List(c *fiber.Ctx) error {
+	ctx := c.Context()
+
+	logger.GetLogger().LogInfoFromContext(ctx, logger.LogFields{
+		Service: logmessages.LogTrashHandler,
+		Message: logmessages.LogTrashListBegin,
+	})
+
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogTrashHandler,
+			Message: logmessages.LogCastUserIdError,
+		})
+		return presenter.SendError(c, fiber.StatusUnauthorized, apperrors.ErrInvalidUserID.Error())
+	}
+
+	trash, err := h.trashService.List(ctx, c.UserContext(), userID)
+	if err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogTrashHandler,
+			Message: err.Error(),
+		})
+		return h.handleError(c, err)
+	}
+
+	return presenter.Send(c, fiber.StatusOK, true, "Trash fetched successfully", trash, nil)
+}
+
+func (h *TrashHandler) RestoreQuestionnaire(c *fiber.Ctx) error {
+	return h.restore(c, "Questionnaire restored", h.trashService.RestoreQuestionnaire)
+}
+
+func (h *TrashHandler) RestoreQuestion(c *fiber.Ctx) error {
+	return h.restore(c, "Question restored", h.trashService.RestoreQuestion)
+}
+
+func (h *TrashHandler) RestoreAnswer(c *fiber.Ctx) error {
+	return h.restore(c, "Answer restored", h.trashService.RestoreAnswer)
+}
+
+func (h *TrashHandler) restore(c *fiber.Ctx, message string, restore func(ctx context.Context, userCtx context.Context, userID, id uuid.UUID) error) error {
+	ctx := c.Context()
+
+	logger.GetLogger().LogInfoFromContext(ctx, logger.LogFields{
+		Service: logmessages.LogTrashHandler,
+		Message: logmessages.LogTrashRestoreBegin,
+	})
+
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogTrashHandler,
+			Message: logmessages.LogCastUserIdError,
+		})
+		return presenter.SendError(c, fiber.StatusUnauthorized, apperrors.ErrInvalidUserID.Error())
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return presenter.SendError(c, fiber.StatusBadRequest, "invalid ID format")
+	}
+
+	if err := restore(ctx, c.UserContext(), userID, id); err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogTrashHandler,
+			Message: err.Error(),
+		})
+		return h.handleError(c, err)
+	}
+
+	return presenter.Send(c, fiber.StatusOK, true, message, nil, nil)
+}
+
+func (h *TrashHandler) handleError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, apperrors.ErrTrashItemNotFound):
+		return presenter.SendError(c, fiber.StatusNotFound, err.Error())
+	case errors.Is(err, apperrors.ErrLackOfAuthorization):
+		return presenter.SendError(c, fiber.StatusForbidden, err.Error())
+	case errors.Is(err, apperrors.ErrTrashItemExpired):
+		return presenter.SendError(c, fiber.StatusGone, err.Error())
+	case errors.Is(err, apperrors.ErrTrashParentDeleted),
+		errors.Is(err, apperrors.ErrTrashAnswerReplaced):
+		return presenter.SendError(c, fiber.StatusConflict, err.Error())
+	default:
+		return presenter.SendError(c, fiber.StatusInternalServerError, apperrors.ErrInternalServerError.Error())
+	}
+}
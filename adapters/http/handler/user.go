@@ -75,7 +75,7 @@ func (h *UserHandler) CreateUser(c *fiber.Ctx) error {
 	user.EmailVerificationExpiry = time.Now().Add(h.Config.VerificationExpiresIn)
 	user.IsActive = false // Ensure the user is inactive until email is verified
 
-	role, err := h.RoleService.CreateRole(ctx, userCtx, user.Username, "Default")
+	role, err := h.RoleService.CreateRole(ctx, userCtx, user.Username, model.SignupRoleDescription)
 	if err != nil {
 
 		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
@@ -85,14 +85,15 @@ func (h *UserHandler) CreateUser(c *fiber.Ctx) error {
 		return h.handleError(c, err)
 	}
 
-	//add more privileges
-	if err := h.RoleService.AddPrivilege(ctx, userCtx, role.ID, privilegeconstants.CreateQuestionnaire); err != nil {
+	for _, privilege := range privilegeconstants.DefaultPrivileges(privilegeconstants.RoleSignup) {
+		if err := h.RoleService.AddPrivilege(ctx, userCtx, role.ID, privilege); err != nil {
 
-		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
-			Service: logmessages.LogUserHandler,
-			Message: err.Error(),
-		})
-		return h.handleError(c, err)
+			logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+				Service: logmessages.LogUserHandler,
+				Message: err.Error(),
+			})
+			return h.handleError(c, err)
+		}
 	}
 
 	// Attempt to save the user
@@ -525,7 +526,7 @@ func (h *UserHandler) UpdateProfile(c *fiber.Ctx) error {
 
 	user := request.ToDomain()
 
-	err := h.UserService.UpdateProfile(ctx, userCtx, userID, user)
+	err := h.UserService.UpdateProfile(ctx, userCtx, userID, user, request.ToPreferredLocale())
 	if err != nil {
 		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
 			Service: logmessages.LogUserHandler,
@@ -0,0 +1,282 @@
+package handler
+
+import (
+	"errors"
+	"golizilla/adapters/http/handler/presenter"
+	"golizilla/adapters/persistence/logger"
+	"golizilla/core/service"
+	"golizilla/internal/apperrors"
+	logmessages "golizilla/internal/logmessages"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type WalletHandler struct {
+	walletService service.IWalletService
+}
+
+func NewWalletHandler(walletService service.IWalletService) *WalletHandler {
+	return &WalletHandler{
+		walletService: walletService,
+	}
+}
+
+func (h *WalletHandler) GetBalance(c *fiber.Ctx) error {
+	ctx := c.Context()
+
+	logger.GetLogger().LogInfoFromContext(ctx, logger.LogFields{
+		Service: logmessages.LogWalletHandler,
+		Message: logmessages.LogWalletGetBalanceBegin,
+	})
+
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogWalletHandler,
+			Message: apperrors.ErrInvalidUserID.Error(),
+		})
+		return presenter.SendError(c, fiber.StatusUnauthorized, apperrors.ErrInvalidUserID.Error())
+	}
+
+	balance, err := h.walletService.GetBalance(ctx, c.UserContext(), userID)
+	if err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogWalletHandler,
+			Message: err.Error(),
+		})
+		return h.handleError(c, err)
+	}
+
+	return presenter.Send(c, fiber.StatusOK, true, "Wallet balance fetched successfully", presenter.NewWalletBalanceResponse(balance), nil)
+}
+
+func (h *WalletHandler) Transfer(c *fiber.Ctx) error {
+	ctx := c.Context()
+
+	logger.GetLogger().LogInfoFromContext(ctx, logger.LogFields{
+		Service: logmessages.LogWalletHandler,
+		Message: logmessages.LogWalletTransferBegin,
+	})
+
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogWalletHandler,
+			Message: apperrors.ErrInvalidUserID.Error(),
+		})
+		return presenter.SendError(c, fiber.StatusUnauthorized, apperrors.ErrInvalidUserID.Error())
+	}
+
+	var request presenter.TransferRequest
+	if err := c.BodyParser(&request); err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogWalletHandler,
+			Message: err.Error(),
+		})
+		return presenter.SendError(c, fiber.StatusBadRequest, apperrors.ErrInvalidInput.Error())
+	}
+
+	if err := request.Validate(); err != nil {
+		logger.GetLogger().LogWarningFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogWalletHandler,
+			Message: err.Error(),
+		})
+		return presenter.SendError(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	transaction, err := h.walletService.Transfer(ctx, c.UserContext(), userID, request.Recipient, request.Amount, request.Description)
+	if err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogWalletHandler,
+			Message: err.Error(),
+		})
+		return h.handleError(c, err)
+	}
+
+	return presenter.Send(c, fiber.StatusOK, true, "Transfer completed successfully", presenter.NewTransferResponse(transaction, userID), nil)
+}
+
+func (h *WalletHandler) GetTransactions(c *fiber.Ctx) error {
+	ctx := c.Context()
+
+	logger.GetLogger().LogInfoFromContext(ctx, logger.LogFields{
+		Service: logmessages.LogWalletHandler,
+		Message: logmessages.LogWalletGetTransactionsBegin,
+	})
+
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogWalletHandler,
+			Message: apperrors.ErrInvalidUserID.Error(),
+		})
+		return presenter.SendError(c, fiber.StatusUnauthorized, apperrors.ErrInvalidUserID.Error())
+	}
+
+	// Get query parameters for page and pageSize
+	page, err := strconv.Atoi(c.Query("page", "1")) // Default to page 1 if not provided
+	if err != nil || page < 1 {
+		return presenter.SendError(c, fiber.StatusBadRequest, "Invalid page number")
+	}
+
+	pageSize, err := strconv.Atoi(c.Query("pageSize", "10")) // Default to 10 items per page
+	if err != nil || pageSize < 1 {
+		return presenter.SendError(c, fiber.StatusBadRequest, "Invalid page size")
+	}
+
+	entries, err := h.walletService.GetTransactions(ctx, c.UserContext(), userID, page, pageSize)
+	if err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogWalletHandler,
+			Message: err.Error(),
+		})
+		return h.handleError(c, err)
+	}
+
+	return presenter.Send(c,
+		fiber.StatusOK,
+		true,
+		"Wallet transactions fetched successfully",
+		presenter.NewPaginatedLedgerEntriesResponse(entries.Data, entries.Pages, entries.Page),
+		nil,
+	)
+}
+
+func (h *WalletHandler) GetReconciliationReport(c *fiber.Ctx) error {
+	ctx := c.Context()
+
+	logger.GetLogger().LogInfoFromContext(ctx, logger.LogFields{
+		Service: logmessages.LogWalletHandler,
+		Message: logmessages.LogWalletReconciliationBegin,
+	})
+
+	report, err := h.walletService.GetReconciliationReport(ctx, c.UserContext())
+	if err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogWalletHandler,
+			Message: err.Error(),
+		})
+		return h.handleError(c, err)
+	}
+
+	return presenter.Send(c, fiber.StatusOK, true, "Wallet reconciliation report generated", report, nil)
+}
+
+func (h *WalletHandler) CreateTopUp(c *fiber.Ctx) error {
+	ctx := c.Context()
+
+	logger.GetLogger().LogInfoFromContext(ctx, logger.LogFields{
+		Service: logmessages.LogWalletHandler,
+		Message: logmessages.LogWalletTopUpBegin,
+	})
+
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogWalletHandler,
+			Message: apperrors.ErrInvalidUserID.Error(),
+		})
+		return presenter.SendError(c, fiber.StatusUnauthorized, apperrors.ErrInvalidUserID.Error())
+	}
+
+	var request presenter.TopUpRequest
+	if err := c.BodyParser(&request); err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogWalletHandler,
+			Message: err.Error(),
+		})
+		return presenter.SendError(c, fiber.StatusBadRequest, apperrors.ErrInvalidInput.Error())
+	}
+
+	if err := request.Validate(); err != nil {
+		logger.GetLogger().LogWarningFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogWalletHandler,
+			Message: err.Error(),
+		})
+		return presenter.SendError(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	intent, err := h.walletService.CreateTopUp(ctx, c.UserContext(), userID, request.Amount)
+	if err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogWalletHandler,
+			Message: err.Error(),
+		})
+		return h.handleError(c, err)
+	}
+
+	return presenter.Send(c, fiber.StatusCreated, true, "Top-up created, complete the payment to credit your wallet", presenter.NewTopUpResponse(intent), nil)
+}
+
+func (h *WalletHandler) GetTopUp(c *fiber.Ctx) error {
+	ctx := c.Context()
+
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogWalletHandler,
+			Message: apperrors.ErrInvalidUserID.Error(),
+		})
+		return presenter.SendError(c, fiber.StatusUnauthorized, apperrors.ErrInvalidUserID.Error())
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return presenter.SendError(c, fiber.StatusBadRequest, apperrors.ErrInvalidInput.Error())
+	}
+
+	intent, err := h.walletService.GetTopUp(ctx, c.UserContext(), userID, id)
+	if err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogWalletHandler,
+			Message: err.Error(),
+		})
+		return h.handleError(c, err)
+	}
+
+	return presenter.Send(c, fiber.StatusOK, true, "Top-up fetched successfully", presenter.NewTopUpResponse(intent), nil)
+}
+
+// PaymentCallback is called by the payment gateway, not by a logged-in user. The
+// raw body is passed on untouched because the signature covers its exact bytes.
+func (h *WalletHandler) PaymentCallback(c *fiber.Ctx) error {
+	ctx := c.Context()
+
+	logger.GetLogger().LogInfoFromContext(ctx, logger.LogFields{
+		Service: logmessages.LogWalletHandler,
+		Message: logmessages.LogWalletPaymentCallbackBegin,
+	})
+
+	intent, err := h.walletService.HandlePaymentCallback(ctx, c.UserContext(), c.Body(), c.Get("X-Payment-Signature"))
+	if err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogWalletHandler,
+			Message: err.Error(),
+		})
+		return h.handleError(c, err)
+	}
+
+	return presenter.Send(c, fiber.StatusOK, true, "Payment callback processed", presenter.NewTopUpResponse(intent), nil)
+}
+
+func (h *WalletHandler) handleError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, apperrors.ErrUserNotFound),
+		errors.Is(err, apperrors.ErrWalletAccountNotFound),
+		errors.Is(err, apperrors.ErrTopUpNotFound):
+		return presenter.SendError(c, fiber.StatusNotFound, err.Error())
+	case errors.Is(err, apperrors.ErrInsufficientBalance):
+		return presenter.SendError(c, fiber.StatusConflict, err.Error())
+	case errors.Is(err, apperrors.ErrInvalidTransferAmount),
+		errors.Is(err, apperrors.ErrSelfTransfer),
+		errors.Is(err, apperrors.ErrUnbalancedTransaction),
+		errors.Is(err, apperrors.ErrPaymentAmountMismatch):
+		return presenter.SendError(c, fiber.StatusBadRequest, err.Error())
+	case errors.Is(err, apperrors.ErrInvalidPaymentSignature):
+		return presenter.SendError(c, fiber.StatusUnauthorized, err.Error())
+	default:
+		return presenter.SendError(c, fiber.StatusInternalServerError, apperrors.ErrInternalServerError.Error())
+	}
+}
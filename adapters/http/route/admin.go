@@ -5,6 +5,7 @@ import (
 	"golizilla/adapters/http/handler/middleware"
 	"golizilla/config"
 	"golizilla/core/service"
+	privilegeconstants "golizilla/internal/privilege"
 
 	"github.com/gofiber/fiber/v2"
 	"gorm.io/gorm"
@@ -15,21 +16,32 @@ func SetupAdminRoutes(
 	db *gorm.DB,
 	cfg *config.Config,
 	adminService service.IAdminService,
+	walletService service.IWalletService,
+	privilegeAuditService service.IPrivilegeAuditService,
+	authorizationService service.IAuthorizationService,
 ) {
 	// Create a group for user routes
 	adminGroup := app.Group("/admin")
 
 	// Initialize handlers
 	adminHandler := handler.NewAdminHandler(adminService)
+	walletHandler := handler.NewWalletHandler(walletService)
+	privilegeAuditHandler := handler.NewPrivilegeAuditHandler(privilegeAuditService)
 
 	// Initialize the JWT middleware with the config
 	adminGroup.Use(middleware.AuthMiddleware(cfg))
 	adminGroup.Use(middleware.ContextMiddleware())
 
+	authorizationMiddleware := middleware.AuthorizationMiddleware(authorizationService)
+
 	// Protected routes
-	adminGroup.Get("/users", adminHandler.GetAllUsers)
-	adminGroup.Get("/questions", adminHandler.GetAllQuestions)
-	adminGroup.Get("/questionnaires", adminHandler.GetAllQuestionnaires)
-	adminGroup.Get("/roles", adminHandler.GetAllRoles)
-	adminGroup.Get("/users/:userID/questionnaires/:questionnaireID", adminHandler.GetAnswersByUserIDAndQuestionnaireID)
+	adminGroup.Get("/users", authorizationMiddleware(privilegeconstants.AdminViewUsers), adminHandler.GetAllUsers)
+	adminGroup.Get("/questions", authorizationMiddleware(privilegeconstants.AdminViewQuestions), adminHandler.GetAllQuestions)
+	adminGroup.Get("/questionnaires", authorizationMiddleware(privilegeconstants.AdminViewQuestionnaires), adminHandler.GetAllQuestionnaires)
+	adminGroup.Get("/roles", authorizationMiddleware(privilegeconstants.AdminViewRoles), adminHandler.GetAllRoles)
+	adminGroup.Get("/users/:userID/questionnaires/:questionnaireID", authorizationMiddleware(privilegeconstants.AdminViewAnswers), adminHandler.GetAnswersByUserIDAndQuestionnaireID)
+	adminGroup.Get("/wallet/reconciliation", authorizationMiddleware(privilegeconstants.AdminViewWallet), walletHandler.GetReconciliationReport)
+	adminGroup.Get("/privileges/audit", authorizationMiddleware(privilegeconstants.AdminViewAuditLogs), privilegeAuditHandler.List)
+	adminGroup.Get("/privileges/audit/export", authorizationMiddleware(privilegeconstants.AdminViewAuditLogs), privilegeAuditHandler.Export)
+	adminGroup.Get("/access/audit", authorizationMiddleware(privilegeconstants.AdminViewAuditLogs), adminHandler.GetAccessLogs)
 }
@@ -11,10 +11,11 @@ import (
 )
 
 func SetupCoreRoutes(app *fiber.App, db *gorm.DB, cfg *config.Config,
-	coreService service.ICoreService, roleService service.IRoleService, questionnaireService service.IQuestionnaireService) { // pass the service as a param or create inside
+	coreService service.ICoreService, roleService service.IRoleService, questionnaireService service.IQuestionnaireService,
+	translationService service.ITranslationService) { // pass the service as a param or create inside
 	coreGroup := app.Group("/core")
 
-	coreHandler := handler.NewCoreHandler(coreService, roleService, questionnaireService)
+	coreHandler := handler.NewCoreHandler(coreService, roleService, questionnaireService, translationService)
 
 	// Add authentication middleware if needed
 	coreGroup.Use(middleware.AuthMiddleware(cfg))
@@ -26,4 +27,12 @@ func SetupCoreRoutes(app *fiber.App, db *gorm.DB, cfg *config.Config,
 	coreGroup.Post("/next", coreHandler.NextHandler)
 	coreGroup.Post("/back", coreHandler.BackHandler)
 	coreGroup.Post("/end", coreHandler.EndHandler)
+	coreGroup.Post("/submit/file", coreHandler.SubmitFileHandler)
+	coreGroup.Post("/submit/offline", coreHandler.SubmitOfflineHandler)
+	coreGroup.Delete("/submission/:id", coreHandler.DeleteSubmissionHandler)
+
+	coreGroup.Get("/page/:submission_id", coreHandler.GetPageHandler)
+	coreGroup.Post("/page/submit", coreHandler.SubmitPageHandler)
+	coreGroup.Post("/page/next", coreHandler.NextPageHandler)
+	coreGroup.Post("/page/back", coreHandler.BackPageHandler)
 }
@@ -0,0 +1,31 @@
+package route
+
+import (
+	"golizilla/adapters/http/handler"
+	"golizilla/adapters/http/handler/middleware"
+	"golizilla/config"
+	"golizilla/core/service"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+func SetupPrivilegeRoutes(
+	app *fiber.App,
+	db *gorm.DB,
+	cfg *config.Config,
+	privilegeService service.IPrivilegeService,
+) {
+	// Create a group for privilege routes
+	privilegeGroup := app.Group("/privileges")
+
+	// Initialize handlers
+	privilegeHandler := handler.NewPrivilegeHandler(privilegeService)
+
+	// Initialize the JWT middleware with the config
+	privilegeGroup.Use(middleware.AuthMiddleware(cfg))
+	privilegeGroup.Use(middleware.ContextMiddleware())
+
+	// Protected routes
+	privilegeGroup.Get("/", privilegeHandler.List)
+}
@@ -10,12 +10,15 @@ import (
 	"gorm.io/gorm"
 )
 
-func SetupQuestionRoutes(app *fiber.App, db *gorm.DB, cfg *config.Config, questionService service.IQuestionService) {
+func SetupQuestionRoutes(app *fiber.App, db *gorm.DB, cfg *config.Config, questionService service.IQuestionService, questionMediaService service.IQuestionMediaService, translationService service.ITranslationService, sectionService service.ISectionService) {
 	// Create a group for user routes
 	questionGroup := app.Group("/question")
 
 	// Initialize handlers
-	questionHandler := handler.NewQuestionHandler(questionService)
+	questionHandler := handler.NewQuestionHandler(questionService, cfg.AnswerFileMaxSize)
+	questionMediaHandler := handler.NewQuestionMediaHandler(questionMediaService)
+	translationHandler := handler.NewTranslationHandler(translationService)
+	sectionHandler := handler.NewSectionHandler(sectionService)
 
 	// Initialize the JWT middleware with the config
 	questionGroup.Use(middleware.AuthMiddleware(cfg))
@@ -29,4 +32,17 @@ func SetupQuestionRoutes(app *fiber.App, db *gorm.DB, cfg *config.Config, questi
 	questionGroup.Get("/:id", questionHandler.GetByID)
 
 	questionGroup.Delete("/:id", questionHandler.Delete)
+
+	// Media routes
+	questionGroup.Post("/:id/media", questionMediaHandler.Upload)
+
+	questionGroup.Get("/:id/media", questionMediaHandler.Download)
+
+	questionGroup.Delete("/:id/media", questionMediaHandler.Delete)
+
+	// Translation routes
+	questionGroup.Put("/:id/translations/:locale", translationHandler.SetQuestion)
+
+	// Branching routes
+	questionGroup.Put("/:id/branching", sectionHandler.SetBranching)
 }
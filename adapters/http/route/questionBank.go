@@ -0,0 +1,41 @@
+package route
+
+import (
+	"golizilla/adapters/http/handler"
+	"golizilla/adapters/http/handler/middleware"
+	"golizilla/config"
+	"golizilla/core/service"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+func SetupQuestionBankRoutes(
+	app *fiber.App,
+	db *gorm.DB,
+	cfg *config.Config,
+	questionBankService service.IQuestionBankService,
+) {
+	// Create a group for question bank routes
+	bankGroup := app.Group("/question-bank")
+
+	// Initialize handlers
+	questionBankHandler := handler.NewQuestionBankHandler(questionBankService)
+
+	// Initialize the JWT middleware with the config
+	bankGroup.Use(middleware.AuthMiddleware(cfg))
+	bankGroup.Use(middleware.ContextMiddleware())
+
+	bankGroup.Post("/", questionBankHandler.Create)
+	bankGroup.Get("/", questionBankHandler.List)
+
+	// Questionnaire question scoped routes, :id is the question ID
+	bankGroup.Post("/questions/:id/sync", questionBankHandler.Sync)
+
+	// Bank question scoped routes, :id is the bank question ID
+	bankGroup.Get("/:id", questionBankHandler.GetByID)
+	bankGroup.Put("/:id", questionBankHandler.Update)
+	bankGroup.Delete("/:id", questionBankHandler.Delete)
+	bankGroup.Post("/:id/use", questionBankHandler.Use)
+	bankGroup.Get("/:id/results", questionBankHandler.Results)
+}
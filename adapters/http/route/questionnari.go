@@ -20,10 +20,27 @@ func SetupQuestionnaireRoutes(
 	authorizationService service.IAuthorizationService,
 	roleService service.IRoleService,
 	userService service.IUserService,
-	questionService service.IQuestionService) {
+	questionService service.IQuestionService,
+	resultExportService service.IResultExportService,
+	translationService service.ITranslationService,
+	timingService service.ITimingService,
+	questionPoolService service.IQuestionPoolService,
+	sectionService service.ISectionService,
+	submissionViewService service.ISubmissionViewService,
+	accountService service.IAccountService,
+	privilegeAuditService service.IPrivilegeAuditService,
+	eligibilityService service.IEligibilityService) {
 	questionnaireGroup := app.Group("/questionnaire")
 
-	questionnaireHandler := handler.NewQuestionnaireHandler(questionnaireService, roleService, userService, questionService)
+	questionnaireHandler := handler.NewQuestionnaireHandler(questionnaireService, roleService, userService, questionService, translationService, privilegeAuditService, eligibilityService)
+	resultExportHandler := handler.NewResultExportHandler(resultExportService)
+	translationHandler := handler.NewTranslationHandler(translationService)
+	timingHandler := handler.NewTimingHandler(timingService)
+	questionPoolHandler := handler.NewQuestionPoolHandler(questionPoolService)
+	sectionHandler := handler.NewSectionHandler(sectionService)
+	submissionViewHandler := handler.NewSubmissionViewHandler(submissionViewService)
+	accountHandler := handler.NewAccountHandler(accountService, cfg)
+	privilegeAuditHandler := handler.NewPrivilegeAuditHandler(privilegeAuditService)
 
 	headerAuthMiddleware := middleware.HeaderAuthMiddleware(cfg)
 	questionnaireGroup.Get("/GetResults/:id",
@@ -40,6 +57,57 @@ func SetupQuestionnaireRoutes(
 	questionnaireGroup.Get("/:id",
 		questionnaireHandler.GetById)
 
+	questionnaireGroup.Get("/:id/export",
+		resultExportHandler.Export)
+
+	questionnaireGroup.Get("/:id/timing",
+		timingHandler.GetAnalytics)
+
+	questionnaireGroup.Get("/:id/pools",
+		questionPoolHandler.List)
+
+	questionnaireGroup.Post("/:id/pools",
+		questionPoolHandler.Create)
+
+	questionnaireGroup.Put("/:id/pools/:poolId",
+		questionPoolHandler.Update)
+
+	questionnaireGroup.Delete("/:id/pools/:poolId",
+		questionPoolHandler.Delete)
+
+	questionnaireGroup.Get("/:id/exam-results",
+		questionPoolHandler.GetExamResults)
+
+	questionnaireGroup.Get("/:id/submissions",
+		submissionViewHandler.List)
+
+	questionnaireGroup.Get("/:id/submissions/:submissionId",
+		submissionViewHandler.Get)
+
+	questionnaireGroup.Get("/:id/sections",
+		sectionHandler.List)
+
+	questionnaireGroup.Post("/:id/sections",
+		sectionHandler.Create)
+
+	questionnaireGroup.Put("/:id/sections/:sectionId",
+		sectionHandler.Update)
+
+	questionnaireGroup.Delete("/:id/sections/:sectionId",
+		sectionHandler.Delete)
+
+	questionnaireGroup.Get("/:id/translations",
+		translationHandler.Get)
+
+	questionnaireGroup.Put("/:id/translations/:locale",
+		translationHandler.SetQuestionnaire)
+
+	questionnaireGroup.Delete("/:id/translations/:locale",
+		translationHandler.DeleteLocale)
+
+	questionnaireGroup.Put("/:id/eligibility",
+		questionnaireHandler.SetEligibility)
+
 	questionnaireGroup.Get("/ownerId/:id",
 		questionnaireHandler.GetByOwnerId)
 
@@ -49,7 +117,23 @@ func SetupQuestionnaireRoutes(
 	questionnaireGroup.Delete("/:id",
 		questionnaireHandler.Delete)
 
+	questionnaireGroup.Post("/:id/archive",
+		accountHandler.Archive)
+
+	questionnaireGroup.Post("/:id/transfer",
+		accountHandler.Transfer)
+
 	questionnaireGroup.Post("/GiveAcess/:id", questionnaireHandler.GiveAcess)
 
 	questionnaireGroup.Post("/DeleteAcess/:id", questionnaireHandler.DeleteAcess)
+
+	questionnaireGroup.Post("/GiveRoleAcess/:id", questionnaireHandler.GiveRoleAcess)
+
+	questionnaireGroup.Post("/DeleteRoleAcess/:id", questionnaireHandler.DeleteRoleAcess)
+
+	questionnaireGroup.Get("/:id/privileges/audit",
+		privilegeAuditHandler.ListForQuestionnaire)
+
+	questionnaireGroup.Get("/:id/privileges/audit/export",
+		privilegeAuditHandler.ExportForQuestionnaire)
 }
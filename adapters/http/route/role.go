@@ -0,0 +1,51 @@
+package route
+
+import (
+	"golizilla/adapters/http/handler"
+	"golizilla/adapters/http/handler/middleware"
+	"golizilla/config"
+	"golizilla/core/service"
+	privilegeconstants "golizilla/internal/privilege"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+func SetupRoleRoutes(
+	app *fiber.App,
+	db *gorm.DB,
+	cfg *config.Config,
+	roleService service.IRoleService,
+	authorizationService service.IAuthorizationService,
+) {
+	// Create a group for role routes
+	roleGroup := app.Group("/roles")
+
+	// Initialize handlers
+	roleHandler := handler.NewRoleHandler(roleService)
+
+	// Initialize the JWT middleware with the config
+	roleGroup.Use(middleware.AuthMiddleware(cfg))
+	roleGroup.Use(middleware.ContextMiddleware())
+	authorizationMiddleware := middleware.AuthorizationMiddleware(authorizationService)
+
+	// Roles and their global privileges
+	roleGroup.Post("/",
+		authorizationMiddleware(privilegeconstants.ManagePrivileges), roleHandler.Create)
+	roleGroup.Put("/:id",
+		authorizationMiddleware(privilegeconstants.ManagePrivileges), roleHandler.Update)
+	roleGroup.Delete("/:id",
+		authorizationMiddleware(privilegeconstants.ManagePrivileges), roleHandler.Delete)
+	roleGroup.Post("/:id/privileges",
+		authorizationMiddleware(privilegeconstants.ManagePrivileges), roleHandler.AttachPrivileges)
+	roleGroup.Delete("/:id/privileges",
+		authorizationMiddleware(privilegeconstants.ManagePrivileges), roleHandler.DetachPrivileges)
+	roleGroup.Put("/:id/parent",
+		authorizationMiddleware(privilegeconstants.ManagePrivileges), roleHandler.SetParent)
+
+	// Role members
+	roleGroup.Get("/:id/members",
+		authorizationMiddleware(privilegeconstants.AssignRole), roleHandler.Members)
+	roleGroup.Post("/:id/members",
+		authorizationMiddleware(privilegeconstants.AssignRole), roleHandler.Assign)
+}
@@ -1,14 +1,21 @@
 package route
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"time"
 
+	"golizilla/adapters/filestore"
 	"golizilla/adapters/http/handler/middleware"
+	"golizilla/adapters/payment"
 	customLogger "golizilla/adapters/persistence/logger"
+	"golizilla/adapters/scanner"
 	"golizilla/config"
+	"golizilla/core/port/gateway"
 	"golizilla/core/port/repository"
+	portScanner "golizilla/core/port/scanner"
+	"golizilla/core/port/storage"
 	"golizilla/core/service"
 
 	"github.com/gofiber/fiber/v2"
@@ -16,12 +23,16 @@ import (
 	"github.com/gofiber/fiber/v2/middleware/limiter"
 	"github.com/gofiber/fiber/v2/middleware/logger"
 	"github.com/gofiber/fiber/v2/middleware/recover"
+	"github.com/robfig/cron/v3"
 	"gorm.io/gorm"
 )
 
 func RunServer(cfg *config.Config, database *gorm.DB) {
 	// Initialize Fiber app with middleware
-	app := fiber.New()
+	app := fiber.New(fiber.Config{
+		// leave room for the multipart envelope around the largest allowed file
+		BodyLimit: int(max(cfg.MediaMaxSize, cfg.AnswerFileMaxSize)) + 1<<20,
+	})
 
 	// Add middleware for logging, panic recovery, and CORS
 	app.Use(logger.New())
@@ -58,29 +69,129 @@ func RunServer(cfg *config.Config, database *gorm.DB) {
 	answerRepo := repository.NewAnswerRepository(database)
 	roleRepo := repository.NewRoleRepository(database)
 	userRepo := repository.NewUserRepository(database)
+	privilegeRepo := repository.NewPrivilegeRepository(database)
 	rolePrivilegeRepo := repository.NewRolePrivilegeRepository(database)
 	rolePrivilegeOnInstanceRepo := repository.NewRolePrivilegeOnInstanceRepository(database)
+	userPrivilegeOnInstanceRepo := repository.NewUserPrivilegeOnInstanceRepository(database)
 	submissionRepo := repository.NewSubmissionRepository(database)
 	adminRepo := repository.NewAdminRepository(database)
+	walletRepo := repository.NewWalletRepository(database)
+	topUpIntentRepo := repository.NewTopUpIntentRepository(database)
+	submitSlotRepo := repository.NewSubmitSlotRepository(database)
+	trashRepo := repository.NewTrashRepository(database)
+	privilegeAuditRepo := repository.NewPrivilegeAuditRepository(database)
+	adminAccessLogRepo := repository.NewAdminAccessLogRepository(database)
+	translationRepo := repository.NewTranslationRepository(database)
+	questionBankRepo := repository.NewQuestionBankRepository(database)
+	questionVisitRepo := repository.NewQuestionVisitRepository(database)
+	questionPoolRepo := repository.NewQuestionPoolRepository(database)
+	sectionRepo := repository.NewSectionRepository(database)
+
+	// Initialize file storage
+	var fileStorage storage.IFileStorage
+	var err error
+	switch cfg.StorageDriver {
+	case "local":
+		fileStorage, err = filestore.NewLocalStorage(cfg.StorageLocalRoot)
+	case "s3":
+		fileStorage, err = filestore.NewS3Storage(filestore.S3Config{
+			Endpoint:     cfg.S3Endpoint,
+			Region:       cfg.S3Region,
+			Bucket:       cfg.S3Bucket,
+			AccessKey:    cfg.S3AccessKey,
+			SecretKey:    cfg.S3SecretKey,
+			UsePathStyle: cfg.S3UsePathStyle,
+		})
+	default:
+		err = fmt.Errorf("unsupported storage driver: %s", cfg.StorageDriver)
+	}
+	if err != nil {
+		log.Fatalf("failed to initialize file storage: %v", err)
+	}
+
+	// Initialize virus scanner
+	var virusScanner portScanner.IVirusScanner
+	switch cfg.VirusScanner {
+	case "none":
+		virusScanner = scanner.NewNoopScanner()
+	default:
+		log.Fatalf("unsupported virus scanner: %s", cfg.VirusScanner)
+	}
+
+	// Initialize payment gateway, callbacks are trusted on the secret alone
+	if cfg.Env == "production" {
+		if cfg.PaymentGateway == "fake" {
+			log.Fatalf("the fake payment gateway cannot be used in production")
+		}
+		if cfg.PaymentGatewaySecret == "" || cfg.PaymentGatewaySecret == config.DefaultPaymentGatewaySecret {
+			log.Fatalf("PAYMENT_GATEWAY_SECRET must be set in production")
+		}
+	}
+	var paymentGateway gateway.IPaymentGateway
+	var fakePaymentGateway *payment.FakeGateway
+	switch cfg.PaymentGateway {
+	case "fake":
+		fakePaymentGateway = payment.NewFakeGateway(cfg.PaymentGatewaySecret, cfg.PaymentCheckoutBaseURL)
+		paymentGateway = fakePaymentGateway
+	default:
+		log.Fatalf("unsupported payment gateway: %s", cfg.PaymentGateway)
+	}
 
 	// Initialize services
-	questionService := service.NewQuestionService(questionRepo)
 	questionnaireService := service.NewQuestionnaireService(questionnaireRepo)
-	roleService := service.NewRoleService(roleRepo, userRepo, rolePrivilegeRepo, rolePrivilegeOnInstanceRepo)
+	roleService := service.NewRoleService(roleRepo, userRepo, rolePrivilegeRepo, rolePrivilegeOnInstanceRepo, userPrivilegeOnInstanceRepo)
+	questionService := service.NewQuestionService(questionRepo, questionnaireRepo, roleService)
 	authorizationsService := service.NewAuthorizationService(roleService)
 	emailService := service.NewEmailService(cfg)
 	userService := service.NewUserService(userRepo, emailService)
-	answerService := service.NewAnswerService(answerRepo)
-	coreService := service.NewCoreService(questionRepo, submissionRepo, questionnaireRepo, answerRepo)
-	adminService := service.NewAdminService(adminRepo)
+	eligibilityService := service.NewEligibilityService(questionnaireRepo, userRepo, roleService)
+	coreService := service.NewCoreService(questionRepo, submissionRepo, questionnaireRepo, answerRepo, submitSlotRepo, questionVisitRepo, questionPoolRepo, sectionRepo, eligibilityService, fileStorage, virusScanner, cfg.AnswerFileMaxSize, cfg.AnswerFileAllowedTypes, cfg.AnswerTextMaxLength, cfg.OfflineClockTolerance)
+	answerService := service.NewAnswerService(answerRepo, submissionRepo, coreService)
+	adminService := service.NewAdminService(adminRepo, adminAccessLogRepo, roleService)
+	walletService := service.NewWalletService(walletRepo, userRepo, topUpIntentRepo, paymentGateway, cfg.PaymentCallbackURL)
+	submitSlotService := service.NewSubmitSlotService(submitSlotRepo, questionnaireRepo, submissionRepo, walletRepo)
+	resultExportService := service.NewResultExportService(questionnaireRepo, questionRepo, submissionRepo, roleService, fileStorage)
+	timingService := service.NewTimingService(questionnaireRepo, questionRepo, submissionRepo, questionVisitRepo, roleService)
+	questionPoolService := service.NewQuestionPoolService(questionPoolRepo, questionnaireRepo, questionRepo, submissionRepo, roleService)
+	sectionService := service.NewSectionService(sectionRepo, questionnaireRepo, questionRepo, roleService)
+	translationService := service.NewTranslationService(translationRepo, questionnaireRepo, questionRepo, userRepo, roleService)
+	questionBankService := service.NewQuestionBankService(questionBankRepo, questionRepo, questionnaireRepo, roleService)
+	questionMediaService := service.NewQuestionMediaService(questionRepo, questionnaireRepo, submissionRepo, roleService, fileStorage, cfg.MediaMaxSize, cfg.MediaAllowedTypes)
+	submissionHistoryService := service.NewSubmissionHistoryService(submissionRepo, questionnaireRepo, questionRepo)
+	submissionViewService := service.NewSubmissionViewService(submissionRepo, questionnaireRepo, questionRepo, roleService)
+	accountService := service.NewAccountService(userRepo, roleRepo, rolePrivilegeRepo, rolePrivilegeOnInstanceRepo, userPrivilegeOnInstanceRepo, questionnaireRepo, submissionRepo, walletRepo, submitSlotRepo, submitSlotService, fileStorage)
+	privilegeAuditService := service.NewPrivilegeAuditService(privilegeAuditRepo, questionnaireRepo)
+	privilegeService := service.NewPrivilegeService(privilegeRepo)
+	trashService := service.NewTrashService(trashRepo, answerRepo, fileStorage, cfg.TrashRetention)
+
+	// Permanently remove what stayed in the trash past the retention window
+	purgeCron := cron.New()
+	_, err = purgeCron.AddFunc("@daily", func() {
+		log.Println("Running trash purge job...")
+		if err := trashService.Purge(context.Background()); err != nil {
+			log.Printf("Trash purge failed: %v", err)
+		} else {
+			log.Println("Trash purge completed successfully.")
+		}
+	})
+	if err != nil {
+		log.Fatalf("Failed to schedule trash purge: %v", err)
+	}
+	purgeCron.Start()
 
 	// Setup routes
-	SetupUserRoutes(app, database, cfg, userService, emailService, roleService)
-	SetupQuestionnaireRoutes(app, database, cfg, questionnaireService, authorizationsService, roleService, userService, questionService)
-	SetupQuestionRoutes(app, database, cfg, questionService)
+	SetupUserRoutes(app, database, cfg, userService, emailService, roleService, submissionHistoryService, accountService)
+	SetupQuestionnaireRoutes(app, database, cfg, questionnaireService, authorizationsService, roleService, userService, questionService, resultExportService, translationService, timingService, questionPoolService, sectionService, submissionViewService, accountService, privilegeAuditService, eligibilityService)
+	SetupQuestionRoutes(app, database, cfg, questionService, questionMediaService, translationService, sectionService)
 	SetupAnswerRoutes(app, database, cfg, answerService, questionService, questionnaireService, roleService)
-	SetupAdminRoutes(app, database, cfg, adminService)
-	SetupCoreRoutes(app, database, cfg, coreService, roleService, questionnaireService)
+	SetupAdminRoutes(app, database, cfg, adminService, walletService, privilegeAuditService, authorizationsService)
+	SetupCoreRoutes(app, database, cfg, coreService, roleService, questionnaireService, translationService)
+	SetupWalletRoutes(app, database, cfg, walletService, fakePaymentGateway)
+	SetupSubmitSlotRoutes(app, database, cfg, submitSlotService)
+	SetupQuestionBankRoutes(app, database, cfg, questionBankService)
+	SetupTrashRoutes(app, database, cfg, trashService)
+	SetupRoleRoutes(app, database, cfg, roleService, authorizationsService)
+	SetupPrivilegeRoutes(app, database, cfg, privilegeService)
 
 	// Start the server
 	host := cfg.Host
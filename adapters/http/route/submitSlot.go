@@ -0,0 +1,38 @@
+package route
+
+import (
+	"golizilla/adapters/http/handler"
+	"golizilla/adapters/http/handler/middleware"
+	"golizilla/config"
+	"golizilla/core/service"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+func SetupSubmitSlotRoutes(
+	app *fiber.App,
+	db *gorm.DB,
+	cfg *config.Config,
+	submitSlotService service.ISubmitSlotService,
+) {
+	// Create a group for submit slot market routes
+	slotGroup := app.Group("/slots")
+
+	// Initialize handlers
+	submitSlotHandler := handler.NewSubmitSlotHandler(submitSlotService)
+
+	// Initialize the JWT middleware with the config
+	slotGroup.Use(middleware.AuthMiddleware(cfg))
+	slotGroup.Use(middleware.ContextMiddleware())
+
+	// Questionnaire scoped routes, :id is the questionnaire ID
+	slotGroup.Get("/questionnaire/:id", submitSlotHandler.GetListings)
+	slotGroup.Post("/questionnaire/:id", submitSlotHandler.CreateListing)
+	slotGroup.Put("/questionnaire/:id/trading", submitSlotHandler.SetTrading)
+	slotGroup.Get("/questionnaire/:id/audit", submitSlotHandler.GetAuditLogs)
+
+	// Listing scoped routes, :id is the listing ID
+	slotGroup.Post("/:id/buy", submitSlotHandler.BuyListing)
+	slotGroup.Delete("/:id", submitSlotHandler.CancelListing)
+}
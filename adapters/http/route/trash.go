@@ -0,0 +1,34 @@
+package route
+
+import (
+	"golizilla/adapters/http/handler"
+	"golizilla/adapters/http/handler/middleware"
+	"golizilla/config"
+	"golizilla/core/service"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+func SetupTrashRoutes(
+	app *fiber.App,
+	db *gorm.DB,
+	cfg *config.Config,
+	trashService service.ITrashService,
+) {
+	// Create a group for trash routes
+	trashGroup := app.Group("/trash")
+
+	// Initialize handlers
+	trashHandler := handler.NewTrashHandler(trashService)
+
+	// Initialize the JWT middleware with the config
+	trashGroup.Use(middleware.AuthMiddleware(cfg))
+	trashGroup.Use(middleware.ContextMiddleware())
+
+	// Protected routes
+	trashGroup.Get("/", trashHandler.List)
+	trashGroup.Post("/questionnaires/:id/restore", trashHandler.RestoreQuestionnaire)
+	trashGroup.Post("/questions/:id/restore", trashHandler.RestoreQuestion)
+	trashGroup.Post("/answers/:id/restore", trashHandler.RestoreAnswer)
+}
@@ -17,12 +17,16 @@ func SetupUserRoutes(
 	userService service.IUserService,
 	emailService service.IEmailService,
 	roleService service.IRoleService,
+	submissionHistoryService service.ISubmissionHistoryService,
+	accountService service.IAccountService,
 ) {
 	// Create a group for user routes
 	userGroup := app.Group("/users")
 
 	// Initialize handlers
 	userHandler := handler.NewUserHandler(userService, emailService, roleService, cfg)
+	submissionHistoryHandler := handler.NewSubmissionHistoryHandler(submissionHistoryService)
+	accountHandler := handler.NewAccountHandler(accountService, cfg)
 
 	// Public routes
 	userGroup.Post("/register", userHandler.CreateUser)
@@ -41,4 +45,8 @@ func SetupUserRoutes(
 	userGroup.Post("/enable-2fa", userHandler.Enable2FA)
 	userGroup.Post("/disable-2fa", userHandler.Disable2FA)
 	userGroup.Post("/logout", userHandler.Logout)
+	userGroup.Get("/me/submissions", submissionHistoryHandler.List)
+	userGroup.Get("/me/submissions/:id", submissionHistoryHandler.Get)
+	userGroup.Get("/me/export", accountHandler.Export)
+	userGroup.Delete("/me", accountHandler.Delete)
 }
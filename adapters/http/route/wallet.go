@@ -0,0 +1,46 @@
+package route
+
+import (
+	"golizilla/adapters/http/handler"
+	"golizilla/adapters/http/handler/middleware"
+	"golizilla/adapters/payment"
+	"golizilla/config"
+	"golizilla/core/service"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+func SetupWalletRoutes(
+	app *fiber.App,
+	db *gorm.DB,
+	cfg *config.Config,
+	walletService service.IWalletService,
+	fakePaymentGateway *payment.FakeGateway,
+) {
+	// Create a group for wallet routes
+	walletGroup := app.Group("/wallet")
+
+	// Initialize handlers
+	walletHandler := handler.NewWalletHandler(walletService)
+
+	// Public routes, authenticated by the gateway signature instead of a JWT
+	walletGroup.Post("/topup/callback", walletHandler.PaymentCallback)
+
+	// Initialize the JWT middleware with the config
+	walletGroup.Use(middleware.AuthMiddleware(cfg))
+	walletGroup.Use(middleware.ContextMiddleware())
+
+	// Protected routes
+	walletGroup.Get("/balance", walletHandler.GetBalance)
+	walletGroup.Post("/transfer", walletHandler.Transfer)
+	walletGroup.Get("/transactions", walletHandler.GetTransactions)
+	walletGroup.Post("/topup", walletHandler.CreateTopUp)
+	walletGroup.Get("/topup/:id", walletHandler.GetTopUp)
+
+	// Development-only checkout for the fake payment gateway
+	if fakePaymentGateway != nil && cfg.Env != "production" {
+		fakePaymentHandler := handler.NewFakePaymentHandler(fakePaymentGateway, walletService)
+		walletGroup.Post("/topup/fake-checkout/:id", fakePaymentHandler.Checkout)
+	}
+}
@@ -0,0 +1,74 @@
+package payment
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"golizilla/core/port/gateway"
+	"golizilla/internal/apperrors"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// FakeGateway is an in-process payment provider for local development. It never
+// talks to the network: payments are completed by calling SimulateCallback, which
+// produces the same HMAC-signed payload a real provider would send.
+type FakeGateway struct {
+	secret          []byte
+	checkoutBaseURL string
+}
+
+func NewFakeGateway(secret string, checkoutBaseURL string) *FakeGateway {
+	return &FakeGateway{
+		secret:          []byte(secret),
+		checkoutBaseURL: strings.TrimRight(checkoutBaseURL, "/"),
+	}
+}
+
+func (g *FakeGateway) Name() string {
+	return "fake"
+}
+
+func (g *FakeGateway) CreatePayment(ctx context.Context, request gateway.PaymentRequest) (*gateway.Payment, error) {
+	if request.Amount == 0 {
+		return nil, apperrors.ErrInvalidTransferAmount
+	}
+	reference := fmt.Sprintf("fake_%s", uuid.New().String())
+	return &gateway.Payment{
+		Reference:   reference,
+		CheckoutURL: fmt.Sprintf("%s/%s", g.checkoutBaseURL, request.IntentID),
+	}, nil
+}
+
+func (g *FakeGateway) VerifyCallback(ctx context.Context, payload []byte, signature string) (*gateway.PaymentCallback, error) {
+	expected, err := hex.DecodeString(signature)
+	if err != nil || !hmac.Equal(expected, g.sign(payload)) {
+		return nil, apperrors.ErrInvalidPaymentSignature
+	}
+
+	var callback gateway.PaymentCallback
+	if err := json.Unmarshal(payload, &callback); err != nil {
+		return nil, apperrors.ErrInvalidInput
+	}
+	return &callback, nil
+}
+
+// SimulateCallback builds and signs the callback the fake provider would send once
+// the user finishes (or abandons) the checkout.
+func (g *FakeGateway) SimulateCallback(callback gateway.PaymentCallback) ([]byte, string, error) {
+	payload, err := json.Marshal(callback)
+	if err != nil {
+		return nil, "", err
+	}
+	return payload, hex.EncodeToString(g.sign(payload)), nil
+}
+
+func (g *FakeGateway) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, g.secret)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"golizilla/config"
 	models "golizilla/core/domain/model"
-	privilegeconstants "golizilla/internal/privilege"
 	"log"
 	"time"
 
@@ -43,6 +42,24 @@ func setupDB(cfg *config.Config) (*gorm.DB, error) {
 		&models.RolePrivilege{},
 		&models.RolePrivilegeOnInstance{},
 		&models.UserSubmission{},
+		&models.WalletTransaction{},
+		&models.LedgerEntry{},
+		&models.WalletSystemAccount{},
+		&models.TopUpIntent{},
+		&models.SubmitSlotListing{},
+		&models.SubmitSlotAdjustment{},
+		&models.SubmitSlotAuditLog{},
+		&models.Translation{},
+		&models.BankQuestion{},
+		&models.BankOption{},
+		&models.QuestionVisit{},
+		&models.QuestionPool{},
+		&models.SubmissionQuestion{},
+		&models.Section{},
+		&models.SubmissionVariable{},
+		&models.PrivilegeAuditLog{},
+		&models.UserPrivilegeOnInstance{},
+		&models.AdminAccessLog{},
 	)
 	if err != nil {
 		log.Fatalf("Failed to migrate database: %v", err)
@@ -58,7 +75,32 @@ func setupDB(cfg *config.Config) (*gorm.DB, error) {
 		}
 	}
 
-	err = createPrivileges(db)
+	err = syncPrivileges(db)
+	if err != nil {
+		return nil, err
+	}
+
+	err = grantSuperAdminPrivileges(db)
+	if err != nil {
+		return nil, err
+	}
+
+	err = grantSignupPrivileges(db)
+	if err != nil {
+		return nil, err
+	}
+
+	err = migrateInstanceGrants(db)
+	if err != nil {
+		return nil, err
+	}
+
+	err = createWalletSystemAccounts(db)
+	if err != nil {
+		return nil, err
+	}
+
+	err = postWalletOpeningBalances(db)
 	if err != nil {
 		return nil, err
 	}
@@ -83,7 +125,7 @@ func SetupDatabaseWithRetry(cfg *config.Config, retries int) (interface{}, error
 
 func createSuperAdmin(db *gorm.DB, cfg *config.Config) error {
 	role := &models.Role{
-		Name: "super-admin",
+		Name: models.SuperAdminRoleName,
 	}
 	if err := db.Create(role).Error; err != nil {
 		return err
@@ -99,36 +141,23 @@ func createSuperAdmin(db *gorm.DB, cfg *config.Config) error {
 	return db.Create(admin).Error
 }
 
-func createPrivileges(db *gorm.DB) error {
-	// Define a list of privileges
-	privileges := []models.Privilege{
-		{Id: privilegeconstants.CreateQuestionnaire},
-		{Id: privilegeconstants.EditQuestionnaire},
-		{Id: privilegeconstants.DeleteQuestion},
-		{Id: privilegeconstants.ViewQuestionnaire},
-		{Id: privilegeconstants.ViewQuestionnaireInstances, CanSetOnQuestionnaire: true},
-		{Id: privilegeconstants.CreateQuestion},
-		{Id: privilegeconstants.EditQuestion},
-		{Id: privilegeconstants.DeleteQuestion},
-		{Id: privilegeconstants.ViewQuestion},
-		{Id: privilegeconstants.AssignRole},
-		{Id: privilegeconstants.ManagePrivileges},
-	}
-
-	// Loop through the privileges and add them if they do not exist
-	for _, privilege := range privileges {
-		var existingPrivilege models.Privilege
-		err := db.Where("id = ?", privilege.Id).First(&existingPrivilege).Error
+func createWalletSystemAccounts(db *gorm.DB) error {
+	accounts := []models.WalletSystemAccount{
+		{ID: models.WalletPaymentGatewayAccountID, Name: "payment-gateway"},
+		{ID: models.WalletOpeningBalanceAccountID, Name: "opening-balances"},
+	}
+
+	for _, account := range accounts {
+		var existingAccount models.WalletSystemAccount
+		err := db.Where("id = ?", account.ID).First(&existingAccount).Error
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			// Privilege does not exist, create it
-			if err := db.Create(&privilege).Error; err != nil {
-				log.Printf("Failed to create privilege '%s': %v", privilege.Id, err)
+			if err := db.Create(&account).Error; err != nil {
+				log.Printf("Failed to create wallet system account '%s': %v", account.Name, err)
 				return err
 			}
-			log.Printf("Privilege '%s' created successfully.", privilege.Id)
+			log.Printf("Wallet system account '%s' created successfully.", account.Name)
 		} else if err != nil {
-			// An error occurred while querying the database
-			log.Printf("Error checking privilege '%s': %v", privilege.Id, err)
+			log.Printf("Error checking wallet system account '%s': %v", account.Name, err)
 			return err
 		}
 	}
@@ -0,0 +1,97 @@
+package database
+
+import (
+	models "golizilla/core/domain/model"
+	"log"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// migrateInstanceGrants moves the instance grants GiveAcess used to store on the
+// role of the target user to UserPrivilegeOnInstance. The intended users are the
+// role members the audit trail shows the privilege was granted to, or the only
+// member of the role. Grants whose users cannot be told apart are kept as
+// explicit role grants and reported, so nobody silently loses access.
+func migrateInstanceGrants(db *gorm.DB) error {
+	var grants []models.RolePrivilegeOnInstance
+	if err := db.Where("explicit = ?", false).Find(&grants).Error; err != nil {
+		return err
+	}
+
+	for _, grant := range grants {
+		err := db.Transaction(func(tx *gorm.DB) error {
+			var members []uuid.UUID
+			err := tx.Model(&models.User{}).
+				Where("role_id = ? AND anonymized_at IS NULL", grant.RoleId).
+				Pluck("id", &members).Error
+			if err != nil {
+				return err
+			}
+
+			users, err := grantedUsers(tx, grant, members)
+			if err != nil {
+				return err
+			}
+
+			where := tx.Where("role_id = ? AND privilege_id = ? AND questionnaire_id = ?", grant.RoleId, grant.PrivilegeId, grant.QuestionnaireId)
+			if len(users) == 0 && len(members) > 0 {
+				log.Printf("Instance grant '%s' on questionnaire %s for role %s is shared by %d users, kept as role grant, please review it.",
+					grant.PrivilegeId, grant.QuestionnaireId, grant.RoleId, len(members))
+				return where.Model(&models.RolePrivilegeOnInstance{}).Update("explicit", true).Error
+			}
+
+			for _, userID := range users {
+				err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.UserPrivilegeOnInstance{
+					UserId:          userID,
+					PrivilegeId:     grant.PrivilegeId,
+					QuestionnaireId: grant.QuestionnaireId,
+				}).Error
+				if err != nil {
+					return err
+				}
+			}
+			if err := where.Delete(&models.RolePrivilegeOnInstance{}).Error; err != nil {
+				return err
+			}
+			log.Printf("Instance grant '%s' on questionnaire %s moved from role %s to %d users.",
+				grant.PrivilegeId, grant.QuestionnaireId, grant.RoleId, len(users))
+			return nil
+		})
+		if err != nil {
+			log.Printf("Failed to migrate instance grant '%s' on questionnaire %s: %v", grant.PrivilegeId, grant.QuestionnaireId, err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// grantedUsers returns the members the grant was meant for.
+func grantedUsers(tx *gorm.DB, grant models.RolePrivilegeOnInstance, members []uuid.UUID) ([]uuid.UUID, error) {
+	if len(members) <= 1 {
+		return members, nil
+	}
+
+	var auditLogs []models.PrivilegeAuditLog
+	err := tx.Where("questionnaire_id = ? AND privilege = ? AND target_user_id IN ?", grant.QuestionnaireId, grant.PrivilegeId, members).
+		Order("created_at ASC").
+		Find(&auditLogs).Error
+	if err != nil {
+		return nil, err
+	}
+	// the latest entry per user decides
+	granted := make(map[uuid.UUID]bool)
+	for _, auditLog := range auditLogs {
+		granted[*auditLog.TargetUserId] = auditLog.Action == models.PrivilegeAuditGrant
+	}
+
+	var users []uuid.UUID
+	for _, userID := range members {
+		if granted[userID] {
+			users = append(users, userID)
+		}
+	}
+	return users, nil
+}
@@ -0,0 +1,71 @@
+package database
+
+import (
+	"fmt"
+	models "golizilla/core/domain/model"
+	privilegeconstants "golizilla/internal/privilege"
+	"log"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// syncPrivileges reconciles the privileges table with the registry. Missing
+// privileges are created and changed descriptions or instance flags are
+// updated. Privileges the registry no longer knows are only reported, since
+// roles may still hold them.
+func syncPrivileges(db *gorm.DB) error {
+	var existing []models.Privilege
+	if err := db.Find(&existing).Error; err != nil {
+		log.Printf("Error loading privileges: %v", err)
+		return err
+	}
+	byID := make(map[string]models.Privilege, len(existing))
+	for _, privilege := range existing {
+		byID[privilege.Id] = privilege
+	}
+
+	for _, definition := range privilegeconstants.Registry {
+		privilege, ok := byID[definition.ID]
+		delete(byID, definition.ID)
+
+		if !ok {
+			privilege = models.Privilege{
+				Id:                    definition.ID,
+				Description:           definition.Description,
+				CanSetOnQuestionnaire: definition.CanSetOnInstance,
+			}
+			if err := db.Create(&privilege).Error; err != nil {
+				log.Printf("Failed to create privilege '%s': %v", definition.ID, err)
+				return err
+			}
+			log.Printf("Privilege '%s' created successfully.", definition.ID)
+			continue
+		}
+
+		if privilege.Description == definition.Description && privilege.CanSetOnQuestionnaire == definition.CanSetOnInstance {
+			continue
+		}
+		var drift []string
+		if privilege.Description != definition.Description {
+			drift = append(drift, fmt.Sprintf("description: %q, want %q", privilege.Description, definition.Description))
+		}
+		if privilege.CanSetOnQuestionnaire != definition.CanSetOnInstance {
+			drift = append(drift, fmt.Sprintf("can set on instance: %t, want %t", privilege.CanSetOnQuestionnaire, definition.CanSetOnInstance))
+		}
+		log.Printf("Privilege '%s' drifted from the registry (%s), updating it.", definition.ID, strings.Join(drift, "; "))
+		err := db.Model(&models.Privilege{}).Where("id = ?", definition.ID).Updates(map[string]interface{}{
+			"description":              definition.Description,
+			"can_set_on_questionnaire": definition.CanSetOnInstance,
+		}).Error
+		if err != nil {
+			log.Printf("Failed to update privilege '%s': %v", definition.ID, err)
+			return err
+		}
+	}
+
+	for id := range byID {
+		log.Printf("Privilege '%s' is in the database but not in the registry, please review it.", id)
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package database
+
+import (
+	models "golizilla/core/domain/model"
+	privilegeconstants "golizilla/internal/privilege"
+	"log"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// grantSignupPrivileges makes sure the personal role of every user holds the
+// privileges the registry gives new signups by default, so users who signed up
+// before a default was added get it too. Personal roles are the ones named after
+// the username of their member with the signup description.
+func grantSignupPrivileges(db *gorm.DB) error {
+	var roleIDs []uuid.UUID
+	err := db.Model(&models.Role{}).
+		Joins("JOIN users ON users.role_id = roles.id AND users.username = roles.name").
+		Where("roles.description = ?", models.SignupRoleDescription).
+		Distinct().
+		Pluck("roles.id", &roleIDs).Error
+	if err != nil {
+		return err
+	}
+
+	privilegeIDs := privilegeconstants.DefaultPrivileges(privilegeconstants.RoleSignup)
+	var grants []models.RolePrivilege
+	for _, roleID := range roleIDs {
+		for _, privilegeID := range privilegeIDs {
+			grants = append(grants, models.RolePrivilege{RoleId: roleID, PrivilegeId: privilegeID})
+		}
+	}
+	if len(grants) == 0 {
+		return nil
+	}
+
+	result := db.Omit(clause.Associations).Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(&grants, 1000)
+	if result.Error != nil {
+		log.Printf("Failed to grant signup privileges to personal roles: %v", result.Error)
+		return result.Error
+	}
+	if result.RowsAffected > 0 {
+		log.Printf("Granted %d signup privileges to personal roles.", result.RowsAffected)
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package database
+
+import (
+	models "golizilla/core/domain/model"
+	privilegeconstants "golizilla/internal/privilege"
+	"log"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// grantSuperAdminPrivileges makes sure the super-admin role holds the privileges
+// the registry gives it by default. It runs on every start so databases seeded
+// before a privilege existed pick it up too.
+func grantSuperAdminPrivileges(db *gorm.DB) error {
+	var roleIDs []uuid.UUID
+	if err := db.Model(&models.Role{}).Where("name = ?", models.SuperAdminRoleName).Pluck("id", &roleIDs).Error; err != nil {
+		return err
+	}
+	if len(roleIDs) == 0 {
+		log.Printf("Role '%s' not found, no privileges granted.", models.SuperAdminRoleName)
+		return nil
+	}
+
+	privilegeIDs := privilegeconstants.DefaultPrivileges(privilegeconstants.RoleSuperAdmin)
+	var grants []models.RolePrivilege
+	for _, roleID := range roleIDs {
+		for _, privilegeID := range privilegeIDs {
+			grants = append(grants, models.RolePrivilege{RoleId: roleID, PrivilegeId: privilegeID})
+		}
+	}
+	if len(grants) == 0 {
+		return nil
+	}
+
+	result := db.Omit(clause.Associations).Clauses(clause.OnConflict{DoNothing: true}).Create(&grants)
+	if result.Error != nil {
+		log.Printf("Failed to grant privileges to role '%s': %v", models.SuperAdminRoleName, result.Error)
+		return result.Error
+	}
+	if result.RowsAffected > 0 {
+		log.Printf("Granted %d privileges to role '%s'.", result.RowsAffected, models.SuperAdminRoleName)
+	}
+	return nil
+}
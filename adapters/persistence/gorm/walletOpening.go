@@ -0,0 +1,67 @@
+package database
+
+import (
+	models "golizilla/core/domain/model"
+	"log"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// postWalletOpeningBalances moves the balance of every user without ledger entries
+// into the ledger, so wallets funded before the ledger existed reconcile. Each
+// balance is credited from the opening-balances account in its own balanced
+// transaction. Users who already have entries are skipped, which makes it run
+// only once per user.
+func postWalletOpeningBalances(db *gorm.DB) error {
+	var users []models.User
+	err := db.Transaction(func(tx *gorm.DB) error {
+		// the account lock keeps instances starting together from posting twice
+		var account models.WalletSystemAccount
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", models.WalletOpeningBalanceAccountID).
+			First(&account).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("wallet <> 0").
+			Where("NOT EXISTS (SELECT 1 FROM ledger_entries WHERE ledger_entries.account_id = users.id)").
+			Find(&users).Error
+		if err != nil || len(users) == 0 {
+			return err
+		}
+
+		balance := account.Balance
+		for _, user := range users {
+			amount := int64(user.Wallet)
+			balance -= amount
+			transaction := models.WalletTransaction{
+				ID:          uuid.New(),
+				Type:        models.WalletTransactionOpeningBalance,
+				Description: "Opening balance",
+				Entries: []models.LedgerEntry{
+					{AccountID: models.WalletOpeningBalanceAccountID, Amount: -amount, BalanceAfter: balance},
+					{AccountID: user.ID, Amount: amount, BalanceAfter: amount},
+				},
+			}
+			if err := tx.Create(&transaction).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Model(&models.WalletSystemAccount{}).
+			Where("id = ?", models.WalletOpeningBalanceAccountID).
+			Update("balance", balance).Error
+	})
+	if err != nil {
+		log.Printf("Failed to post wallet opening balances: %v", err)
+		return err
+	}
+	if len(users) > 0 {
+		log.Printf("Posted wallet opening balances for %d users.", len(users))
+	}
+	return nil
+}
@@ -4,11 +4,16 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
 )
 
+// DefaultPaymentGatewaySecret is the publicly known development secret, it must
+// never sign payments in production.
+const DefaultPaymentGatewaySecret = "your-payment-secret"
+
 // Config represents the application configuration
 type Config struct {
 	Host string
@@ -43,6 +48,34 @@ type Config struct {
 	AdminPassword   string
 	AdminEmail      string
 	AdminNationalID string
+
+	PaymentGateway         string
+	PaymentGatewaySecret   string
+	PaymentCallbackURL     string
+	PaymentCheckoutBaseURL string
+
+	StorageDriver     string
+	StorageLocalRoot  string
+	S3Endpoint        string
+	S3Region          string
+	S3Bucket          string
+	S3AccessKey       string
+	S3SecretKey       string
+	S3UsePathStyle    bool
+	MediaMaxSize      int64
+	MediaAllowedTypes []string
+
+	AnswerFileMaxSize      int64
+	AnswerFileAllowedTypes []string
+	VirusScanner           string
+	AnswerTextMaxLength    int
+
+	// How far the clock of an offline client may be off
+	OfflineClockTolerance time.Duration
+
+	// Deleted questionnaires, questions and answers can be restored for this long
+	// before the purge job removes them
+	TrashRetention time.Duration
 }
 
 // LoadConfig loads environment variables from the .env file and returns a Config struct
@@ -86,6 +119,30 @@ func LoadConfig() (*Config, error) {
 		AdminPassword:   getEnv("ADMIN_PASSWORD", "password123"),
 		AdminEmail:      getEnv("ADMIN_EMAIL", "admin@example.com"),
 		AdminNationalID: getEnv("ADMIN_NATIONAL_ID", "1234567890"),
+
+		PaymentGateway:         getEnv("PAYMENT_GATEWAY", "fake"),
+		PaymentGatewaySecret:   getEnv("PAYMENT_GATEWAY_SECRET", DefaultPaymentGatewaySecret),
+		PaymentCallbackURL:     getEnv("PAYMENT_CALLBACK_URL", "http://localhost:8080/wallet/topup/callback"),
+		PaymentCheckoutBaseURL: getEnv("PAYMENT_CHECKOUT_BASE_URL", "http://localhost:8080/wallet/topup/fake-checkout"),
+
+		StorageDriver:     getEnv("STORAGE_DRIVER", "local"),
+		StorageLocalRoot:  getEnv("STORAGE_LOCAL_ROOT", "./data/files"),
+		S3Endpoint:        getEnv("S3_ENDPOINT", "http://localhost:9000"),
+		S3Region:          getEnv("S3_REGION", "us-east-1"),
+		S3Bucket:          getEnv("S3_BUCKET", "golizilla"),
+		S3AccessKey:       getEnv("S3_ACCESS_KEY", ""),
+		S3SecretKey:       getEnv("S3_SECRET_KEY", ""),
+		S3UsePathStyle:    getEnv("S3_USE_PATH_STYLE", "true") == "true",
+		MediaMaxSize:      int64(getEnvAsInt("MEDIA_MAX_SIZE_MB", 20)) << 20,
+		MediaAllowedTypes: getEnvAsList("MEDIA_ALLOWED_TYPES", "image/png,image/jpeg,image/gif,image/webp,audio/mpeg,audio/wave,application/ogg,video/mp4,video/webm"),
+
+		AnswerFileMaxSize:      int64(getEnvAsInt("ANSWER_FILE_MAX_SIZE_MB", 10)) << 20,
+		AnswerFileAllowedTypes: getEnvAsList("ANSWER_FILE_ALLOWED_TYPES", "application/pdf,image/png,image/jpeg,application/zip,text/plain"),
+		VirusScanner:           getEnv("VIRUS_SCANNER", "none"),
+		AnswerTextMaxLength:    getEnvAsInt("ANSWER_TEXT_MAX_LENGTH", 10000),
+
+		OfflineClockTolerance: time.Duration(getEnvAsInt("OFFLINE_CLOCK_TOLERANCE", 300)) * time.Second,
+		TrashRetention:        time.Duration(getEnvAsInt("TRASH_RETENTION_DAYS", 30)) * 24 * time.Hour,
 	}
 
 	return cfg, nil
@@ -99,6 +156,17 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
+// Helper function to read a comma separated environment variable as a list
+func getEnvAsList(key, defaultValue string) []string {
+	var values []string
+	for _, value := range strings.Split(getEnv(key, defaultValue), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
 // Helper function to read an environment variable as an integer or return a default value
 func getEnvAsInt(key string, defaultValue int) int {
 	if valueStr, exists := os.LookupEnv(key); exists {
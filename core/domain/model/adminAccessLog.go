@@ -0,0 +1,44 @@
+package model
+
+import (
+	"golizilla/internal/apperrors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type AdminAccessAction string
+
+const (
+	AdminAccessViewAnswers AdminAccessAction = "view_answers"
+)
+
+// AdminAccessLog records an admin reading data of another user. Like the privilege
+// audit trail the IDs are not foreign keys, so entries outlive the data they point to.
+type AdminAccessLog struct {
+	ID              uuid.UUID         `gorm:"type:uuid;primary_key;"`
+	ActorId         uuid.UUID         `gorm:"type:uuid;not null;index"`
+	TargetUserId    uuid.UUID         `gorm:"type:uuid;not null;index"`
+	QuestionnaireId *uuid.UUID        `gorm:"type:uuid;index"`
+	Action          AdminAccessAction `gorm:"not null"`
+	TraceID         string
+	CreatedAt       time.Time `gorm:"index"`
+}
+
+func (l *AdminAccessLog) BeforeCreate(tx *gorm.DB) error {
+	if l.ID == uuid.Nil {
+		l.ID = uuid.New()
+	}
+	return nil
+}
+
+// BeforeUpdate keeps admin access logs append-only.
+func (l *AdminAccessLog) BeforeUpdate(tx *gorm.DB) error {
+	return apperrors.ErrAdminAccessLogImmutable
+}
+
+// BeforeDelete keeps admin access logs append-only.
+func (l *AdminAccessLog) BeforeDelete(tx *gorm.DB) error {
+	return apperrors.ErrAdminAccessLogImmutable
+}
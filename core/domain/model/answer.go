@@ -18,6 +18,14 @@ type Answer struct {
 	Text        *string
 	OptionID    *uuid.UUID // Optional if answer references a chosen option
 	Option      *Option    `gorm:"foreignKey:OptionID"`
+
+	// Set for answers to file upload questions
+	FilePath        string // storage key
+	FileName        string // original name given by the respondent
+	FileContentType string
+	FileSize        int64
+
+	DeletedAt gorm.DeletedAt `gorm:"index"`
 }
 
 func (a *Answer) BeforeCreate(tx *gorm.DB) error {
@@ -0,0 +1,197 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	AnswerFormatEmail      = "email"
+	AnswerFormatPhone      = "phone"
+	AnswerFormatNationalID = "national_id"
+	AnswerFormatNumber     = "number"
+
+	AnswerCharsetDigits       = "digits"
+	AnswerCharsetLetters      = "letters"
+	AnswerCharsetAlphanumeric = "alphanumeric"
+	AnswerCharsetPersian      = "persian"
+	AnswerCharsetLatin        = "latin"
+)
+
+// AnswerRules restrict the text of a descriptive answer. Zero values mean no rule.
+// Lengths are counted in characters, not bytes.
+type AnswerRules struct {
+	MinLength uint
+	MaxLength uint
+	Pattern   string
+	Format    string
+	MinValue  *float64 // a numeric range implies the number format
+	MaxValue  *float64
+	Charset   string
+}
+
+// AnswerValidationError reports which rule an answer broke.
+type AnswerValidationError struct {
+	Field   string
+	Rule    string
+	Message string
+}
+
+func (e *AnswerValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+var (
+	answerEmailRegex = regexp.MustCompile(`^[a-z0-9._%+-]+@[a-z0-9.-]+\.[a-z]{2,}$`)
+	answerPhoneRegex = regexp.MustCompile(`^\+?[0-9]{7,15}$`)
+)
+
+// Validate checks that the rules themselves make sense.
+func (r AnswerRules) Validate() error {
+	if r.MaxLength > 0 && r.MinLength > r.MaxLength {
+		return errors.New("min length cannot be greater than max length")
+	}
+	if r.Pattern != "" {
+		if _, err := regexp.Compile(r.Pattern); err != nil {
+			return fmt.Errorf("invalid pattern: %w", err)
+		}
+	}
+	switch r.Format {
+	case "", AnswerFormatEmail, AnswerFormatPhone, AnswerFormatNationalID, AnswerFormatNumber:
+	default:
+		return fmt.Errorf("unknown format: %q", r.Format)
+	}
+	if (r.MinValue != nil || r.MaxValue != nil) && r.Format != "" && r.Format != AnswerFormatNumber {
+		return errors.New("a numeric range can only be used with the number format")
+	}
+	if r.MinValue != nil && r.MaxValue != nil && *r.MinValue > *r.MaxValue {
+		return errors.New("min value cannot be greater than max value")
+	}
+	switch r.Charset {
+	case "", AnswerCharsetDigits, AnswerCharsetLetters, AnswerCharsetAlphanumeric, AnswerCharsetPersian, AnswerCharsetLatin:
+	default:
+		return fmt.Errorf("unknown charset: %q", r.Charset)
+	}
+	return nil
+}
+
+// IsZero reports whether no rule is set.
+func (r AnswerRules) IsZero() bool {
+	return r == AnswerRules{}
+}
+
+// Check validates the text of an answer and returns an *AnswerValidationError for
+// the first rule it breaks.
+func (r AnswerRules) Check(text string) error {
+	length := uint(utf8.RuneCountInString(text))
+	if r.MinLength > 0 && length < r.MinLength {
+		return newAnswerValidationError("min_length", fmt.Sprintf("must be at least %d characters", r.MinLength))
+	}
+	if r.MaxLength > 0 && length > r.MaxLength {
+		return newAnswerValidationError("max_length", fmt.Sprintf("must be at most %d characters", r.MaxLength))
+	}
+
+	if r.Charset != "" {
+		for _, char := range text {
+			if !inAnswerCharset(r.Charset, char) {
+				return newAnswerValidationError("charset", fmt.Sprintf("contains %q, which is not allowed by the %s character set", char, r.Charset))
+			}
+		}
+	}
+
+	value := strings.TrimSpace(normalizeDigits(text))
+	switch r.Format {
+	case AnswerFormatEmail:
+		if !answerEmailRegex.MatchString(strings.ToLower(value)) {
+			return newAnswerValidationError("format", "must be a valid email address")
+		}
+	case AnswerFormatPhone:
+		if !answerPhoneRegex.MatchString(strings.NewReplacer(" ", "", "-", "", "(", "", ")", "").Replace(value)) {
+			return newAnswerValidationError("format", "must be a valid phone number")
+		}
+	case AnswerFormatNationalID:
+		if !validNationalID(value) {
+			return newAnswerValidationError("format", "must be a valid national ID")
+		}
+	}
+
+	if r.Format == AnswerFormatNumber || r.MinValue != nil || r.MaxValue != nil {
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return newAnswerValidationError("format", "must be a number")
+		}
+		if r.MinValue != nil && number < *r.MinValue {
+			return newAnswerValidationError("min_value", fmt.Sprintf("must be at least %v", *r.MinValue))
+		}
+		if r.MaxValue != nil && number > *r.MaxValue {
+			return newAnswerValidationError("max_value", fmt.Sprintf("must be at most %v", *r.MaxValue))
+		}
+	}
+
+	if r.Pattern != "" {
+		pattern, err := regexp.Compile(r.Pattern)
+		if err != nil || !pattern.MatchString(text) {
+			return newAnswerValidationError("pattern", "does not match the required pattern")
+		}
+	}
+	return nil
+}
+
+func newAnswerValidationError(rule, message string) *AnswerValidationError {
+	return &AnswerValidationError{Field: "text", Rule: rule, Message: message}
+}
+
+func inAnswerCharset(charset string, char rune) bool {
+	switch charset {
+	case AnswerCharsetDigits:
+		return unicode.IsDigit(char)
+	case AnswerCharsetLetters:
+		return unicode.IsLetter(char) || unicode.IsMark(char) || unicode.IsSpace(char)
+	case AnswerCharsetAlphanumeric:
+		return unicode.IsLetter(char) || unicode.IsMark(char) || unicode.IsDigit(char) || unicode.IsSpace(char)
+	case AnswerCharsetPersian:
+		// zero width non-joiner is part of regular Persian spelling
+		return unicode.Is(unicode.Arabic, char) || char == '\u200c' || unicode.IsDigit(char) || unicode.IsSpace(char) || unicode.IsPunct(char)
+	case AnswerCharsetLatin:
+		return unicode.Is(unicode.Latin, char) || unicode.IsDigit(char) || unicode.IsSpace(char) || unicode.IsPunct(char)
+	}
+	return true
+}
+
+// normalizeDigits turns Persian and Arabic-Indic digits into ASCII digits, so
+// respondents can type numbers with either keyboard.
+func normalizeDigits(text string) string {
+	return strings.Map(func(char rune) rune {
+		switch {
+		case char >= '۰' && char <= '۹':
+			return '0' + char - '۰'
+		case char >= '٠' && char <= '٩':
+			return '0' + char - '٠'
+		}
+		return char
+	}, text)
+}
+
+// validNationalID checks the length and checksum of an Iranian national ID.
+func validNationalID(nationalID string) bool {
+	if len(nationalID) != 10 || strings.Count(nationalID, nationalID[:1]) == 10 {
+		return false
+	}
+	sum := 0
+	for i := 0; i < 10; i++ {
+		if nationalID[i] < '0' || nationalID[i] > '9' {
+			return false
+		}
+		if i < 9 {
+			sum += int(nationalID[i]-'0') * (10 - i)
+		}
+	}
+	remainder := sum % 11
+	check := int(nationalID[9] - '0')
+	return (remainder < 2 && check == remainder) || (remainder >= 2 && check == 11-remainder)
+}
@@ -0,0 +1,132 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EligibilityRules restrict who may answer a questionnaire. Zero values mean no
+// rule, list rules are comma separated and match any of their values.
+type EligibilityRules struct {
+	MinAge            uint   // in years, from the date of birth
+	MaxAge            uint   // in years, from the date of birth
+	Cities            string // compared case-insensitively
+	RoleIDs           string // the role of the user or one it inherits from
+	EmailDomains      string // the email must be verified
+	MinAccountAgeDays uint
+}
+
+// EligibilityError reports why a user may not answer a questionnaire.
+type EligibilityError struct {
+	Rule   string
+	Reason string
+}
+
+func (e *EligibilityError) Error() string {
+	return e.Reason
+}
+
+// Validate checks that the rules themselves make sense.
+func (r EligibilityRules) Validate() error {
+	if r.MaxAge > 0 && r.MinAge > r.MaxAge {
+		return errors.New("min age cannot be greater than max age")
+	}
+	for _, roleID := range SplitList(r.RoleIDs) {
+		if _, err := uuid.Parse(roleID); err != nil {
+			return fmt.Errorf("invalid role ID: %q", roleID)
+		}
+	}
+	for _, domain := range SplitList(r.EmailDomains) {
+		if strings.Contains(domain, "@") || !strings.Contains(domain, ".") {
+			return fmt.Errorf("invalid email domain: %q", domain)
+		}
+	}
+	return nil
+}
+
+// IsZero reports whether no rule is set.
+func (r EligibilityRules) IsZero() bool {
+	return r == EligibilityRules{}
+}
+
+// Check returns an *EligibilityError for the first rule the user breaks. roleChain
+// is the role of the user followed by the roles it inherits from.
+func (r EligibilityRules) Check(user *User, roleChain []uuid.UUID, now time.Time) error {
+	if r.MinAge > 0 || r.MaxAge > 0 {
+		if user.DateOfBirth.IsZero() {
+			return newEligibilityError("age", "your date of birth is required, add it to your profile")
+		}
+		age := ageAt(user.DateOfBirth, now)
+		if r.MinAge > 0 && age < r.MinAge {
+			return newEligibilityError("min_age", fmt.Sprintf("you must be at least %d years old", r.MinAge))
+		}
+		if r.MaxAge > 0 && age > r.MaxAge {
+			return newEligibilityError("max_age", fmt.Sprintf("you must be at most %d years old", r.MaxAge))
+		}
+	}
+
+	if cities := SplitList(r.Cities); len(cities) > 0 {
+		if !slices.ContainsFunc(cities, func(city string) bool {
+			return strings.EqualFold(city, strings.TrimSpace(user.City))
+		}) {
+			return newEligibilityError("city", fmt.Sprintf("only respondents from %s may answer", strings.Join(cities, ", ")))
+		}
+	}
+
+	if roleIDs := SplitList(r.RoleIDs); len(roleIDs) > 0 {
+		if !slices.ContainsFunc(roleChain, func(roleID uuid.UUID) bool {
+			return slices.Contains(roleIDs, roleID.String())
+		}) {
+			return newEligibilityError("role", "your role may not answer this questionnaire")
+		}
+	}
+
+	if domains := SplitList(r.EmailDomains); len(domains) > 0 {
+		if !user.IsActive {
+			return newEligibilityError("email_domain", "your email must be verified")
+		}
+		_, domain, _ := strings.Cut(user.Email, "@")
+		if !slices.ContainsFunc(domains, func(allowed string) bool {
+			return strings.EqualFold(allowed, domain)
+		}) {
+			return newEligibilityError("email_domain", fmt.Sprintf("your email must belong to %s", strings.Join(domains, ", ")))
+		}
+	}
+
+	if r.MinAccountAgeDays > 0 && now.Before(user.CreatedAt.AddDate(0, 0, int(r.MinAccountAgeDays))) {
+		return newEligibilityError("account_age", fmt.Sprintf("your account must be at least %d days old", r.MinAccountAgeDays))
+	}
+	return nil
+}
+
+// SplitList returns the trimmed, non-empty values of a comma separated list.
+func SplitList(list string) []string {
+	var values []string
+	for _, value := range strings.Split(list, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+func newEligibilityError(rule, reason string) *EligibilityError {
+	return &EligibilityError{Rule: rule, Reason: reason}
+}
+
+// ageAt returns the age in full years on the given day.
+func ageAt(dateOfBirth, now time.Time) uint {
+	years := now.Year() - dateOfBirth.Year()
+	if now.Month() < dateOfBirth.Month() || (now.Month() == dateOfBirth.Month() && now.Day() < dateOfBirth.Day()) {
+		years--
+	}
+	if years < 0 {
+		return 0
+	}
+	return uint(years)
+}
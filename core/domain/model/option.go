@@ -10,6 +10,12 @@ type Option struct {
 	QuestionID uuid.UUID `gorm:"type:uuid;not null"` // FK back to Question
 	Index      uint
 	Text       string
+
+	// Set when the option came from the question bank
+	BankOptionID *uuid.UUID `gorm:"type:uuid;index"`
+
+	// Choosing the option skips ahead to this section once the page is done
+	JumpSectionID *uuid.UUID `gorm:"type:uuid"`
 }
 
 func (o *Option) BeforeCreate(tx *gorm.DB) error {
@@ -2,6 +2,7 @@ package model
 
 type Privilege struct {
 	Id                    string `gorm:"primaryKey"`
+	Description           string
 	CanSetOnQuestionnaire bool
 	Roles                 []*Role `gorm:"many2many:RolePrivilege"`
 }
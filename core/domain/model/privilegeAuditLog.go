@@ -0,0 +1,61 @@
+package model
+
+import (
+	"golizilla/internal/apperrors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type PrivilegeAuditAction string
+
+const (
+	PrivilegeAuditGrant  PrivilegeAuditAction = "grant"
+	PrivilegeAuditRevoke PrivilegeAuditAction = "revoke"
+)
+
+// PrivilegeAuditLog records one privilege granted to or revoked from a user, or a
+// whole role, on a questionnaire. The IDs are not foreign keys, so the trail
+// outlives purged questionnaires.
+type PrivilegeAuditLog struct {
+	ID              uuid.UUID            `gorm:"type:uuid;primary_key;"`
+	QuestionnaireId uuid.UUID            `gorm:"type:uuid;not null;index"`
+	ActorId         uuid.UUID            `gorm:"type:uuid;not null;index"`
+	TargetUserId    *uuid.UUID           `gorm:"type:uuid;index"`
+	TargetRoleId    *uuid.UUID           `gorm:"type:uuid;index"`
+	Privilege       string               `gorm:"not null"`
+	Action          PrivilegeAuditAction `gorm:"not null"`
+	TraceID         string
+	CreatedAt       time.Time `gorm:"index"`
+}
+
+// PrivilegeAuditFilter narrows a list of privilege audit logs, zero fields match
+// everything. From and To bound the time of the change.
+type PrivilegeAuditFilter struct {
+	QuestionnaireID *uuid.UUID
+	ActorID         *uuid.UUID
+	TargetUserID    *uuid.UUID
+	TargetRoleID    *uuid.UUID
+	Privilege       string
+	Action          PrivilegeAuditAction
+	From            *time.Time
+	To              *time.Time
+}
+
+func (l *PrivilegeAuditLog) BeforeCreate(tx *gorm.DB) error {
+	if l.ID == uuid.Nil {
+		l.ID = uuid.New()
+	}
+	return nil
+}
+
+// BeforeUpdate keeps privilege audit logs append-only.
+func (l *PrivilegeAuditLog) BeforeUpdate(tx *gorm.DB) error {
+	return apperrors.ErrPrivilegeAuditLogImmutable
+}
+
+// BeforeDelete keeps privilege audit logs append-only.
+func (l *PrivilegeAuditLog) BeforeDelete(tx *gorm.DB) error {
+	return apperrors.ErrPrivilegeAuditLogImmutable
+}
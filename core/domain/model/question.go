@@ -13,7 +13,42 @@ type Question struct {
 	Index        uint
 	QuestionText string
 	Descriptive  bool
-	MetaDataPath string
+	MetaDataPath string // storage key of the uploaded media, if any
+
+	// Required questions must be answered before the respondent leaves their page
+	Required bool
+
+	MetaDataContentType string
+	MetaDataSize        int64
+
+	// Page the question is shown on, questions of a section are asked together
+	SectionID *uuid.UUID `gorm:"type:uuid;index"`
+
+	// Questions in a pool are only asked when drawn for the submission, Tag and
+	// Difficulty are the groups a stratified pool draws from.
+	PoolID     *uuid.UUID `gorm:"type:uuid;index"`
+	Tag        string
+	Difficulty string
+
+	// Seconds a respondent may spend on the question, 0 means no limit. After it
+	// passes answers are rejected. An "advance" question also moves the respondent
+	// on to the next page and can no longer be returned to.
+	TimeLimit       uint
+	TimeLimitAction string
+
+	// Rules for the text of descriptive answers
+	AnswerRules AnswerRules `gorm:"embedded;embeddedPrefix:answer_"`
+
+	// File upload questions are answered with a file instead of text or an option
+	FileUpload       bool
+	FileMaxSize      int64  // in bytes, 0 uses the server default
+	FileAllowedTypes string // comma separated MIME types, empty uses the server default
+
+	// Set when the question came from the question bank. Linked questions pull
+	// updates of the bank question, copies only keep the reference for results.
+	BankQuestionID *uuid.UUID `gorm:"type:uuid;index"`
+	BankVersion    uint
+	BankLinked     bool
 
 	// For correct option, store an ID
 	CorrectOptionID *uuid.UUID
@@ -24,8 +59,15 @@ type Question struct {
 
 	// Multiple answers for this question
 	Answers []Answer `gorm:"foreignKey:QuestionID"`
+
+	DeletedAt gorm.DeletedAt `gorm:"index"`
 }
 
+const (
+	TimeLimitActionLock    = "lock"
+	TimeLimitActionAdvance = "advance"
+)
+
 func (q *Question) BeforeCreate(tx *gorm.DB) error {
 	if q.ID == uuid.Nil {
 		q.ID = uuid.New()
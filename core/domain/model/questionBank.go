@@ -0,0 +1,69 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type BankQuestionScope string
+
+const (
+	BankQuestionScopePersonal     BankQuestionScope = "personal"
+	BankQuestionScopeOrganization BankQuestionScope = "organization"
+)
+
+// BankQuestion is a library entry that can be linked or copied into questionnaires.
+// Version is bumped on every update so linked questions know when to pull.
+type BankQuestion struct {
+	ID           uuid.UUID         `gorm:"type:uuid;primary_key;"`
+	OwnerId      uuid.UUID         `gorm:"type:uuid;not null;index"`
+	Scope        BankQuestionScope `gorm:"not null;index"`
+	QuestionText string            `gorm:"not null"`
+	Descriptive  bool
+	Version      uint         `gorm:"not null;default:1"`
+	Options      []BankOption `gorm:"foreignKey:BankQuestionID"`
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+func (b *BankQuestion) BeforeCreate(tx *gorm.DB) error {
+	if b.ID == uuid.Nil {
+		b.ID = uuid.New()
+	}
+	return nil
+}
+
+// BankOption keeps its ID across versions, questionnaire options point back to it
+// so answers can be compared across every questionnaire using the bank question.
+type BankOption struct {
+	ID             uuid.UUID `gorm:"type:uuid;primary_key;"`
+	BankQuestionID uuid.UUID `gorm:"type:uuid;not null;index"`
+	Index          uint
+	Text           string
+}
+
+func (o *BankOption) BeforeCreate(tx *gorm.DB) error {
+	if o.ID == uuid.Nil {
+		o.ID = uuid.New()
+	}
+	return nil
+}
+
+// BankQuestionUsage is a questionnaire question created from a bank question and
+// the number of answers it received.
+type BankQuestionUsage struct {
+	QuestionnaireId uuid.UUID `json:"questionnaire_id"`
+	QuestionID      uuid.UUID `json:"question_id"`
+	BankLinked      bool      `json:"linked"`
+	BankVersion     uint      `json:"version"`
+	Answers         int64     `json:"answers"`
+}
+
+// BankOptionCount is the number of answers choosing a bank option in one questionnaire.
+type BankOptionCount struct {
+	QuestionnaireId uuid.UUID `json:"questionnaire_id"`
+	BankOptionID    uuid.UUID `json:"bank_option_id"`
+	Count           int64     `json:"count"`
+}
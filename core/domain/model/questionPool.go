@@ -0,0 +1,61 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// QuestionPool groups questions of a questionnaire of which only DrawCount are asked
+// to each respondent. The draw happens when the submission starts.
+type QuestionPool struct {
+	ID              uuid.UUID `gorm:"type:uuid;primary_key;"`
+	QuestionnaireId uuid.UUID `gorm:"type:uuid;not null;index"`
+	Name            string
+	DrawCount       uint   `gorm:"not null"`
+	StratifyBy      string // empty, "tag" or "difficulty"
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+
+	Questions []Question `gorm:"foreignKey:PoolID"`
+}
+
+const (
+	PoolStratifyTag        = "tag"
+	PoolStratifyDifficulty = "difficulty"
+)
+
+func (p *QuestionPool) BeforeCreate(tx *gorm.DB) error {
+	if p.ID == uuid.Nil {
+		p.ID = uuid.New()
+	}
+	return nil
+}
+
+// StratumOf returns the group a question is drawn from when the pool is stratified.
+func (p *QuestionPool) StratumOf(question *Question) string {
+	switch p.StratifyBy {
+	case PoolStratifyTag:
+		return question.Tag
+	case PoolStratifyDifficulty:
+		return question.Difficulty
+	}
+	return ""
+}
+
+// SubmissionQuestion is one question drawn for a submission, Position is its place
+// in the order the respondent goes through them.
+type SubmissionQuestion struct {
+	ID               uuid.UUID `gorm:"type:uuid;primary_key;"`
+	UserSubmissionID uuid.UUID `gorm:"type:uuid;not null;index"`
+	QuestionID       uuid.UUID `gorm:"type:uuid;not null"`
+	Position         int       `gorm:"not null"`
+}
+
+func (s *SubmissionQuestion) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// QuestionVisit is one stay of a respondent on a question, from the Start, Next or
+// Back call that showed it until the call that left it. Time spent on a question is
+// the sum of its visits.
+type QuestionVisit struct {
+	ID               uuid.UUID `gorm:"type:uuid;primary_key;"`
+	UserSubmissionID uuid.UUID `gorm:"type:uuid;not null;index"`
+	QuestionnaireId  uuid.UUID `gorm:"type:uuid;not null;index"`
+	QuestionID       uuid.UUID `gorm:"type:uuid;not null"`
+	EnteredAt        time.Time `gorm:"not null"`
+	LeftAt           *time.Time
+	DurationMs       int64
+	AnsweredAt       *time.Time // last answer submitted during this visit
+}
+
+func (v *QuestionVisit) BeforeCreate(tx *gorm.DB) error {
+	if v.ID == uuid.Nil {
+		v.ID = uuid.New()
+	}
+	return nil
+}
+
+// QuestionDwell is the total time a submission spent on one question.
+type QuestionDwell struct {
+	UserSubmissionID uuid.UUID
+	QuestionID       uuid.UUID
+	DurationMs       int64
+	Answered         bool
+}
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type Questionnaire struct {
@@ -15,9 +16,15 @@ type Questionnaire struct {
 	Random             bool
 	BackCompatible     bool
 	Title              string
-	AnswerTime         uint `gorm:"not null"`
+	DefaultLocale      string `gorm:"not null;default:'en'"`
+	AnswerTime         uint   `gorm:"not null"`
 	ParticipationCount uint
 	Anonymous          bool
 	SubmitLimit        uint
-	Owner              User `gorm:"foreinKey:OwnerId"`
+	SlotTradingEnabled bool
+	AnswerReview       bool             // respondents may see their answers after submitting
+	Eligibility        EligibilityRules `gorm:"embedded;embeddedPrefix:eligibility_"` // who may answer
+	ArchivedAt         *time.Time       // archived questionnaires cannot be answered anymore
+	DeletedAt          gorm.DeletedAt   `gorm:"index"`
+	Owner              User             `gorm:"foreinKey:OwnerId"`
 }
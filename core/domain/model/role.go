@@ -1,12 +1,21 @@
 package model
 
 import (
+	privilegeconstants "golizilla/internal/privilege"
 	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
+// SuperAdminRoleName is the role seeded for the admin user, it cannot be renamed or
+// deleted.
+const SuperAdminRoleName = privilegeconstants.RoleSuperAdmin
+
+// SignupRoleDescription is the description of the personal role every user gets
+// at signup, named after their username.
+const SignupRoleDescription = "Default"
+
 type Role struct {
 	ID             uuid.UUID `gorm:"type:uuid;primaryKey"`
 	Name           string
@@ -16,6 +25,8 @@ type Role struct {
 	Users          []*User          `gorm:"foreinKey:RoleId"`
 	Privileges     []*Privilege     `gorm:"many2many:RolePrivilege"`
 	Questionnaires []*Questionnaire `gorm:"many2many:RolePrivilegeOnInstance"`
+	// the role inherits every privilege of its parent
+	ParentId *uuid.UUID `gorm:"type:uuid;index"`
 }
 
 // BeforeCreate is a GORM hook to generate a UUID before creating a new record.
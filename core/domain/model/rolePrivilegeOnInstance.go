@@ -1,7 +1,12 @@
 package model
 
-import "github.com/google/uuid"
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
 
+// RolePrivilegeOnInstance grants a privilege on a questionnaire to every user of
+// a role. Grants for single users are stored as UserPrivilegeOnInstance.
 type RolePrivilegeOnInstance struct {
 	Id              uuid.UUID `gorm:"primaryKey"`
 	RoleId          uuid.UUID `gorm:"not null"`
@@ -9,4 +14,14 @@ type RolePrivilegeOnInstance struct {
 	QuestionnaireId uuid.UUID     `gorm:"not null"`
 	Role            Role          `gorm:"foreinKey:RoleId"`
 	Questionnaire   Questionnaire `gorm:"foreinKey:QuestionnaireId"`
+	// false on grants made before user grants existed, when granting access to a
+	// user silently granted it to their whole role, until they are migrated
+	Explicit bool `gorm:"not null;default:false"`
+}
+
+func (r *RolePrivilegeOnInstance) BeforeCreate(tx *gorm.DB) error {
+	if r.Id == uuid.Nil {
+		r.Id = uuid.New()
+	}
+	return nil
 }
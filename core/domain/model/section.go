@@ -0,0 +1,28 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Section groups questions of a questionnaire into one page. Questions without a
+// section are shown on a page of their own.
+type Section struct {
+	ID              uuid.UUID `gorm:"type:uuid;primary_key;"`
+	QuestionnaireId uuid.UUID `gorm:"type:uuid;not null;index"`
+	Title           string
+	Description     string
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+
+	Questions []Question `gorm:"foreignKey:SectionID"`
+}
+
+func (s *Section) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	return nil
+}
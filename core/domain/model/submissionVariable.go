@@ -0,0 +1,49 @@
+package model
+
+import (
+	"regexp"
+	"strconv"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// SubmissionVariable is a hidden variable supplied when the submission was started,
+// such as a campaign code. It is not asked but can be piped into question texts
+// and is part of the results.
+type SubmissionVariable struct {
+	ID               uuid.UUID `gorm:"type:uuid;primary_key;"`
+	UserSubmissionID uuid.UUID `gorm:"type:uuid;not null;index"`
+	Name             string    `gorm:"not null"`
+	Value            string
+}
+
+func (v *SubmissionVariable) BeforeCreate(tx *gorm.DB) error {
+	if v.ID == uuid.Nil {
+		v.ID = uuid.New()
+	}
+	return nil
+}
+
+var (
+	variableNamePattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]{0,63}$`)
+	// answers are piped with {{q<index>}}, variables may not shadow them
+	answerRefPattern = regexp.MustCompile(`^q[0-9]+$`)
+)
+
+// IsValidVariableName reports whether name can be used as a hidden variable.
+func IsValidVariableName(name string) bool {
+	return variableNamePattern.MatchString(name) && !answerRefPattern.MatchString(name)
+}
+
+// AnswerRefIndex returns the question index of an answer reference such as q3.
+func AnswerRefIndex(name string) (uint, bool) {
+	if !answerRefPattern.MatchString(name) {
+		return 0, false
+	}
+	index, err := strconv.ParseUint(name[1:], 10, 32)
+	if err != nil {
+		return 0, false
+	}
+	return uint(index), true
+}
@@ -0,0 +1,88 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type SubmitSlotListingStatus string
+
+const (
+	SubmitSlotListingOpen      SubmitSlotListingStatus = "open"
+	SubmitSlotListingSold      SubmitSlotListingStatus = "sold"
+	SubmitSlotListingCancelled SubmitSlotListingStatus = "cancelled"
+)
+
+// SubmitSlotListing offers one unused submission slot of a questionnaire for sale.
+// The slot is taken from the seller as soon as it is listed and handed back if the
+// listing is cancelled.
+type SubmitSlotListing struct {
+	ID              uuid.UUID               `gorm:"type:uuid;primary_key;"`
+	QuestionnaireId uuid.UUID               `gorm:"type:uuid;not null;index"` // FK to Questionnaire
+	Questionnaire   Questionnaire           `gorm:"foreignKey:QuestionnaireId"`
+	SellerId        uuid.UUID               `gorm:"type:uuid;not null;index"` // FK to User
+	Seller          User                    `gorm:"foreignKey:SellerId"`
+	BuyerId         *uuid.UUID              `gorm:"type:uuid"`
+	Price           uint                    `gorm:"not null"`
+	Status          SubmitSlotListingStatus `gorm:"not null;default:'open'"`
+	TransactionID   *uuid.UUID              `gorm:"type:uuid;uniqueIndex"` // ledger transaction that paid the seller
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	SoldAt          *time.Time
+}
+
+// SubmitSlotAdjustment changes a user's submit limit on one questionnaire by Delta.
+// Adjustments are append-only; the effective limit is SubmitLimit plus their sum.
+type SubmitSlotAdjustment struct {
+	ID              uuid.UUID  `gorm:"type:uuid;primary_key;"`
+	UserId          uuid.UUID  `gorm:"type:uuid;not null;index:idx_submit_slot_adjustment_user_questionnaire"`
+	QuestionnaireId uuid.UUID  `gorm:"type:uuid;not null;index:idx_submit_slot_adjustment_user_questionnaire"`
+	Delta           int        `gorm:"not null"`
+	ListingID       *uuid.UUID `gorm:"type:uuid;index"`
+	CreatedAt       time.Time
+}
+
+type SubmitSlotAuditAction string
+
+const (
+	SubmitSlotAuditTradingEnabled  SubmitSlotAuditAction = "trading_enabled"
+	SubmitSlotAuditTradingDisabled SubmitSlotAuditAction = "trading_disabled"
+	SubmitSlotAuditListed          SubmitSlotAuditAction = "listed"
+	SubmitSlotAuditCancelled       SubmitSlotAuditAction = "cancelled"
+	SubmitSlotAuditSold            SubmitSlotAuditAction = "sold"
+)
+
+// SubmitSlotAuditLog records every change to the slot market of a questionnaire.
+type SubmitSlotAuditLog struct {
+	ID              uuid.UUID             `gorm:"type:uuid;primary_key;"`
+	QuestionnaireId uuid.UUID             `gorm:"type:uuid;not null;index"`
+	ActorId         uuid.UUID             `gorm:"type:uuid;not null"`
+	Action          SubmitSlotAuditAction `gorm:"not null"`
+	ListingID       *uuid.UUID            `gorm:"type:uuid"`
+	TransactionID   *uuid.UUID            `gorm:"type:uuid"`
+	Price           uint
+	CreatedAt       time.Time
+}
+
+func (l *SubmitSlotListing) BeforeCreate(tx *gorm.DB) error {
+	if l.ID == uuid.Nil {
+		l.ID = uuid.New()
+	}
+	return nil
+}
+
+func (a *SubmitSlotAdjustment) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	return nil
+}
+
+func (a *SubmitSlotAuditLog) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	return nil
+}
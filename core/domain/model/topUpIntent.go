@@ -0,0 +1,40 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type TopUpStatus string
+
+const (
+	TopUpStatusPending   TopUpStatus = "pending"
+	TopUpStatusSucceeded TopUpStatus = "succeeded"
+	TopUpStatusFailed    TopUpStatus = "failed"
+)
+
+// TopUpIntent tracks a wallet top-up from its creation at the payment gateway
+// until the gateway reports the outcome through a signed callback.
+type TopUpIntent struct {
+	ID            uuid.UUID   `gorm:"type:uuid;primary_key;"`
+	UserID        uuid.UUID   `gorm:"type:uuid;not null;index"` // FK to User
+	User          User        `gorm:"foreignKey:UserID"`
+	Amount        uint        `gorm:"not null"`
+	Status        TopUpStatus `gorm:"not null;default:'pending'"`
+	Gateway       string      `gorm:"not null"`
+	Reference     string      `gorm:"uniqueIndex;not null"` // payment ID at the gateway
+	CheckoutURL   string
+	TransactionID *uuid.UUID `gorm:"type:uuid;uniqueIndex"` // ledger transaction that credited the wallet
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	CompletedAt   *time.Time
+}
+
+func (t *TopUpIntent) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package model
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	DefaultLocale = "en"
+
+	TranslationQuestionnaireTitle = "questionnaire_title"
+	TranslationQuestionText       = "question_text"
+	TranslationOptionText         = "option_text"
+)
+
+// Translation holds the text of a questionnaire title, question or option in one
+// locale. The text fields on those models are in the questionnaire's DefaultLocale,
+// so options keep a single ID no matter which language they were answered in.
+type Translation struct {
+	ID              uuid.UUID `gorm:"type:uuid;primary_key;"`
+	QuestionnaireId uuid.UUID `gorm:"type:uuid;not null;index"`
+	EntityType      string    `gorm:"not null;uniqueIndex:idx_translation_entity_locale"`
+	EntityID        uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_translation_entity_locale"`
+	Locale          string    `gorm:"not null;uniqueIndex:idx_translation_entity_locale"`
+	Text            string    `gorm:"not null"`
+}
+
+func (t *Translation) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	return nil
+}
+
+var localePattern = regexp.MustCompile(`^[a-z]{2,3}(-[a-z0-9]{2,8})*$`)
+
+// NormalizeLocale lowercases a language tag such as "fa_IR" to "fa-ir" and reports
+// whether it is well formed.
+func NormalizeLocale(locale string) (string, bool) {
+	locale = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+	return locale, localePattern.MatchString(locale)
+}
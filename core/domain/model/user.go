@@ -32,11 +32,14 @@ type User struct {
 	FirstName        string
 	LastName         string
 	City             string
+	PreferredLocale  string
 	Wallet           uint
 	DateOfBirth      time.Time       `gorm:"type:date"`
 	NotificationList []*Notification `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE;"`
 	RoleId           uuid.UUID       `gorm:"not null"`
 	Role             Role            `gorm:"foreinKey:RoleId"`
+	// set when the account was deleted, the row only keeps references intact
+	AnonymizedAt *time.Time
 }
 
 // BeforeCreate is a GORM hook to generate a UUID before creating a new record.
@@ -0,0 +1,24 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// UserPrivilegeOnInstance grants a privilege on a questionnaire to a single user.
+type UserPrivilegeOnInstance struct {
+	Id              uuid.UUID `gorm:"type:uuid;primaryKey"`
+	UserId          uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_user_privilege_on_instance"`
+	PrivilegeId     string    `gorm:"not null;uniqueIndex:idx_user_privilege_on_instance"`
+	QuestionnaireId uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_user_privilege_on_instance;index"`
+	CreatedAt       time.Time
+}
+
+func (u *UserPrivilegeOnInstance) BeforeCreate(tx *gorm.DB) error {
+	if u.Id == uuid.Nil {
+		u.Id = uuid.New()
+	}
+	return nil
+}
@@ -30,4 +30,28 @@ type UserSubmission struct {
 
 	// If you need to track current question index
 	CurrentQuestionIndex int `gorm:"default:0"`
+
+	// Questions asked in this submission, in order. Submissions started before
+	// question pools existed have none and go through every question.
+	DrawnQuestions []SubmissionQuestion `gorm:"foreignKey:UserSubmissionID"`
+
+	// Hidden variables supplied at start
+	Variables []SubmissionVariable `gorm:"foreignKey:UserSubmissionID"`
+}
+
+// SubmissionFilter narrows a list of submissions, zero fields match everything.
+// From and To bound the time the submission was started.
+type SubmissionFilter struct {
+	Status SubmissionStatus
+	From   *time.Time
+	To     *time.Time
+}
+
+// VariableMap returns the hidden variables of the submission by name.
+func (s *UserSubmission) VariableMap() map[string]string {
+	variables := make(map[string]string, len(s.Variables))
+	for _, variable := range s.Variables {
+		variables[variable.Name] = variable.Value
+	}
+	return variables
 }
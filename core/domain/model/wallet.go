@@ -0,0 +1,95 @@
+package model
+
+import (
+	"golizilla/internal/apperrors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type WalletTransactionType string
+
+const (
+	WalletTransactionTransfer WalletTransactionType = "transfer"
+	WalletTransactionTopUp    WalletTransactionType = "top_up"
+	WalletTransactionSlotSale WalletTransactionType = "slot_sale"
+	// balances users held before the ledger existed
+	WalletTransactionOpeningBalance WalletTransactionType = "opening_balance"
+)
+
+// System wallet accounts are not backed by a user. Their balance may go negative,
+// e.g. the payment gateway account mirrors all money that entered from outside.
+var (
+	WalletPaymentGatewayAccountID = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	// counterpart of the opening balances, it holds all money that predates the ledger
+	WalletOpeningBalanceAccountID = uuid.MustParse("00000000-0000-0000-0000-000000000002")
+)
+
+type WalletSystemAccount struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;"`
+	Name      string    `gorm:"unique;not null"`
+	Balance   int64     `gorm:"not null;default:0"`
+	UpdatedAt time.Time
+}
+
+// WalletTransaction groups the ledger entries of a single money movement.
+// The amounts of its entries always sum to zero.
+type WalletTransaction struct {
+	ID          uuid.UUID             `gorm:"type:uuid;primary_key;"`
+	Type        WalletTransactionType `gorm:"not null"`
+	Description string
+	CreatedAt   time.Time
+	Entries     []LedgerEntry `gorm:"foreignKey:TransactionID"`
+}
+
+// LedgerEntry is an immutable debit (negative amount) or credit (positive amount)
+// on a single wallet account. For users the account ID is the user ID.
+type LedgerEntry struct {
+	ID            uuid.UUID `gorm:"type:uuid;primary_key;"`
+	TransactionID uuid.UUID `gorm:"type:uuid;not null;index"` // FK to WalletTransaction
+	AccountID     uuid.UUID `gorm:"type:uuid;not null;index"`
+	Amount        int64     `gorm:"not null"`
+	BalanceAfter  int64     `gorm:"not null"`
+	CreatedAt     time.Time
+	Transaction   *WalletTransaction `gorm:"foreignKey:TransactionID"`
+}
+
+// WalletReconciliation reports an account whose cached balance differs from its ledger.
+type WalletReconciliation struct {
+	AccountID     uuid.UUID `json:"account_id"`
+	Username      string    `json:"username"`
+	WalletBalance int64     `json:"wallet_balance"`
+	LedgerBalance int64     `json:"ledger_balance"`
+	Difference    int64     `json:"difference"`
+}
+
+// WalletUnbalancedTransaction reports a transaction whose entries do not sum to zero.
+type WalletUnbalancedTransaction struct {
+	TransactionID uuid.UUID `json:"transaction_id"`
+	Sum           int64     `json:"sum"`
+}
+
+func (t *WalletTransaction) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	return nil
+}
+
+func (e *LedgerEntry) BeforeCreate(tx *gorm.DB) error {
+	if e.ID == uuid.Nil {
+		e.ID = uuid.New()
+	}
+	return nil
+}
+
+// BeforeUpdate keeps ledger entries append-only.
+func (e *LedgerEntry) BeforeUpdate(tx *gorm.DB) error {
+	return apperrors.ErrLedgerEntryImmutable
+}
+
+// BeforeDelete keeps ledger entries append-only.
+func (e *LedgerEntry) BeforeDelete(tx *gorm.DB) error {
+	return apperrors.ErrLedgerEntryImmutable
+}
@@ -0,0 +1,45 @@
+package gateway
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type PaymentStatus string
+
+const (
+	PaymentStatusSucceeded PaymentStatus = "succeeded"
+	PaymentStatusFailed    PaymentStatus = "failed"
+)
+
+// PaymentRequest asks the gateway to collect Amount for a top-up intent.
+type PaymentRequest struct {
+	IntentID    uuid.UUID
+	UserID      uuid.UUID
+	Amount      uint
+	CallbackURL string
+}
+
+// Payment is the gateway's answer to a PaymentRequest. The user completes the
+// payment at CheckoutURL; the outcome arrives later as a callback.
+type Payment struct {
+	Reference   string
+	CheckoutURL string
+}
+
+// PaymentCallback is a verified notification about the outcome of a payment.
+type PaymentCallback struct {
+	Reference string        `json:"reference"`
+	IntentID  uuid.UUID     `json:"intent_id"`
+	Amount    uint          `json:"amount"`
+	Status    PaymentStatus `json:"status"`
+}
+
+// IPaymentGateway is implemented by every payment provider adapter, so the wallet
+// service can switch providers without changes.
+type IPaymentGateway interface {
+	Name() string
+	CreatePayment(ctx context.Context, request PaymentRequest) (*Payment, error)
+	VerifyCallback(ctx context.Context, payload []byte, signature string) (*PaymentCallback, error)
+}
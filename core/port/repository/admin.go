@@ -153,10 +153,10 @@ func (r *AdminRepository) GetAnswersByUserIDAndQuestionnaireID(
 
     // Perform the query to get answers by UserID and QuestionnaireID
 	// Count total records for pagination info
-    err := db.WithContext(ctx).Preload("Questionnaire").
+    err := db.WithContext(ctx).Preload("Question").
 		Joins("JOIN questions ON answers.question_id = questions.id").
         Joins("JOIN questionnaires ON questions.questionnaire_id = questionnaires.id").
-        Where("answers.user_id = ? AND questionnaires.id = ?", userID, questionnaireID).
+        Where("answers.user_id = ? AND questionnaires.id = ? AND questions.deleted_at IS NULL AND questionnaires.deleted_at IS NULL", userID, questionnaireID).
         Count(&totalRecords).Error
     if err != nil {
         return nil, 0, err
@@ -166,10 +166,10 @@ func (r *AdminRepository) GetAnswersByUserIDAndQuestionnaireID(
 	offset := (page - 1) * pageSize
 
 	// Retrieve paginated records
-	err = db.WithContext(ctx).Preload("Questionnaire").
+	err = db.WithContext(ctx).Preload("Question").
 		Joins("JOIN questions ON answers.question_id = questions.id").
         Joins("JOIN questionnaires ON questions.questionnaire_id = questionnaires.id").
-        Where("answers.user_id = ? AND questionnaires.id = ?", userID, questionnaireID).
+        Where("answers.user_id = ? AND questionnaires.id = ? AND questions.deleted_at IS NULL AND questionnaires.deleted_at IS NULL", userID, questionnaireID).
 		Offset(offset).Limit(pageSize).Find(&answers).Error
 	return answers, totalRecords, err
 }
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+	myContext "golizilla/adapters/http/handler/context"
+	"golizilla/core/domain/model"
+
+	"gorm.io/gorm"
+)
+
+type IAdminAccessLogRepository interface {
+	Add(ctx context.Context, userCtx context.Context, accessLog *model.AdminAccessLog) error
+	GetPage(ctx context.Context, userCtx context.Context, page, pageSize int) ([]model.AdminAccessLog, int64, error)
+}
+
+type adminAccessLogRepository struct {
+	db *gorm.DB
+}
+
+func NewAdminAccessLogRepository(db *gorm.DB) IAdminAccessLogRepository {
+	return &adminAccessLogRepository{db: db}
+}
+
+func (r *adminAccessLogRepository) Add(ctx context.Context, userCtx context.Context, accessLog *model.AdminAccessLog) error {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+	return db.WithContext(ctx).Create(accessLog).Error
+}
+
+func (r *adminAccessLogRepository) GetPage(ctx context.Context, userCtx context.Context, page, pageSize int) ([]model.AdminAccessLog, int64, error) {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+
+	var accessLogs []model.AdminAccessLog
+	var totalRecords int64
+	if err := db.WithContext(ctx).Model(&model.AdminAccessLog{}).Count(&totalRecords).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * pageSize
+	err := db.WithContext(ctx).
+		Order("created_at DESC").
+		Offset(offset).Limit(pageSize).
+		Find(&accessLogs).Error
+	return accessLogs, totalRecords, err
+}
@@ -14,8 +14,10 @@ import (
 type IAnswerRepository interface {
 	Create(ctx context.Context, userCtx context.Context, answer *model.Answer) (uuid.UUID, error)
 	Update(ctx context.Context, userCtx context.Context, answer *model.Answer) error
+	Replace(ctx context.Context, userCtx context.Context, answer *model.Answer) error
 	Delete(ctx context.Context, userCtx context.Context, id uuid.UUID) error
 	GetByID(ctx context.Context, userCtx context.Context, id uuid.UUID) (*model.Answer, error)
+	GetBySubmissionAndQuestion(ctx context.Context, userCtx context.Context, submissionID, questionID uuid.UUID) (*model.Answer, error)
 }
 
 type AnswerRepository struct {
@@ -54,6 +56,10 @@ func (r *AnswerRepository) Create(ctx context.Context, userCtx context.Context,
 		// Update the existing record
 		existingAnswer.Descriptive = answer.Descriptive
 		existingAnswer.Text = answer.Text // Update any other fields as needed
+		existingAnswer.FilePath = answer.FilePath
+		existingAnswer.FileName = answer.FileName
+		existingAnswer.FileContentType = answer.FileContentType
+		existingAnswer.FileSize = answer.FileSize
 		result := db.WithContext(ctx).Save(&existingAnswer)
 		if result.Error != nil {
 			return uuid.Nil, fmt.Errorf("failed to update answer: %w", result.Error)
@@ -72,6 +78,26 @@ func (r *AnswerRepository) Update(ctx context.Context, userCtx context.Context,
 	return db.WithContext(ctx).Save(answer).Error
 }
 
+// Replace stores the answer in place of every answer already given to its
+// question in the submission. The old answers are removed for good, so they do
+// not show up in the trash.
+func (r *AnswerRepository) Replace(ctx context.Context, userCtx context.Context, answer *model.Answer) error {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+	err := db.WithContext(ctx).Unscoped().
+		Where("question_id = ? AND user_submission_id = ?", answer.QuestionID, answer.UserSubmissionID).
+		Delete(&model.Answer{}).Error
+	if err != nil {
+		return fmt.Errorf("failed to delete previous answers: %w", err)
+	}
+	if err := db.WithContext(ctx).Create(answer).Error; err != nil {
+		return fmt.Errorf("failed to create answer: %w", err)
+	}
+	return nil
+}
+
 func (r *AnswerRepository) Delete(ctx context.Context, userCtx context.Context, id uuid.UUID) error {
 	var db *gorm.DB
 	if db = myContext.GetDB(userCtx); db == nil {
@@ -92,3 +118,16 @@ func (r *AnswerRepository) GetByID(ctx context.Context, userCtx context.Context,
 	}
 	return &answer, nil
 }
+
+func (r *AnswerRepository) GetBySubmissionAndQuestion(ctx context.Context, userCtx context.Context, submissionID, questionID uuid.UUID) (*model.Answer, error) {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+	var answer model.Answer
+	err := db.WithContext(ctx).Where("user_submission_id = ? AND question_id = ?", submissionID, questionID).First(&answer).Error
+	if err != nil {
+		return nil, err
+	}
+	return &answer, nil
+}
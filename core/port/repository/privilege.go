@@ -12,6 +12,7 @@ import (
 type IPrivilegeRepository interface {
 	Add(ctx context.Context, userCtx context.Context, privilege *model.Privilege) error
 	GetById(ctx context.Context, userCtx context.Context, id uuid.UUID) (*model.Privilege, error)
+	GetAll(ctx context.Context, userCtx context.Context) ([]model.Privilege, error)
 }
 
 type privilgeRepository struct {
@@ -47,3 +48,13 @@ func (r *privilgeRepository) GetById(ctx context.Context, userCtx context.Contex
 
 	return &privilege, err
 }
+
+func (r *privilgeRepository) GetAll(ctx context.Context, userCtx context.Context) ([]model.Privilege, error) {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+	var privileges []model.Privilege
+	err := db.WithContext(ctx).Order("id ASC").Find(&privileges).Error
+	return privileges, err
+}
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	myContext "golizilla/adapters/http/handler/context"
+	"golizilla/core/domain/model"
+
+	"gorm.io/gorm"
+)
+
+type IPrivilegeAuditRepository interface {
+	Add(ctx context.Context, userCtx context.Context, auditLogs ...*model.PrivilegeAuditLog) error
+	GetPage(ctx context.Context, userCtx context.Context, filter model.PrivilegeAuditFilter, page, pageSize int) ([]model.PrivilegeAuditLog, int64, error)
+	GetAll(ctx context.Context, userCtx context.Context, filter model.PrivilegeAuditFilter) ([]model.PrivilegeAuditLog, error)
+}
+
+type privilegeAuditRepository struct {
+	db *gorm.DB
+}
+
+func NewPrivilegeAuditRepository(db *gorm.DB) IPrivilegeAuditRepository {
+	return &privilegeAuditRepository{db: db}
+}
+
+func (r *privilegeAuditRepository) Add(ctx context.Context, userCtx context.Context, auditLogs ...*model.PrivilegeAuditLog) error {
+	if len(auditLogs) == 0 {
+		return nil
+	}
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+	return db.WithContext(ctx).Create(auditLogs).Error
+}
+
+func (r *privilegeAuditRepository) GetPage(ctx context.Context, userCtx context.Context, filter model.PrivilegeAuditFilter, page, pageSize int) ([]model.PrivilegeAuditLog, int64, error) {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+
+	query := applyPrivilegeAuditFilter(db.WithContext(ctx).Model(&model.PrivilegeAuditLog{}), filter)
+
+	var auditLogs []model.PrivilegeAuditLog
+	var totalRecords int64
+	if err := query.Session(&gorm.Session{}).Count(&totalRecords).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * pageSize
+	err := query.
+		Order("created_at DESC").
+		Offset(offset).Limit(pageSize).
+		Find(&auditLogs).Error
+	return auditLogs, totalRecords, err
+}
+
+// GetAll returns every matching audit log, oldest first, for exports.
+func (r *privilegeAuditRepository) GetAll(ctx context.Context, userCtx context.Context, filter model.PrivilegeAuditFilter) ([]model.PrivilegeAuditLog, error) {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+	var auditLogs []model.PrivilegeAuditLog
+	err := applyPrivilegeAuditFilter(db.WithContext(ctx), filter).
+		Order("created_at ASC").
+		Find(&auditLogs).Error
+	return auditLogs, err
+}
+
+func applyPrivilegeAuditFilter(query *gorm.DB, filter model.PrivilegeAuditFilter) *gorm.DB {
+	if filter.QuestionnaireID != nil {
+		query = query.Where("questionnaire_id = ?", *filter.QuestionnaireID)
+	}
+	if filter.ActorID != nil {
+		query = query.Where("actor_id = ?", *filter.ActorID)
+	}
+	if filter.TargetUserID != nil {
+		query = query.Where("target_user_id = ?", *filter.TargetUserID)
+	}
+	if filter.TargetRoleID != nil {
+		query = query.Where("target_role_id = ?", *filter.TargetRoleID)
+	}
+	if filter.Privilege != "" {
+		query = query.Where("privilege = ?", filter.Privilege)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at <= ?", *filter.To)
+	}
+	return query
+}
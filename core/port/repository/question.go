@@ -19,6 +19,11 @@ type IQuestionRepository interface {
 	GetByID(ctx context.Context, userCtx context.Context, id uuid.UUID) (*model.Question, error)
 	GetByQuestionnaireID(ctx context.Context, userCtx context.Context, questionnaireID uuid.UUID) ([]*model.Question, error)
 	GetFullByQuestionnaireID(ctx context.Context, userCtx context.Context, questionnaireID uuid.UUID) ([]*model.Question, error)
+	UpdateMetaData(ctx context.Context, userCtx context.Context, id uuid.UUID, path string, contentType string, size int64) error
+	UpdateAnswerRules(ctx context.Context, userCtx context.Context, id uuid.UUID, rules model.AnswerRules) error
+	UpdateTimeLimit(ctx context.Context, userCtx context.Context, id uuid.UUID, limit uint, action string) error
+	UpdateClassification(ctx context.Context, userCtx context.Context, id uuid.UUID, tag, difficulty string) error
+	UpdateRequired(ctx context.Context, userCtx context.Context, id uuid.UUID, required bool) error
 }
 
 type QuestionRepository struct {
@@ -102,3 +107,63 @@ func (r *QuestionRepository) GetFullByQuestionnaireID(ctx context.Context, userC
 
 	return questions, nil
 }
+
+// UpdateMetaData sets the media fields explicitly, so they can also be cleared.
+func (r *QuestionRepository) UpdateMetaData(ctx context.Context, userCtx context.Context, id uuid.UUID, path string, contentType string, size int64) error {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+	return db.WithContext(ctx).Model(&model.Question{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"meta_data_path":         path,
+		"meta_data_content_type": contentType,
+		"meta_data_size":         size,
+	}).Error
+}
+
+// UpdateAnswerRules replaces every answer rule, so rules can also be removed.
+func (r *QuestionRepository) UpdateAnswerRules(ctx context.Context, userCtx context.Context, id uuid.UUID, rules model.AnswerRules) error {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+	return db.WithContext(ctx).Model(&model.Question{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"answer_min_length": rules.MinLength,
+		"answer_max_length": rules.MaxLength,
+		"answer_pattern":    rules.Pattern,
+		"answer_format":     rules.Format,
+		"answer_min_value":  rules.MinValue,
+		"answer_max_value":  rules.MaxValue,
+		"answer_charset":    rules.Charset,
+	}).Error
+}
+
+func (r *QuestionRepository) UpdateTimeLimit(ctx context.Context, userCtx context.Context, id uuid.UUID, limit uint, action string) error {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+	return db.WithContext(ctx).Model(&model.Question{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"time_limit":        limit,
+		"time_limit_action": action,
+	}).Error
+}
+
+func (r *QuestionRepository) UpdateClassification(ctx context.Context, userCtx context.Context, id uuid.UUID, tag, difficulty string) error {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+	return db.WithContext(ctx).Model(&model.Question{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"tag":        tag,
+		"difficulty": difficulty,
+	}).Error
+}
+
+func (r *QuestionRepository) UpdateRequired(ctx context.Context, userCtx context.Context, id uuid.UUID, required bool) error {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+	return db.WithContext(ctx).Model(&model.Question{}).Where("id = ?", id).Update("required", required).Error
+}
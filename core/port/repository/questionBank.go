@@ -0,0 +1,194 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	myContext "golizilla/adapters/http/handler/context"
+	"golizilla/adapters/persistence/logger"
+	"golizilla/core/domain/model"
+	"golizilla/internal/apperrors"
+	"golizilla/internal/logmessages"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type IQuestionBankRepository interface {
+	Create(ctx context.Context, userCtx context.Context, bankQuestion *model.BankQuestion) error
+	Update(ctx context.Context, userCtx context.Context, bankQuestion *model.BankQuestion) error
+	Delete(ctx context.Context, userCtx context.Context, id uuid.UUID) error
+	GetByID(ctx context.Context, userCtx context.Context, id uuid.UUID) (*model.BankQuestion, error)
+	GetVisible(ctx context.Context, userCtx context.Context, userID uuid.UUID, page, pageSize int) ([]model.BankQuestion, int64, error)
+	ApplyToQuestion(ctx context.Context, userCtx context.Context, question *model.Question, removedOptionIDs []uuid.UUID) error
+	GetUsage(ctx context.Context, userCtx context.Context, bankQuestionID uuid.UUID) ([]model.BankQuestionUsage, error)
+	GetOptionCounts(ctx context.Context, userCtx context.Context, bankQuestionID uuid.UUID) ([]model.BankOptionCount, error)
+}
+
+type questionBankRepository struct {
+	db *gorm.DB
+}
+
+func NewQuestionBankRepository(db *gorm.DB) IQuestionBankRepository {
+	return &questionBankRepository{db: db}
+}
+
+func (r *questionBankRepository) Create(ctx context.Context, userCtx context.Context, bankQuestion *model.BankQuestion) error {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+	return db.WithContext(ctx).Create(bankQuestion).Error
+}
+
+// Update saves the bank question and its options. Options keep their IDs, options
+// missing from bankQuestion.Options are removed.
+func (r *questionBankRepository) Update(ctx context.Context, userCtx context.Context, bankQuestion *model.BankQuestion) error {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Options").Save(bankQuestion).Error; err != nil {
+			return err
+		}
+
+		keep := []uuid.UUID{uuid.Nil}
+		for i := range bankQuestion.Options {
+			bankQuestion.Options[i].BankQuestionID = bankQuestion.ID
+			if err := tx.Save(&bankQuestion.Options[i]).Error; err != nil {
+				return err
+			}
+			keep = append(keep, bankQuestion.Options[i].ID)
+		}
+		return tx.Where("bank_question_id = ? AND id NOT IN ?", bankQuestion.ID, keep).
+			Delete(&model.BankOption{}).Error
+	})
+}
+
+func (r *questionBankRepository) Delete(ctx context.Context, userCtx context.Context, id uuid.UUID) error {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("bank_question_id = ?", id).Delete(&model.BankOption{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&model.BankQuestion{}, "id = ?", id).Error
+	})
+}
+
+func (r *questionBankRepository) GetByID(ctx context.Context, userCtx context.Context, id uuid.UUID) (*model.BankQuestion, error) {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+	var bankQuestion model.BankQuestion
+	err := db.WithContext(ctx).
+		Preload("Options", func(db *gorm.DB) *gorm.DB { return db.Order("index ASC") }).
+		First(&bankQuestion, "id = ?", id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrBankQuestionNotFound
+		}
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogQuestionBankRepository,
+			Message: err.Error(),
+		})
+		return nil, err
+	}
+	return &bankQuestion, nil
+}
+
+// GetVisible returns the personal entries of the user and every organisation-wide entry.
+func (r *questionBankRepository) GetVisible(ctx context.Context, userCtx context.Context, userID uuid.UUID, page, pageSize int) ([]model.BankQuestion, int64, error) {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+	var bankQuestions []model.BankQuestion
+	var totalRecords int64
+
+	query := db.WithContext(ctx).Model(&model.BankQuestion{}).
+		Where("owner_id = ? OR scope = ?", userID, model.BankQuestionScopeOrganization)
+	if err := query.Count(&totalRecords).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * pageSize
+	err := query.
+		Preload("Options", func(db *gorm.DB) *gorm.DB { return db.Order("index ASC") }).
+		Order("created_at DESC").
+		Offset(offset).
+		Limit(pageSize).
+		Find(&bankQuestions).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return bankQuestions, totalRecords, nil
+}
+
+// ApplyToQuestion writes the bank fields of a linked question and its options.
+// Removed options that already have answers are kept so the answers stay valid.
+func (r *questionBankRepository) ApplyToQuestion(ctx context.Context, userCtx context.Context, question *model.Question, removedOptionIDs []uuid.UUID) error {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&model.Question{}).Where("id = ?", question.ID).Updates(map[string]interface{}{
+			"question_text": question.QuestionText,
+			"descriptive":   question.Descriptive,
+			"bank_version":  question.BankVersion,
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		for i := range question.Options {
+			question.Options[i].QuestionID = question.ID
+			if err := tx.Save(&question.Options[i]).Error; err != nil {
+				return err
+			}
+		}
+
+		if len(removedOptionIDs) == 0 {
+			return nil
+		}
+		return tx.Where("id IN ? AND NOT EXISTS (SELECT 1 FROM answers WHERE answers.option_id = options.id)", removedOptionIDs).
+			Delete(&model.Option{}).Error
+	})
+}
+
+func (r *questionBankRepository) GetUsage(ctx context.Context, userCtx context.Context, bankQuestionID uuid.UUID) ([]model.BankQuestionUsage, error) {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+	var usage []model.BankQuestionUsage
+	err := db.WithContext(ctx).
+		Model(&model.Question{}).
+		Select("questions.questionnaire_id, questions.id AS question_id, questions.bank_linked, questions.bank_version, COUNT(answers.id) AS answers").
+		Joins("LEFT JOIN answers ON answers.question_id = questions.id AND answers.deleted_at IS NULL").
+		Where("questions.bank_question_id = ?", bankQuestionID).
+		Group("questions.questionnaire_id, questions.id, questions.bank_linked, questions.bank_version").
+		Scan(&usage).Error
+	return usage, err
+}
+
+func (r *questionBankRepository) GetOptionCounts(ctx context.Context, userCtx context.Context, bankQuestionID uuid.UUID) ([]model.BankOptionCount, error) {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+	var counts []model.BankOptionCount
+	err := db.WithContext(ctx).
+		Model(&model.Answer{}).
+		Select("questions.questionnaire_id, options.bank_option_id, COUNT(answers.id) AS count").
+		Joins("JOIN options ON options.id = answers.option_id").
+		Joins("JOIN questions ON questions.id = answers.question_id AND questions.deleted_at IS NULL").
+		Where("questions.bank_question_id = ? AND options.bank_option_id IS NOT NULL", bankQuestionID).
+		Group("questions.questionnaire_id, options.bank_option_id").
+		Scan(&counts).Error
+	return counts, err
+}
@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	myContext "golizilla/adapters/http/handler/context"
+	"golizilla/adapters/persistence/logger"
+	"golizilla/core/domain/model"
+	"golizilla/internal/apperrors"
+	"golizilla/internal/logmessages"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type IQuestionPoolRepository interface {
+	Create(ctx context.Context, userCtx context.Context, pool *model.QuestionPool, questionIDs []uuid.UUID) error
+	Update(ctx context.Context, userCtx context.Context, pool *model.QuestionPool, questionIDs []uuid.UUID) error
+	Delete(ctx context.Context, userCtx context.Context, id uuid.UUID) error
+	GetByID(ctx context.Context, userCtx context.Context, id uuid.UUID) (*model.QuestionPool, error)
+	GetByQuestionnaireID(ctx context.Context, userCtx context.Context, questionnaireID uuid.UUID) ([]model.QuestionPool, error)
+}
+
+type questionPoolRepository struct {
+	db *gorm.DB
+}
+
+func NewQuestionPoolRepository(db *gorm.DB) IQuestionPoolRepository {
+	return &questionPoolRepository{db: db}
+}
+
+func (r *questionPoolRepository) Create(ctx context.Context, userCtx context.Context, pool *model.QuestionPool, questionIDs []uuid.UUID) error {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Questions").Create(pool).Error; err != nil {
+			return err
+		}
+		return setPoolQuestions(tx, pool, questionIDs)
+	})
+}
+
+// Update saves the pool and replaces its questions when questionIDs is not nil.
+func (r *questionPoolRepository) Update(ctx context.Context, userCtx context.Context, pool *model.QuestionPool, questionIDs []uuid.UUID) error {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Questions").Save(pool).Error; err != nil {
+			return err
+		}
+		if questionIDs == nil {
+			return nil
+		}
+		return setPoolQuestions(tx, pool, questionIDs)
+	})
+}
+
+// Delete removes the pool, its questions stay in the questionnaire and are asked to
+// every respondent again.
+func (r *questionPoolRepository) Delete(ctx context.Context, userCtx context.Context, id uuid.UUID) error {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.Question{}).Where("pool_id = ?", id).Update("pool_id", nil).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&model.QuestionPool{}, "id = ?", id).Error
+	})
+}
+
+func (r *questionPoolRepository) GetByID(ctx context.Context, userCtx context.Context, id uuid.UUID) (*model.QuestionPool, error) {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+	var pool model.QuestionPool
+	err := db.WithContext(ctx).
+		Preload("Questions", func(db *gorm.DB) *gorm.DB { return db.Order("index ASC") }).
+		First(&pool, "id = ?", id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrQuestionPoolNotFound
+		}
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogQuestionPoolRepository,
+			Message: err.Error(),
+		})
+		return nil, err
+	}
+	return &pool, nil
+}
+
+func (r *questionPoolRepository) GetByQuestionnaireID(ctx context.Context, userCtx context.Context, questionnaireID uuid.UUID) ([]model.QuestionPool, error) {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+	var pools []model.QuestionPool
+	err := db.WithContext(ctx).
+		Where("questionnaire_id = ?", questionnaireID).
+		Preload("Questions", func(db *gorm.DB) *gorm.DB { return db.Order("index ASC") }).
+		Order("created_at ASC").
+		Find(&pools).Error
+	return pools, err
+}
+
+// setPoolQuestions moves the given questions into the pool and takes every other
+// question out of it. Questions may only come from the questionnaire of the pool.
+func setPoolQuestions(tx *gorm.DB, pool *model.QuestionPool, questionIDs []uuid.UUID) error {
+	if err := tx.Model(&model.Question{}).Where("pool_id = ?", pool.ID).Update("pool_id", nil).Error; err != nil {
+		return err
+	}
+	if len(questionIDs) == 0 {
+		return nil
+	}
+	result := tx.Model(&model.Question{}).
+		Where("id IN ? AND questionnaire_id = ?", questionIDs, pool.QuestionnaireId).
+		Update("pool_id", pool.ID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected != int64(len(questionIDs)) {
+		return apperrors.ErrQuestionNotInQuestionnaire
+	}
+	return nil
+}
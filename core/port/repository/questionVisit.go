@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+	myContext "golizilla/adapters/http/handler/context"
+	"golizilla/core/domain/model"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type IQuestionVisitRepository interface {
+	Open(ctx context.Context, userCtx context.Context, visit *model.QuestionVisit) error
+	CloseOpen(ctx context.Context, userCtx context.Context, submissionID uuid.UUID, at time.Time) error
+	MarkAnswered(ctx context.Context, userCtx context.Context, submissionID, questionID uuid.UUID, at time.Time) error
+	GetSpent(ctx context.Context, userCtx context.Context, submissionID, questionID uuid.UUID, now time.Time) (time.Duration, error)
+	GetDwellByQuestionnaireID(ctx context.Context, userCtx context.Context, questionnaireID uuid.UUID) ([]model.QuestionDwell, error)
+	GetVisitedQuestionIDs(ctx context.Context, userCtx context.Context, submissionID uuid.UUID) ([]uuid.UUID, error)
+}
+
+type questionVisitRepository struct {
+	db *gorm.DB
+}
+
+func NewQuestionVisitRepository(db *gorm.DB) IQuestionVisitRepository {
+	return &questionVisitRepository{db: db}
+}
+
+func (r *questionVisitRepository) Open(ctx context.Context, userCtx context.Context, visit *model.QuestionVisit) error {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+	return db.WithContext(ctx).Create(visit).Error
+}
+
+// CloseOpen ends the visit the submission is currently on, if any.
+func (r *questionVisitRepository) CloseOpen(ctx context.Context, userCtx context.Context, submissionID uuid.UUID, at time.Time) error {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+	var visits []model.QuestionVisit
+	err := db.WithContext(ctx).
+		Where("user_submission_id = ? AND left_at IS NULL", submissionID).
+		Find(&visits).Error
+	if err != nil {
+		return err
+	}
+
+	for _, visit := range visits {
+		err := db.WithContext(ctx).Model(&model.QuestionVisit{}).Where("id = ?", visit.ID).Updates(map[string]interface{}{
+			"left_at":     at,
+			"duration_ms": at.Sub(visit.EnteredAt).Milliseconds(),
+		}).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *questionVisitRepository) MarkAnswered(ctx context.Context, userCtx context.Context, submissionID, questionID uuid.UUID, at time.Time) error {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+	return db.WithContext(ctx).
+		Model(&model.QuestionVisit{}).
+		Where("user_submission_id = ? AND question_id = ? AND left_at IS NULL", submissionID, questionID).
+		Update("answered_at", at).Error
+}
+
+// GetSpent returns the time the submission spent on a question so far, including
+// the visit that is still open.
+func (r *questionVisitRepository) GetSpent(ctx context.Context, userCtx context.Context, submissionID, questionID uuid.UUID, now time.Time) (time.Duration, error) {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+	var visits []model.QuestionVisit
+	err := db.WithContext(ctx).
+		Where("user_submission_id = ? AND question_id = ?", submissionID, questionID).
+		Find(&visits).Error
+	if err != nil {
+		return 0, err
+	}
+
+	var spent time.Duration
+	for _, visit := range visits {
+		if visit.LeftAt == nil {
+			spent += now.Sub(visit.EnteredAt)
+		} else {
+			spent += time.Duration(visit.DurationMs) * time.Millisecond
+		}
+	}
+	return spent, nil
+}
+
+// GetDwellByQuestionnaireID sums the closed visits of every submission per question.
+func (r *questionVisitRepository) GetDwellByQuestionnaireID(ctx context.Context, userCtx context.Context, questionnaireID uuid.UUID) ([]model.QuestionDwell, error) {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+	var dwell []model.QuestionDwell
+	err := db.WithContext(ctx).
+		Model(&model.QuestionVisit{}).
+		Select("user_submission_id, question_id, SUM(duration_ms) AS duration_ms, BOOL_OR(answered_at IS NOT NULL) AS answered").
+		Where("questionnaire_id = ? AND left_at IS NOT NULL", questionnaireID).
+		Group("user_submission_id, question_id").
+		Scan(&dwell).Error
+	return dwell, err
+}
+
+// GetVisitedQuestionIDs returns every question the submission has been shown.
+func (r *questionVisitRepository) GetVisitedQuestionIDs(ctx context.Context, userCtx context.Context, submissionID uuid.UUID) ([]uuid.UUID, error) {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+	var ids []uuid.UUID
+	err := db.WithContext(ctx).
+		Model(&model.QuestionVisit{}).
+		Distinct("question_id").
+		Where("user_submission_id = ?", submissionID).
+		Pluck("question_id", &ids).Error
+	return ids, err
+}
@@ -14,6 +14,12 @@ type IRoleRepository interface {
 	Delete(ctx context.Context, userCtx context.Context, id uuid.UUID) error
 	Update(ctx context.Context, userCtx context.Context, role *model.Role) error
 	GetById(ctx context.Context, userCtx context.Context, id uuid.UUID) (*model.Role, error)
+	DeleteWithGrants(ctx context.Context, userCtx context.Context, id uuid.UUID) error
+	GetMembers(ctx context.Context, userCtx context.Context, id uuid.UUID, page, pageSize int) ([]model.User, int64, error)
+	CountMembers(ctx context.Context, userCtx context.Context, id uuid.UUID) (int64, error)
+	GetMemberIDs(ctx context.Context, userCtx context.Context, id uuid.UUID) ([]uuid.UUID, error)
+	UpdateParent(ctx context.Context, userCtx context.Context, id uuid.UUID, parentId *uuid.UUID) error
+	CountChildren(ctx context.Context, userCtx context.Context, id uuid.UUID) (int64, error)
 }
 
 type roleRepository struct {
@@ -66,10 +72,92 @@ func (r *roleRepository) GetById(ctx context.Context, userCtx context.Context, i
 		db = r.db
 	}
 	var role model.Role
-	err := db.WithContext(ctx).Find(&role, id).Error
+	err := db.WithContext(ctx).Where("id = ?", id).First(&role).Error
 	if err != nil {
-		//log
+		return nil, err
 	}
 
-	return &role, err
+	return &role, nil
+}
+
+// DeleteWithGrants deletes the role together with its global and instance
+// privileges. The role must not have members anymore.
+func (r *roleRepository) DeleteWithGrants(ctx context.Context, userCtx context.Context, id uuid.UUID) error {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("role_id = ?", id).Delete(&model.RolePrivilege{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("role_id = ?", id).Delete(&model.RolePrivilegeOnInstance{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", id).Delete(&model.Role{}).Error
+	})
+}
+
+func (r *roleRepository) GetMembers(ctx context.Context, userCtx context.Context, id uuid.UUID, page, pageSize int) ([]model.User, int64, error) {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+
+	query := db.WithContext(ctx).Model(&model.User{}).Where("role_id = ? AND anonymized_at IS NULL", id)
+
+	var users []model.User
+	var totalRecords int64
+	if err := query.Session(&gorm.Session{}).Count(&totalRecords).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * pageSize
+	err := query.
+		Order("username").
+		Offset(offset).Limit(pageSize).
+		Find(&users).Error
+	return users, totalRecords, err
+}
+
+func (r *roleRepository) CountMembers(ctx context.Context, userCtx context.Context, id uuid.UUID) (int64, error) {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+	var count int64
+	err := db.WithContext(ctx).Model(&model.User{}).Where("role_id = ?", id).Count(&count).Error
+	return count, err
+}
+
+// GetMemberIDs returns the IDs of the role's users, for notifying them.
+func (r *roleRepository) GetMemberIDs(ctx context.Context, userCtx context.Context, id uuid.UUID) ([]uuid.UUID, error) {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+	var ids []uuid.UUID
+	err := db.WithContext(ctx).Model(&model.User{}).
+		Where("role_id = ? AND anonymized_at IS NULL", id).
+		Pluck("id", &ids).Error
+	return ids, err
+}
+
+// UpdateParent sets the role the role inherits from, nil makes it a root role.
+func (r *roleRepository) UpdateParent(ctx context.Context, userCtx context.Context, id uuid.UUID, parentId *uuid.UUID) error {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+	return db.WithContext(ctx).Model(&model.Role{}).Where("id = ?", id).Update("parent_id", parentId).Error
+}
+
+func (r *roleRepository) CountChildren(ctx context.Context, userCtx context.Context, id uuid.UUID) (int64, error) {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+	var count int64
+	err := db.WithContext(ctx).Model(&model.Role{}).Where("parent_id = ?", id).Count(&count).Error
+	return count, err
 }
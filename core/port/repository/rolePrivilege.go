@@ -15,6 +15,7 @@ type IRolePrivilegeRepository interface {
 	Delete(ctx context.Context, userCtx context.Context, roleId uuid.UUID, privilegeId string) error
 	GetRolePrivileges(ctx context.Context, userCtx context.Context, roleId uuid.UUID) ([]model.RolePrivilege, error)
 	GetRolePrivilegesByPrivileges(ctx context.Context, userCtx context.Context, roleId uuid.UUID, privileges ...string) ([]model.RolePrivilege, error)
+	GetRolesPrivileges(ctx context.Context, userCtx context.Context, roleIds ...uuid.UUID) ([]model.RolePrivilege, error)
 }
 
 type rolePrivilege struct {
@@ -87,3 +88,17 @@ func (r *rolePrivilege) GetRolePrivilegesByPrivileges(ctx context.Context, userC
 
 	return rolePrivileges, err
 }
+
+// GetRolesPrivileges returns the global privileges of all the roles.
+func (r *rolePrivilege) GetRolesPrivileges(ctx context.Context, userCtx context.Context, roleIds ...uuid.UUID) ([]model.RolePrivilege, error) {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+	var rolePrivileges []model.RolePrivilege
+	if len(roleIds) == 0 {
+		return nil, nil
+	}
+	err := db.WithContext(ctx).Where("role_id IN ?", roleIds).Find(&rolePrivileges).Error
+	return rolePrivileges, err
+}
@@ -16,7 +16,7 @@ type IRolePrivilegeOnInstanceRepository interface {
 	Add(ctx context.Context, userCtx context.Context, rolePrivelegeOnInsance *model.RolePrivilegeOnInstance) error
 	Delete(ctx context.Context, userCtx context.Context, roleId uuid.UUID, privilegeId string, questionnaireId uuid.UUID) error
 	GetRolePrivilegesOnInstance(ctx context.Context, userCtx context.Context, roleId uuid.UUID) ([]model.RolePrivilegeOnInstance, error)
-	HasPrivilegesOnInsance(ctx context.Context, userCtx context.Context, roleId uuid.UUID, questionnariId uuid.UUID, privileges ...string) (bool, error)
+	HasPrivilegesOnInsance(ctx context.Context, userCtx context.Context, roleIds []uuid.UUID, questionnariId uuid.UUID, privileges ...string) (bool, error)
 }
 
 type rolePrivilegeOnInstanceRepository struct {
@@ -70,10 +70,12 @@ func (r *rolePrivilegeOnInstanceRepository) GetRolePrivilegesOnInstance(ctx cont
 	return rolePrivilegeOnInstance, err
 }
 
+// HasPrivilegesOnInsance reports whether any of the roles holds any of the
+// privileges on the questionnaire.
 func (r *rolePrivilegeOnInstanceRepository) HasPrivilegesOnInsance(
 	ctx context.Context,
 	userCtx context.Context,
-	roleId uuid.UUID,
+	roleIds []uuid.UUID,
 	questionnariId uuid.UUID,
 	privileges ...string) (bool, error) {
 	var db *gorm.DB
@@ -82,7 +84,7 @@ func (r *rolePrivilegeOnInstanceRepository) HasPrivilegesOnInsance(
 	}
 	var rolePrivilegeOnInstance []model.RolePrivilegeOnInstance
 	// Query matching both role_id and privilege_id
-	result := db.WithContext(ctx).Where("role_id = ? AND privilege_id IN (?) AND questionnaire_id = ?", roleId, privileges, questionnariId).Find(&rolePrivilegeOnInstance)
+	result := db.WithContext(ctx).Where("role_id IN ? AND privilege_id IN (?) AND questionnaire_id = ?", roleIds, privileges, questionnariId).Find(&rolePrivilegeOnInstance)
 
 	if result.Error != nil {
 		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
@@ -93,7 +95,7 @@ func (r *rolePrivilegeOnInstanceRepository) HasPrivilegesOnInsance(
 	}
 	logger.GetLogger().LogInfoFromContext(ctx, logger.LogFields{
 		Service: logmessages.LogRolePrivilegeOnInstance,
-		Message: fmt.Sprintf("rows=%d role_id=%s privileges=%s q_id=%s", result.RowsAffected, roleId, privileges[0], questionnariId),
+		Message: fmt.Sprintf("rows=%d role_ids=%v privileges=%s q_id=%s", result.RowsAffected, roleIds, privileges[0], questionnariId),
 	})
 
 	return result.RowsAffected > 0, result.Error
@@ -0,0 +1,157 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	myContext "golizilla/adapters/http/handler/context"
+	"golizilla/adapters/persistence/logger"
+	"golizilla/core/domain/model"
+	"golizilla/internal/apperrors"
+	"golizilla/internal/logmessages"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ISectionRepository interface {
+	Create(ctx context.Context, userCtx context.Context, section *model.Section, questionIDs []uuid.UUID) error
+	Update(ctx context.Context, userCtx context.Context, section *model.Section, questionIDs []uuid.UUID) error
+	Delete(ctx context.Context, userCtx context.Context, id uuid.UUID) error
+	GetByID(ctx context.Context, userCtx context.Context, id uuid.UUID) (*model.Section, error)
+	GetByQuestionnaireID(ctx context.Context, userCtx context.Context, questionnaireID uuid.UUID) ([]model.Section, error)
+	SetOptionJumps(ctx context.Context, userCtx context.Context, questionID uuid.UUID, jumps map[uuid.UUID]*uuid.UUID) error
+}
+
+type sectionRepository struct {
+	db *gorm.DB
+}
+
+func NewSectionRepository(db *gorm.DB) ISectionRepository {
+	return &sectionRepository{db: db}
+}
+
+func (r *sectionRepository) Create(ctx context.Context, userCtx context.Context, section *model.Section, questionIDs []uuid.UUID) error {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Questions").Create(section).Error; err != nil {
+			return err
+		}
+		return setSectionQuestions(tx, section, questionIDs)
+	})
+}
+
+// Update saves the section and replaces its questions.
+func (r *sectionRepository) Update(ctx context.Context, userCtx context.Context, section *model.Section, questionIDs []uuid.UUID) error {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Questions").Save(section).Error; err != nil {
+			return err
+		}
+		return setSectionQuestions(tx, section, questionIDs)
+	})
+}
+
+// Delete removes the section, its questions go back to a page each and options
+// jumping to it no longer jump.
+func (r *sectionRepository) Delete(ctx context.Context, userCtx context.Context, id uuid.UUID) error {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.Question{}).Where("section_id = ?", id).Update("section_id", nil).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&model.Option{}).Where("jump_section_id = ?", id).Update("jump_section_id", nil).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&model.Section{}, "id = ?", id).Error
+	})
+}
+
+func (r *sectionRepository) GetByID(ctx context.Context, userCtx context.Context, id uuid.UUID) (*model.Section, error) {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+	var section model.Section
+	err := db.WithContext(ctx).
+		Preload("Questions", func(db *gorm.DB) *gorm.DB { return db.Order("index ASC") }).
+		First(&section, "id = ?", id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrSectionNotFound
+		}
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogSectionRepository,
+			Message: err.Error(),
+		})
+		return nil, err
+	}
+	return &section, nil
+}
+
+func (r *sectionRepository) GetByQuestionnaireID(ctx context.Context, userCtx context.Context, questionnaireID uuid.UUID) ([]model.Section, error) {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+	var sections []model.Section
+	err := db.WithContext(ctx).
+		Where("questionnaire_id = ?", questionnaireID).
+		Preload("Questions", func(db *gorm.DB) *gorm.DB { return db.Order("index ASC") }).
+		Order("created_at ASC").
+		Find(&sections).Error
+	return sections, err
+}
+
+// SetOptionJumps sets the section each option of the question jumps to, a nil
+// section removes the jump. Options missing from jumps are left alone.
+func (r *sectionRepository) SetOptionJumps(ctx context.Context, userCtx context.Context, questionID uuid.UUID, jumps map[uuid.UUID]*uuid.UUID) error {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for optionID, sectionID := range jumps {
+			result := tx.Model(&model.Option{}).
+				Where("id = ? AND question_id = ?", optionID, questionID).
+				Update("jump_section_id", sectionID)
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return apperrors.ErrOptionNotFound
+			}
+		}
+		return nil
+	})
+}
+
+// setSectionQuestions moves the given questions into the section and takes every
+// other question out of it. Questions may only come from the questionnaire of the
+// section.
+func setSectionQuestions(tx *gorm.DB, section *model.Section, questionIDs []uuid.UUID) error {
+	if err := tx.Model(&model.Question{}).Where("section_id = ?", section.ID).Update("section_id", nil).Error; err != nil {
+		return err
+	}
+	if len(questionIDs) == 0 {
+		return nil
+	}
+	result := tx.Model(&model.Question{}).
+		Where("id IN ? AND questionnaire_id = ?", questionIDs, section.QuestionnaireId).
+		Update("section_id", section.ID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected != int64(len(questionIDs)) {
+		return apperrors.ErrQuestionNotInQuestionnaire
+	}
+	return nil
+}
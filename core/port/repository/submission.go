@@ -2,13 +2,16 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	appContext "golizilla/adapters/http/handler/context"
 	"golizilla/adapters/persistence/logger"
 	"golizilla/core/domain/model"
+	"golizilla/internal/apperrors"
 	"golizilla/internal/logmessages"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
 	"gorm.io/gorm"
 )
 
@@ -18,6 +21,13 @@ type ISubmissionRepository interface {
 	UpdateSubmission(ctx context.Context, userCtx context.Context, submission *model.UserSubmission) error
 	GetActiveSubmissionByUserIDAndQuestionnaire(ctx context.Context, userCtx context.Context, userID, questionnaireID uuid.UUID) (*model.UserSubmission, error)
 	SubmitCount(ctx context.Context, userCtx context.Context, userID, questionnaireID uuid.UUID, submitLimit uint) (bool, error)
+	GetByQuestionnaireID(ctx context.Context, userCtx context.Context, questionnaireID uuid.UUID) ([]model.UserSubmission, error)
+	DeleteSubmission(ctx context.Context, userCtx context.Context, submissionID uuid.UUID) error
+	GetByUserID(ctx context.Context, userCtx context.Context, userID uuid.UUID, page, pageSize int) ([]model.UserSubmission, int64, error)
+	GetPageByQuestionnaireID(ctx context.Context, userCtx context.Context, questionnaireID uuid.UUID, filter model.SubmissionFilter, page, pageSize int) ([]model.UserSubmission, int64, error)
+	GetAllByUserID(ctx context.Context, userCtx context.Context, userID uuid.UUID) ([]model.UserSubmission, error)
+	StripPersonalData(ctx context.Context, userCtx context.Context, submissionID uuid.UUID) error
+	CancelInProgressByUserID(ctx context.Context, userCtx context.Context, userID uuid.UUID) error
 	// Add any other needed methods, e.g., to get the current question index, etc.
 }
 
@@ -35,7 +45,7 @@ func (r *SubmissionRepository) GetSubmissionByID(ctx context.Context, userCtx co
 		db = r.db
 	}
 	var sub model.UserSubmission
-	if err := db.WithContext(ctx).Where("id = ?", submissionID).Preload("Answers").First(&sub).Error; err != nil {
+	if err := db.WithContext(ctx).Where("id = ?", submissionID).Preload("Answers").Preload("DrawnQuestions", orderByPosition).Preload("Variables").First(&sub).Error; err != nil {
 		logger.GetLogger().LogWarningFromContext(ctx, logger.LogFields{
 			Service: logmessages.LogSubmitRepo,
 			Message: "submission not found",
@@ -50,7 +60,15 @@ func (r *SubmissionRepository) CreateSubmission(ctx context.Context, userCtx con
 	if db == nil {
 		db = r.db
 	}
-	return db.WithContext(ctx).Create(submission).Error
+	// the savepoint keeps the request's transaction usable when the id is taken
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return tx.Create(submission).Error
+	})
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "user_submissions_pkey" {
+		return apperrors.ErrSubmissionExists
+	}
+	return err
 }
 
 func (r *SubmissionRepository) UpdateSubmission(ctx context.Context, userCtx context.Context, submission *model.UserSubmission) error {
@@ -58,7 +76,9 @@ func (r *SubmissionRepository) UpdateSubmission(ctx context.Context, userCtx con
 	if db == nil {
 		db = r.db
 	}
-	return db.WithContext(ctx).Save(submission).Error
+	// answers are written through the answer repository, saving the loaded ones
+	// again would bring back those replaced in the meantime
+	return db.WithContext(ctx).Omit("Answers").Save(submission).Error
 }
 
 func (r *SubmissionRepository) GetActiveSubmissionByUserIDAndQuestionnaire(ctx context.Context, userCtx context.Context, userID, questionnaireID uuid.UUID) (*model.UserSubmission, error) {
@@ -98,3 +118,167 @@ func (r *SubmissionRepository) SubmitCount(ctx context.Context, userCtx context.
 	})
 	return count < int64(submitLimit), nil
 }
+
+func (r *SubmissionRepository) GetByQuestionnaireID(ctx context.Context, userCtx context.Context, questionnaireID uuid.UUID) ([]model.UserSubmission, error) {
+	db := appContext.GetDB(userCtx)
+	if db == nil {
+		db = r.db
+	}
+	var submissions []model.UserSubmission
+	err := db.WithContext(ctx).
+		Where("questionnaire_id = ?", questionnaireID).
+		Preload("Answers.Option").
+		Preload("DrawnQuestions", orderByPosition).
+		Preload("Variables").
+		Order("created_at ASC").
+		Find(&submissions).Error
+	return submissions, err
+}
+
+// GetByUserID returns a page of the user's submissions with their questionnaires,
+// newest first.
+func (r *SubmissionRepository) GetByUserID(ctx context.Context, userCtx context.Context, userID uuid.UUID, page, pageSize int) ([]model.UserSubmission, int64, error) {
+	db := appContext.GetDB(userCtx)
+	if db == nil {
+		db = r.db
+	}
+
+	var submissions []model.UserSubmission
+	var totalRecords int64
+	if err := db.WithContext(ctx).Model(&model.UserSubmission{}).Where("user_id = ?", userID).Count(&totalRecords).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * pageSize
+	err := db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Preload("Questionnaire").
+		Preload("Answers.Option").
+		Preload("DrawnQuestions", orderByPosition).
+		Order("created_at DESC").
+		Offset(offset).Limit(pageSize).
+		Find(&submissions).Error
+	return submissions, totalRecords, err
+}
+
+// GetPageByQuestionnaireID returns a page of the questionnaire's submissions that
+// match the filter, newest first.
+func (r *SubmissionRepository) GetPageByQuestionnaireID(ctx context.Context, userCtx context.Context, questionnaireID uuid.UUID, filter model.SubmissionFilter, page, pageSize int) ([]model.UserSubmission, int64, error) {
+	db := appContext.GetDB(userCtx)
+	if db == nil {
+		db = r.db
+	}
+
+	query := db.WithContext(ctx).Model(&model.UserSubmission{}).Where("questionnaire_id = ?", questionnaireID)
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at <= ?", *filter.To)
+	}
+
+	var submissions []model.UserSubmission
+	var totalRecords int64
+	if err := query.Session(&gorm.Session{}).Count(&totalRecords).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * pageSize
+	err := query.
+		Preload("Answers").
+		Preload("DrawnQuestions", orderByPosition).
+		Preload("Variables").
+		Order("created_at DESC").
+		Offset(offset).Limit(pageSize).
+		Find(&submissions).Error
+	return submissions, totalRecords, err
+}
+
+// GetAllByUserID returns every submission of the user with its questionnaire and
+// answers, oldest first. Deleted rows are included, they are still stored.
+func (r *SubmissionRepository) GetAllByUserID(ctx context.Context, userCtx context.Context, userID uuid.UUID) ([]model.UserSubmission, error) {
+	db := appContext.GetDB(userCtx)
+	if db == nil {
+		db = r.db
+	}
+	var submissions []model.UserSubmission
+	err := db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Preload("Questionnaire", unscoped).
+		Preload("Answers", unscoped).
+		Preload("Answers.Question", unscoped).
+		Preload("Answers.Option").
+		Preload("Variables").
+		Order("created_at ASC").
+		Find(&submissions).Error
+	return submissions, err
+}
+
+// StripPersonalData keeps only the chosen options of the submission's answers: texts
+// and uploaded files are cleared and hidden variables are removed.
+func (r *SubmissionRepository) StripPersonalData(ctx context.Context, userCtx context.Context, submissionID uuid.UUID) error {
+	db := appContext.GetDB(userCtx)
+	if db == nil {
+		db = r.db
+	}
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Unscoped().Model(&model.Answer{}).
+			Where("user_submission_id = ?", submissionID).
+			Updates(map[string]interface{}{
+				"text":              nil,
+				"file_path":         "",
+				"file_name":         "",
+				"file_content_type": "",
+				"file_size":         0,
+			}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Where("user_submission_id = ?", submissionID).Delete(&model.SubmissionVariable{}).Error
+	})
+}
+
+// CancelInProgressByUserID cancels every unfinished submission of the user.
+func (r *SubmissionRepository) CancelInProgressByUserID(ctx context.Context, userCtx context.Context, userID uuid.UUID) error {
+	db := appContext.GetDB(userCtx)
+	if db == nil {
+		db = r.db
+	}
+	return db.WithContext(ctx).Model(&model.UserSubmission{}).
+		Where("user_id = ? AND status = ?", userID, model.SubmissionsStatusInProgress).
+		Update("status", model.SubmissionsStatusCancelled).Error
+}
+
+// DeleteSubmission removes the submission together with its answers.
+func (r *SubmissionRepository) DeleteSubmission(ctx context.Context, userCtx context.Context, submissionID uuid.UUID) error {
+	db := appContext.GetDB(userCtx)
+	if db == nil {
+		db = r.db
+	}
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("user_submission_id = ?", submissionID).Delete(&model.Answer{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_submission_id = ?", submissionID).Delete(&model.QuestionVisit{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_submission_id = ?", submissionID).Delete(&model.SubmissionQuestion{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_submission_id = ?", submissionID).Delete(&model.SubmissionVariable{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&model.UserSubmission{}, "id = ?", submissionID).Error
+	})
+}
+
+func orderByPosition(db *gorm.DB) *gorm.DB {
+	return db.Order("position ASC")
+}
+
+func unscoped(db *gorm.DB) *gorm.DB {
+	return db.Unscoped()
+}
@@ -0,0 +1,184 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	myContext "golizilla/adapters/http/handler/context"
+	"golizilla/adapters/persistence/logger"
+	"golizilla/core/domain/model"
+	"golizilla/internal/apperrors"
+	"golizilla/internal/logmessages"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ISubmitSlotRepository interface {
+	CreateListing(ctx context.Context, userCtx context.Context, listing *model.SubmitSlotListing) error
+	UpdateListing(ctx context.Context, userCtx context.Context, listing *model.SubmitSlotListing) error
+	GetListingByIDForUpdate(ctx context.Context, userCtx context.Context, id uuid.UUID) (*model.SubmitSlotListing, error)
+	GetOpenListings(ctx context.Context, userCtx context.Context, questionnaireID uuid.UUID, page, pageSize int) ([]model.SubmitSlotListing, int64, error)
+	GetOpenListingsBySeller(ctx context.Context, userCtx context.Context, sellerID uuid.UUID) ([]model.SubmitSlotListing, error)
+	AddAdjustment(ctx context.Context, userCtx context.Context, adjustment *model.SubmitSlotAdjustment) error
+	GetSlotDelta(ctx context.Context, userCtx context.Context, userID, questionnaireID uuid.UUID) (int, error)
+	LockSlots(ctx context.Context, userCtx context.Context, userID, questionnaireID uuid.UUID) error
+	AddAuditLog(ctx context.Context, userCtx context.Context, auditLog *model.SubmitSlotAuditLog) error
+	GetAuditLogs(ctx context.Context, userCtx context.Context, questionnaireID uuid.UUID, page, pageSize int) ([]model.SubmitSlotAuditLog, int64, error)
+}
+
+type submitSlotRepository struct {
+	db *gorm.DB
+}
+
+func NewSubmitSlotRepository(db *gorm.DB) ISubmitSlotRepository {
+	return &submitSlotRepository{db: db}
+}
+
+func (r *submitSlotRepository) CreateListing(ctx context.Context, userCtx context.Context, listing *model.SubmitSlotListing) error {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+	return db.WithContext(ctx).Omit("Questionnaire", "Seller").Create(listing).Error
+}
+
+func (r *submitSlotRepository) UpdateListing(ctx context.Context, userCtx context.Context, listing *model.SubmitSlotListing) error {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+	return db.WithContext(ctx).Omit("Questionnaire", "Seller").Save(listing).Error
+}
+
+// GetListingByIDForUpdate locks the listing row until the surrounding transaction
+// ends, so a slot cannot be bought twice or bought while it is being cancelled.
+func (r *submitSlotRepository) GetListingByIDForUpdate(ctx context.Context, userCtx context.Context, id uuid.UUID) (*model.SubmitSlotListing, error) {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+	var listing model.SubmitSlotListing
+	err := db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", id).
+		First(&listing).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrSlotListingNotFound
+		}
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogSubmitSlotRepository,
+			Message: err.Error(),
+		})
+		return nil, err
+	}
+	return &listing, nil
+}
+
+func (r *submitSlotRepository) GetOpenListings(ctx context.Context, userCtx context.Context, questionnaireID uuid.UUID, page, pageSize int) ([]model.SubmitSlotListing, int64, error) {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+
+	var listings []model.SubmitSlotListing
+	var totalRecords int64
+
+	// Count total records for pagination info
+	query := db.WithContext(ctx).Model(&model.SubmitSlotListing{}).
+		Where("questionnaire_id = ? AND status = ?", questionnaireID, model.SubmitSlotListingOpen)
+	if err := query.Count(&totalRecords).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Calculate offset based on page and pageSize
+	offset := (page - 1) * pageSize
+
+	// Retrieve paginated records, cheapest first
+	err := db.WithContext(ctx).
+		Where("questionnaire_id = ? AND status = ?", questionnaireID, model.SubmitSlotListingOpen).
+		Order("price ASC, created_at ASC").
+		Offset(offset).Limit(pageSize).
+		Find(&listings).Error
+	return listings, totalRecords, err
+}
+
+func (r *submitSlotRepository) GetOpenListingsBySeller(ctx context.Context, userCtx context.Context, sellerID uuid.UUID) ([]model.SubmitSlotListing, error) {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+
+	var listings []model.SubmitSlotListing
+	err := db.WithContext(ctx).
+		Where("seller_id = ? AND status = ?", sellerID, model.SubmitSlotListingOpen).
+		Find(&listings).Error
+	return listings, err
+}
+
+func (r *submitSlotRepository) AddAdjustment(ctx context.Context, userCtx context.Context, adjustment *model.SubmitSlotAdjustment) error {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+	return db.WithContext(ctx).Create(adjustment).Error
+}
+
+func (r *submitSlotRepository) GetSlotDelta(ctx context.Context, userCtx context.Context, userID, questionnaireID uuid.UUID) (int, error) {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+	var delta int
+	err := db.WithContext(ctx).Model(&model.SubmitSlotAdjustment{}).
+		Select("COALESCE(SUM(delta), 0)").
+		Where("user_id = ? AND questionnaire_id = ?", userID, questionnaireID).
+		Scan(&delta).Error
+	return delta, err
+}
+
+// LockSlots serializes everything that uses up the slots of the user on the
+// questionnaire until the surrounding transaction ends, so the free slots are
+// counted only by one request at a time.
+func (r *submitSlotRepository) LockSlots(ctx context.Context, userCtx context.Context, userID, questionnaireID uuid.UUID) error {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+	return db.WithContext(ctx).
+		Exec("SELECT pg_advisory_xact_lock(hashtextextended(?, 0))", "submit_slots:"+userID.String()+":"+questionnaireID.String()).
+		Error
+}
+
+func (r *submitSlotRepository) AddAuditLog(ctx context.Context, userCtx context.Context, auditLog *model.SubmitSlotAuditLog) error {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+	return db.WithContext(ctx).Create(auditLog).Error
+}
+
+func (r *submitSlotRepository) GetAuditLogs(ctx context.Context, userCtx context.Context, questionnaireID uuid.UUID, page, pageSize int) ([]model.SubmitSlotAuditLog, int64, error) {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+
+	var auditLogs []model.SubmitSlotAuditLog
+	var totalRecords int64
+
+	query := db.WithContext(ctx).Model(&model.SubmitSlotAuditLog{}).Where("questionnaire_id = ?", questionnaireID)
+	if err := query.Count(&totalRecords).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * pageSize
+
+	err := db.WithContext(ctx).
+		Where("questionnaire_id = ?", questionnaireID).
+		Order("created_at DESC").
+		Offset(offset).Limit(pageSize).
+		Find(&auditLogs).Error
+	return auditLogs, totalRecords, err
+}
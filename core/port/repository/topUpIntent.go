@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	myContext "golizilla/adapters/http/handler/context"
+	"golizilla/core/domain/model"
+	"golizilla/internal/apperrors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ITopUpIntentRepository interface {
+	Create(ctx context.Context, userCtx context.Context, intent *model.TopUpIntent) error
+	Update(ctx context.Context, userCtx context.Context, intent *model.TopUpIntent) error
+	GetByID(ctx context.Context, userCtx context.Context, id uuid.UUID) (*model.TopUpIntent, error)
+	GetByReferenceForUpdate(ctx context.Context, userCtx context.Context, reference string) (*model.TopUpIntent, error)
+}
+
+type topUpIntentRepository struct {
+	db *gorm.DB
+}
+
+func NewTopUpIntentRepository(db *gorm.DB) ITopUpIntentRepository {
+	return &topUpIntentRepository{db: db}
+}
+
+func (r *topUpIntentRepository) Create(ctx context.Context, userCtx context.Context, intent *model.TopUpIntent) error {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+	return db.WithContext(ctx).Create(intent).Error
+}
+
+func (r *topUpIntentRepository) Update(ctx context.Context, userCtx context.Context, intent *model.TopUpIntent) error {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+	return db.WithContext(ctx).Omit("User").Save(intent).Error
+}
+
+func (r *topUpIntentRepository) GetByID(ctx context.Context, userCtx context.Context, id uuid.UUID) (*model.TopUpIntent, error) {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+	var intent model.TopUpIntent
+	if err := db.WithContext(ctx).Where("id = ?", id).First(&intent).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrTopUpNotFound
+		}
+		return nil, err
+	}
+	return &intent, nil
+}
+
+// GetByReferenceForUpdate locks the intent row until the surrounding transaction
+// ends, so concurrent deliveries of the same callback are processed one at a time.
+func (r *topUpIntentRepository) GetByReferenceForUpdate(ctx context.Context, userCtx context.Context, reference string) (*model.TopUpIntent, error) {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+	var intent model.TopUpIntent
+	err := db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("reference = ?", reference).
+		First(&intent).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrTopUpNotFound
+		}
+		return nil, err
+	}
+	return &intent, nil
+}
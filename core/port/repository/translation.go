@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	myContext "golizilla/adapters/http/handler/context"
+	"golizilla/adapters/persistence/logger"
+	"golizilla/core/domain/model"
+	"golizilla/internal/logmessages"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ITranslationRepository interface {
+	Upsert(ctx context.Context, userCtx context.Context, translations []model.Translation) error
+	GetByQuestionnaireID(ctx context.Context, userCtx context.Context, questionnaireID uuid.UUID) ([]model.Translation, error)
+	GetByLocale(ctx context.Context, userCtx context.Context, questionnaireID uuid.UUID, locale string) ([]model.Translation, error)
+	GetLocales(ctx context.Context, userCtx context.Context, questionnaireID uuid.UUID) ([]string, error)
+	DeleteLocale(ctx context.Context, userCtx context.Context, questionnaireID uuid.UUID, locale string) error
+}
+
+type translationRepository struct {
+	db *gorm.DB
+}
+
+func NewTranslationRepository(db *gorm.DB) ITranslationRepository {
+	return &translationRepository{db: db}
+}
+
+// Upsert creates the given translations or replaces the text of existing ones.
+func (r *translationRepository) Upsert(ctx context.Context, userCtx context.Context, translations []model.Translation) error {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+	if len(translations) == 0 {
+		return nil
+	}
+	err := db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "entity_type"}, {Name: "entity_id"}, {Name: "locale"}},
+		DoUpdates: clause.AssignmentColumns([]string{"text"}),
+	}).Create(&translations).Error
+	if err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogTranslationRepository,
+			Message: err.Error(),
+		})
+	}
+	return err
+}
+
+func (r *translationRepository) GetByQuestionnaireID(ctx context.Context, userCtx context.Context, questionnaireID uuid.UUID) ([]model.Translation, error) {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+	var translations []model.Translation
+	err := db.WithContext(ctx).
+		Where("questionnaire_id = ?", questionnaireID).
+		Order("locale ASC").
+		Find(&translations).Error
+	return translations, err
+}
+
+func (r *translationRepository) GetByLocale(ctx context.Context, userCtx context.Context, questionnaireID uuid.UUID, locale string) ([]model.Translation, error) {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+	var translations []model.Translation
+	err := db.WithContext(ctx).
+		Where("questionnaire_id = ? AND locale = ?", questionnaireID, locale).
+		Find(&translations).Error
+	return translations, err
+}
+
+func (r *translationRepository) GetLocales(ctx context.Context, userCtx context.Context, questionnaireID uuid.UUID) ([]string, error) {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+	var locales []string
+	err := db.WithContext(ctx).
+		Model(&model.Translation{}).
+		Where("questionnaire_id = ?", questionnaireID).
+		Distinct().
+		Order("locale ASC").
+		Pluck("locale", &locales).Error
+	return locales, err
+}
+
+func (r *translationRepository) DeleteLocale(ctx context.Context, userCtx context.Context, questionnaireID uuid.UUID, locale string) error {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+	return db.WithContext(ctx).
+		Where("questionnaire_id = ? AND locale = ?", questionnaireID, locale).
+		Delete(&model.Translation{}).Error
+}
@@ -0,0 +1,221 @@
+package repository
+
+import (
+	"context"
+	myContext "golizilla/adapters/http/handler/context"
+	"golizilla/core/domain/model"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ITrashRepository reaches the soft deleted questionnaires, questions and answers
+// the other repositories no longer see.
+type ITrashRepository interface {
+	GetDeletedQuestionnaires(ctx context.Context, userCtx context.Context, ownerID uuid.UUID, since time.Time) ([]model.Questionnaire, error)
+	GetDeletedQuestions(ctx context.Context, userCtx context.Context, ownerID uuid.UUID, since time.Time) ([]model.Question, error)
+	GetDeletedAnswers(ctx context.Context, userCtx context.Context, ownerID uuid.UUID, since time.Time) ([]model.Answer, error)
+	GetQuestionnaire(ctx context.Context, userCtx context.Context, id uuid.UUID) (*model.Questionnaire, error)
+	GetQuestion(ctx context.Context, userCtx context.Context, id uuid.UUID) (*model.Question, error)
+	GetAnswer(ctx context.Context, userCtx context.Context, id uuid.UUID) (*model.Answer, error)
+	Restore(ctx context.Context, userCtx context.Context, value interface{}, id uuid.UUID) error
+	Purge(ctx context.Context, userCtx context.Context, before time.Time) ([]string, error)
+}
+
+type trashRepository struct {
+	db *gorm.DB
+}
+
+func NewTrashRepository(db *gorm.DB) ITrashRepository {
+	return &trashRepository{db: db}
+}
+
+func (r *trashRepository) GetDeletedQuestionnaires(ctx context.Context, userCtx context.Context, ownerID uuid.UUID, since time.Time) ([]model.Questionnaire, error) {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+	var questionnaires []model.Questionnaire
+	err := db.WithContext(ctx).Unscoped().
+		Where("owner_id = ? AND deleted_at >= ?", ownerID, since).
+		Order("deleted_at DESC").
+		Find(&questionnaires).Error
+	return questionnaires, err
+}
+
+// GetDeletedQuestions returns the deleted questions of the owner's questionnaires,
+// including those of deleted questionnaires.
+func (r *trashRepository) GetDeletedQuestions(ctx context.Context, userCtx context.Context, ownerID uuid.UUID, since time.Time) ([]model.Question, error) {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+	var questions []model.Question
+	err := db.WithContext(ctx).Unscoped().
+		Joins("JOIN questionnaires ON questionnaires.id = questions.questionnaire_id").
+		Where("questionnaires.owner_id = ? AND questions.deleted_at >= ?", ownerID, since).
+		Order("questions.deleted_at DESC").
+		Find(&questions).Error
+	return questions, err
+}
+
+// GetDeletedAnswers returns the deleted answers given to the owner's questionnaires.
+func (r *trashRepository) GetDeletedAnswers(ctx context.Context, userCtx context.Context, ownerID uuid.UUID, since time.Time) ([]model.Answer, error) {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+	var answers []model.Answer
+	err := db.WithContext(ctx).Unscoped().
+		Joins("JOIN questions ON questions.id = answers.question_id").
+		Joins("JOIN questionnaires ON questionnaires.id = questions.questionnaire_id").
+		Where("questionnaires.owner_id = ? AND answers.deleted_at >= ?", ownerID, since).
+		Order("answers.deleted_at DESC").
+		Find(&answers).Error
+	return answers, err
+}
+
+func (r *trashRepository) GetQuestionnaire(ctx context.Context, userCtx context.Context, id uuid.UUID) (*model.Questionnaire, error) {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+	var questionnaire model.Questionnaire
+	if err := db.WithContext(ctx).Unscoped().Where("id = ?", id).First(&questionnaire).Error; err != nil {
+		return nil, err
+	}
+	return &questionnaire, nil
+}
+
+func (r *trashRepository) GetQuestion(ctx context.Context, userCtx context.Context, id uuid.UUID) (*model.Question, error) {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+	var question model.Question
+	if err := db.WithContext(ctx).Unscoped().Where("id = ?", id).First(&question).Error; err != nil {
+		return nil, err
+	}
+	return &question, nil
+}
+
+func (r *trashRepository) GetAnswer(ctx context.Context, userCtx context.Context, id uuid.UUID) (*model.Answer, error) {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+	var answer model.Answer
+	if err := db.WithContext(ctx).Unscoped().Where("id = ?", id).First(&answer).Error; err != nil {
+		return nil, err
+	}
+	return &answer, nil
+}
+
+// Restore clears deleted_at of the row with the ID in the table of value, e.g.
+// &model.Question{}.
+func (r *trashRepository) Restore(ctx context.Context, userCtx context.Context, value interface{}, id uuid.UUID) error {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+	return db.WithContext(ctx).Unscoped().Model(value).Where("id = ?", id).Update("deleted_at", nil).Error
+}
+
+// Purge permanently removes everything deleted before the given time, together
+// with the rows that depend on it: a questionnaire takes its questions, sections,
+// pools, translations, submissions, slot market and instance privileges along, a
+// question its options, translations and answers. It returns the storage keys of
+// the answer files and question media that were removed, for the caller to delete
+// once the transaction is committed.
+func (r *trashRepository) Purge(ctx context.Context, userCtx context.Context, before time.Time) ([]string, error) {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+
+	var files []string
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var questionnaireIDs, questionIDs, submissionIDs, optionIDs []uuid.UUID
+		err := tx.Unscoped().Model(&model.Questionnaire{}).
+			Where("deleted_at < ?", before).
+			Pluck("id", &questionnaireIDs).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Unscoped().Model(&model.Question{}).
+			Where("deleted_at < ? OR questionnaire_id IN ?", before, questionnaireIDs).
+			Pluck("id", &questionIDs).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Model(&model.UserSubmission{}).
+			Where("questionnaire_id IN ?", questionnaireIDs).
+			Pluck("id", &submissionIDs).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Model(&model.Option{}).
+			Where("question_id IN ?", questionIDs).
+			Pluck("id", &optionIDs).Error
+		if err != nil {
+			return err
+		}
+
+		var answers []model.Answer
+		err = tx.Unscoped().
+			Where("deleted_at < ? OR question_id IN ? OR user_submission_id IN ?", before, questionIDs, submissionIDs).
+			Find(&answers).Error
+		if err != nil {
+			return err
+		}
+		answerIDs := make([]uuid.UUID, len(answers))
+		for i, answer := range answers {
+			answerIDs[i] = answer.ID
+			if answer.FilePath != "" {
+				files = append(files, answer.FilePath)
+			}
+		}
+		var media []string
+		err = tx.Unscoped().Model(&model.Question{}).
+			Where("id IN ? AND meta_data_path <> ''", questionIDs).
+			Pluck("meta_data_path", &media).Error
+		if err != nil {
+			return err
+		}
+		files = append(files, media...)
+
+		deletes := []struct {
+			value interface{}
+			query string
+			args  []interface{}
+		}{
+			{&model.Answer{}, "id IN ?", []interface{}{answerIDs}},
+			{&model.QuestionVisit{}, "question_id IN ? OR user_submission_id IN ?", []interface{}{questionIDs, submissionIDs}},
+			{&model.SubmissionQuestion{}, "question_id IN ? OR user_submission_id IN ?", []interface{}{questionIDs, submissionIDs}},
+			{&model.SubmissionVariable{}, "user_submission_id IN ?", []interface{}{submissionIDs}},
+			{&model.UserSubmission{}, "id IN ?", []interface{}{submissionIDs}},
+			{&model.Translation{}, "questionnaire_id IN ? OR entity_id IN ? OR entity_id IN ?", []interface{}{questionnaireIDs, questionIDs, optionIDs}},
+			{&model.Option{}, "id IN ?", []interface{}{optionIDs}},
+			{&model.Question{}, "id IN ?", []interface{}{questionIDs}},
+			{&model.Section{}, "questionnaire_id IN ?", []interface{}{questionnaireIDs}},
+			{&model.QuestionPool{}, "questionnaire_id IN ?", []interface{}{questionnaireIDs}},
+			{&model.SubmitSlotListing{}, "questionnaire_id IN ?", []interface{}{questionnaireIDs}},
+			{&model.SubmitSlotAdjustment{}, "questionnaire_id IN ?", []interface{}{questionnaireIDs}},
+			{&model.SubmitSlotAuditLog{}, "questionnaire_id IN ?", []interface{}{questionnaireIDs}},
+			{&model.RolePrivilegeOnInstance{}, "questionnaire_id IN ?", []interface{}{questionnaireIDs}},
+			{&model.UserPrivilegeOnInstance{}, "questionnaire_id IN ?", []interface{}{questionnaireIDs}},
+			{&model.Questionnaire{}, "id IN ?", []interface{}{questionnaireIDs}},
+		}
+		for _, d := range deletes {
+			if err := tx.Unscoped().Where(d.query, d.args...).Delete(d.value).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
@@ -8,6 +8,7 @@ import (
 	"golizilla/adapters/persistence/logger"
 	"golizilla/core/domain/model"
 	"golizilla/internal/logmessages"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -16,11 +17,14 @@ import (
 type IUserRepository interface {
 	Create(ctx context.Context, userCtx context.Context, user *model.User) error
 	FindByEmail(ctx context.Context, userCtx context.Context, email string) (*model.User, error)
+	FindByUsername(ctx context.Context, userCtx context.Context, username string) (*model.User, error)
 	FindByID(ctx context.Context, userCtx context.Context, id uuid.UUID) (*model.User, error)
 	Update(ctx context.Context, userCtx context.Context, user *model.User) error
 	// profile
+	UpdateRole(ctx context.Context, userCtx context.Context, userId uuid.UUID, roleId uuid.UUID) error
 	CreateNotification(ctx context.Context, userCtx context.Context, userId uuid.UUID, notification *model.Notification) error
 	FindByIDWithNotifications(ctx context.Context, userCtx context.Context, userId uuid.UUID) (*model.User, error)
+	Anonymize(ctx context.Context, userCtx context.Context, userId uuid.UUID) error
 }
 
 type UserRepository struct {
@@ -67,6 +71,22 @@ func (r *UserRepository) FindByEmail(ctx context.Context, userCtx context.Contex
 	return &user, nil
 }
 
+func (r *UserRepository) FindByUsername(ctx context.Context, userCtx context.Context, username string) (*model.User, error) {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+	var user model.User
+	err := db.WithContext(ctx).Where("username = ?", username).First(&user).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, gorm.ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("failed to find user by username: %w", err)
+	}
+	return &user, nil
+}
+
 func (r *UserRepository) FindByID(ctx context.Context, userCtx context.Context, id uuid.UUID) (*model.User, error) {
 	var db *gorm.DB
 	if db = myContext.GetDB(userCtx); db == nil {
@@ -87,8 +107,8 @@ func (r *UserRepository) Update(ctx context.Context, userCtx context.Context, us
 		db = r.db
 	}
 
-	// Perform the update
-	err := db.WithContext(ctx).Model(&model.User{}).Where("id = ?", user.ID).Updates(user).Error
+	// Perform the update, the balance is only written by the wallet ledger
+	err := db.WithContext(ctx).Model(&model.User{}).Where("id = ?", user.ID).Omit("wallet").Updates(user).Error
 	if err != nil {
 		// Log the error
 		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
@@ -101,6 +121,15 @@ func (r *UserRepository) Update(ctx context.Context, userCtx context.Context, us
 	return nil
 }
 
+// UpdateRole moves the user to another role.
+func (r *UserRepository) UpdateRole(ctx context.Context, userCtx context.Context, userId uuid.UUID, roleId uuid.UUID) error {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+	return db.WithContext(ctx).Model(&model.User{}).Where("id = ?", userId).Update("role_id", roleId).Error
+}
+
 func (r *UserRepository) CreateNotification(ctx context.Context, userCtx context.Context, userId uuid.UUID, notification *model.Notification) error {
 	var db *gorm.DB
 	if db = myContext.GetDB(userCtx); db == nil {
@@ -124,3 +153,42 @@ func (r *UserRepository) FindByIDWithNotifications(ctx context.Context, userCtx
 	}
 	return &user, nil
 }
+
+// Anonymize removes the personal fields and notifications of a deleted account. The
+// row itself stays, as submissions, ledger entries and payments still refer to it.
+// Unique fields get placeholders derived from the ID and the empty password never
+// matches, so the account cannot be logged into again.
+func (r *UserRepository) Anonymize(ctx context.Context, userCtx context.Context, userId uuid.UUID) error {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userId).Delete(&model.Notification{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", userId).Delete(&model.UserPrivilegeOnInstance{}).Error; err != nil {
+			return err
+		}
+		placeholder := "deleted-" + userId.String()
+		return tx.Model(&model.User{}).Where("id = ?", userId).Updates(map[string]interface{}{
+			"username":                  placeholder,
+			"email":                     placeholder + "@deleted.invalid",
+			"national_id":               placeholder,
+			"password":                  "",
+			"is_active":                 false,
+			"email_verification_code":   "",
+			"email_verification_expiry": time.Time{},
+			"is_two_fa_enabled":         false,
+			"two_fa_code":               "",
+			"two_fa_code_expiry":        time.Time{},
+			"first_name":                "",
+			"last_name":                 "",
+			"city":                      "",
+			"preferred_locale":          "",
+			"date_of_birth":             time.Time{},
+			"anonymized_at":             time.Now(),
+		}).Error
+	})
+}
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+	myContext "golizilla/adapters/http/handler/context"
+	"golizilla/core/domain/model"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IUserPrivilegeOnInstanceRepository interface {
+	Add(ctx context.Context, userCtx context.Context, userPrivilegeOnInstance *model.UserPrivilegeOnInstance) error
+	Delete(ctx context.Context, userCtx context.Context, userId uuid.UUID, privilegeId string, questionnaireId uuid.UUID) error
+	GetUserPrivilegesOnInstance(ctx context.Context, userCtx context.Context, userId uuid.UUID) ([]model.UserPrivilegeOnInstance, error)
+	HasPrivilegesOnInsance(ctx context.Context, userCtx context.Context, userId uuid.UUID, questionnariId uuid.UUID, privileges ...string) (bool, error)
+}
+
+type userPrivilegeOnInstanceRepository struct {
+	db *gorm.DB
+}
+
+func NewUserPrivilegeOnInstanceRepository(db *gorm.DB) IUserPrivilegeOnInstanceRepository {
+	return &userPrivilegeOnInstanceRepository{db: db}
+}
+
+// Add grants the privilege, granting it again is a no-op.
+func (r *userPrivilegeOnInstanceRepository) Add(ctx context.Context, userCtx context.Context, userPrivilegeOnInstance *model.UserPrivilegeOnInstance) error {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+	return db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(userPrivilegeOnInstance).Error
+}
+
+func (r *userPrivilegeOnInstanceRepository) Delete(ctx context.Context, userCtx context.Context, userId uuid.UUID, privilegeId string, questionnaireId uuid.UUID) error {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+	return db.WithContext(ctx).
+		Where("user_id = ? AND privilege_id = ? AND questionnaire_id = ?", userId, privilegeId, questionnaireId).
+		Delete(&model.UserPrivilegeOnInstance{}).Error
+}
+
+func (r *userPrivilegeOnInstanceRepository) GetUserPrivilegesOnInstance(ctx context.Context, userCtx context.Context, userId uuid.UUID) ([]model.UserPrivilegeOnInstance, error) {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+	var userPrivilegesOnInstance []model.UserPrivilegeOnInstance
+	err := db.WithContext(ctx).Where("user_id = ?", userId).Order("created_at").Find(&userPrivilegesOnInstance).Error
+	return userPrivilegesOnInstance, err
+}
+
+func (r *userPrivilegeOnInstanceRepository) HasPrivilegesOnInsance(ctx context.Context, userCtx context.Context, userId uuid.UUID, questionnariId uuid.UUID, privileges ...string) (bool, error) {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+	var count int64
+	err := db.WithContext(ctx).Model(&model.UserPrivilegeOnInstance{}).
+		Where("user_id = ? AND privilege_id IN ? AND questionnaire_id = ?", userId, privileges, questionnariId).
+		Count(&count).Error
+	return count > 0, err
+}
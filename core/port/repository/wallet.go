@@ -0,0 +1,197 @@
+package repository
+
+import (
+	"context"
+	myContext "golizilla/adapters/http/handler/context"
+	"golizilla/adapters/persistence/logger"
+	"golizilla/core/domain/model"
+	"golizilla/internal/apperrors"
+	"golizilla/internal/logmessages"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IWalletRepository interface {
+	Post(ctx context.Context, userCtx context.Context, transaction *model.WalletTransaction) error
+	GetEntriesByAccountID(ctx context.Context, userCtx context.Context, accountID uuid.UUID, page, pageSize int) ([]model.LedgerEntry, int64, error)
+	GetMismatchedAccounts(ctx context.Context, userCtx context.Context) ([]model.WalletReconciliation, error)
+	GetUnbalancedTransactions(ctx context.Context, userCtx context.Context) ([]model.WalletUnbalancedTransaction, error)
+}
+
+type walletRepository struct {
+	db *gorm.DB
+}
+
+func NewWalletRepository(db *gorm.DB) IWalletRepository {
+	return &walletRepository{db: db}
+}
+
+// Post writes a balanced transaction to the ledger and applies its entries to the
+// cached wallet balances. Everything happens in one (nested) DB transaction, and the
+// affected user and system account rows are locked in ID order so concurrent
+// transfers cannot deadlock. Only system accounts may end up with a negative balance.
+func (r *walletRepository) Post(ctx context.Context, userCtx context.Context, transaction *model.WalletTransaction) error {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+
+	var sum int64
+	accountIDs := make([]uuid.UUID, 0, len(transaction.Entries))
+	for _, entry := range transaction.Entries {
+		sum += entry.Amount
+		accountIDs = append(accountIDs, entry.AccountID)
+	}
+	if len(transaction.Entries) < 2 || sum != 0 {
+		return apperrors.ErrUnbalancedTransaction
+	}
+
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var users []model.User
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id IN ?", accountIDs).
+			Order("id").
+			Find(&users).Error
+		if err != nil {
+			logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+				Service: logmessages.LogWalletRepository,
+				Message: err.Error(),
+			})
+			return err
+		}
+
+		var systemAccounts []model.WalletSystemAccount
+		err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id IN ?", accountIDs).
+			Order("id").
+			Find(&systemAccounts).Error
+		if err != nil {
+			return err
+		}
+
+		balances := make(map[uuid.UUID]int64, len(users)+len(systemAccounts))
+		isSystem := make(map[uuid.UUID]bool, len(systemAccounts))
+		for _, user := range users {
+			balances[user.ID] = int64(user.Wallet)
+		}
+		for _, account := range systemAccounts {
+			balances[account.ID] = account.Balance
+			isSystem[account.ID] = true
+		}
+
+		for i := range transaction.Entries {
+			entry := &transaction.Entries[i]
+			balance, ok := balances[entry.AccountID]
+			if !ok {
+				return apperrors.ErrWalletAccountNotFound
+			}
+			balance += entry.Amount
+			if balance < 0 && !isSystem[entry.AccountID] {
+				return apperrors.ErrInsufficientBalance
+			}
+			balances[entry.AccountID] = balance
+			entry.BalanceAfter = balance
+		}
+
+		for accountID, balance := range balances {
+			var err error
+			if isSystem[accountID] {
+				err = tx.Model(&model.WalletSystemAccount{}).Where("id = ?", accountID).UpdateColumn("balance", balance).Error
+			} else {
+				err = tx.Model(&model.User{}).Where("id = ?", accountID).UpdateColumn("wallet", balance).Error
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Create(transaction).Error; err != nil {
+			logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+				Service: logmessages.LogWalletRepository,
+				Message: err.Error(),
+			})
+			return err
+		}
+		return nil
+	})
+}
+
+func (r *walletRepository) GetEntriesByAccountID(ctx context.Context, userCtx context.Context, accountID uuid.UUID, page, pageSize int) ([]model.LedgerEntry, int64, error) {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+
+	var entries []model.LedgerEntry
+	var totalRecords int64
+
+	// Count total records for pagination info
+	query := db.WithContext(ctx).Model(&model.LedgerEntry{}).Where("account_id = ?", accountID)
+	if err := query.Count(&totalRecords).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Calculate offset based on page and pageSize
+	offset := (page - 1) * pageSize
+
+	// Retrieve paginated records, newest first
+	err := db.WithContext(ctx).
+		Preload("Transaction.Entries").
+		Where("account_id = ?", accountID).
+		Order("created_at DESC").
+		Offset(offset).Limit(pageSize).
+		Find(&entries).Error
+	return entries, totalRecords, err
+}
+
+func (r *walletRepository) GetMismatchedAccounts(ctx context.Context, userCtx context.Context) ([]model.WalletReconciliation, error) {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+
+	var accounts []model.WalletReconciliation
+	err := db.WithContext(ctx).Model(&model.User{}).
+		Select("users.id AS account_id, users.username, users.wallet AS wallet_balance, COALESCE(SUM(ledger_entries.amount), 0) AS ledger_balance").
+		Joins("LEFT JOIN ledger_entries ON ledger_entries.account_id = users.id").
+		Group("users.id, users.username, users.wallet").
+		Having("users.wallet <> COALESCE(SUM(ledger_entries.amount), 0)").
+		Scan(&accounts).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var systemAccounts []model.WalletReconciliation
+	err = db.WithContext(ctx).Model(&model.WalletSystemAccount{}).
+		Select("wallet_system_accounts.id AS account_id, wallet_system_accounts.name AS username, wallet_system_accounts.balance AS wallet_balance, COALESCE(SUM(ledger_entries.amount), 0) AS ledger_balance").
+		Joins("LEFT JOIN ledger_entries ON ledger_entries.account_id = wallet_system_accounts.id").
+		Group("wallet_system_accounts.id, wallet_system_accounts.name, wallet_system_accounts.balance").
+		Having("wallet_system_accounts.balance <> COALESCE(SUM(ledger_entries.amount), 0)").
+		Scan(&systemAccounts).Error
+	if err != nil {
+		return nil, err
+	}
+	accounts = append(accounts, systemAccounts...)
+
+	for i := range accounts {
+		accounts[i].Difference = accounts[i].WalletBalance - accounts[i].LedgerBalance
+	}
+	return accounts, nil
+}
+
+func (r *walletRepository) GetUnbalancedTransactions(ctx context.Context, userCtx context.Context) ([]model.WalletUnbalancedTransaction, error) {
+	var db *gorm.DB
+	if db = myContext.GetDB(userCtx); db == nil {
+		db = r.db
+	}
+
+	var transactions []model.WalletUnbalancedTransaction
+	err := db.WithContext(ctx).Model(&model.LedgerEntry{}).
+		Select("transaction_id, SUM(amount) AS sum").
+		Group("transaction_id").
+		Having("SUM(amount) <> 0").
+		Scan(&transactions).Error
+	return transactions, err
+}
@@ -0,0 +1,18 @@
+package scanner
+
+import (
+	"context"
+	"io"
+)
+
+// ScanResult is the verdict of a virus scanner about one file.
+type ScanResult struct {
+	Clean  bool
+	Threat string // name of the detected threat, empty when clean
+}
+
+// IVirusScanner is called for every file a respondent uploads, before the file is
+// stored. Adapters can wrap ClamAV, a cloud scanning API or anything similar.
+type IVirusScanner interface {
+	Scan(ctx context.Context, fileName string, body io.Reader) (*ScanResult, error)
+}
@@ -0,0 +1,15 @@
+package storage
+
+import (
+	"context"
+	"io"
+)
+
+// IFileStorage is implemented by every blob storage adapter. Keys are slash
+// separated paths such as "questions/<question id>/<file id>".
+type IFileStorage interface {
+	Save(ctx context.Context, key string, contentType string, body io.Reader, size int64) error
+	// Open returns the object's content and its size in bytes. The caller closes the reader.
+	Open(ctx context.Context, key string) (io.ReadCloser, int64, error)
+	Delete(ctx context.Context, key string) error
+}
@@ -0,0 +1,478 @@
+package service
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"golizilla/adapters/persistence/logger"
+	"golizilla/core/domain/model"
+	"golizilla/core/port/repository"
+	"golizilla/core/port/storage"
+	"golizilla/internal/apperrors"
+	logmessages "golizilla/internal/logmessages"
+	"io"
+	"path"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type IAccountService interface {
+	Export(ctx context.Context, userCtx context.Context, userID uuid.UUID) (*AccountExport, error)
+	Delete(ctx context.Context, userCtx context.Context, userID uuid.UUID, password string) error
+	ArchiveQuestionnaire(ctx context.Context, userCtx context.Context, userID, questionnaireID uuid.UUID) error
+	TransferQuestionnaire(ctx context.Context, userCtx context.Context, userID, questionnaireID uuid.UUID, newOwnerUsername string) error
+}
+
+type AccountService struct {
+	userRepo                    repository.IUserRepository
+	roleRepo                    repository.IRoleRepository
+	rolePrivilegeRepo           repository.IRolePrivilegeRepository
+	rolePrivilegeOnInstanceRepo repository.IRolePrivilegeOnInstanceRepository
+	userPrivilegeOnInstanceRepo repository.IUserPrivilegeOnInstanceRepository
+	questionnaireRepo           repository.IQuestionnaireRepository
+	submissionRepo              repository.ISubmissionRepository
+	walletRepo                  repository.IWalletRepository
+	submitSlotRepo              repository.ISubmitSlotRepository
+	submitSlotService           ISubmitSlotService
+	fileStorage                 storage.IFileStorage
+}
+
+func NewAccountService(
+	userRepo repository.IUserRepository,
+	roleRepo repository.IRoleRepository,
+	rolePrivilegeRepo repository.IRolePrivilegeRepository,
+	rolePrivilegeOnInstanceRepo repository.IRolePrivilegeOnInstanceRepository,
+	userPrivilegeOnInstanceRepo repository.IUserPrivilegeOnInstanceRepository,
+	questionnaireRepo repository.IQuestionnaireRepository,
+	submissionRepo repository.ISubmissionRepository,
+	walletRepo repository.IWalletRepository,
+	submitSlotRepo repository.ISubmitSlotRepository,
+	submitSlotService ISubmitSlotService,
+	fileStorage storage.IFileStorage,
+) IAccountService {
+	return &AccountService{
+		userRepo:                    userRepo,
+		roleRepo:                    roleRepo,
+		rolePrivilegeRepo:           rolePrivilegeRepo,
+		rolePrivilegeOnInstanceRepo: rolePrivilegeOnInstanceRepo,
+		userPrivilegeOnInstanceRepo: userPrivilegeOnInstanceRepo,
+		questionnaireRepo:           questionnaireRepo,
+		submissionRepo:              submissionRepo,
+		walletRepo:                  walletRepo,
+		submitSlotRepo:              submitSlotRepo,
+		submitSlotService:           submitSlotService,
+		fileStorage:                 fileStorage,
+	}
+}
+
+// AccountExport is everything stored about a user, written as account.json
+// together with the files the user uploaded as answers.
+type AccountExport struct {
+	ExportedAt time.Time       `json:"exported_at"`
+	Profile    ExportedProfile `json:"profile"`
+	Role       ExportedRole    `json:"role"`
+	// privileges granted to the user alone, on top of those of the role
+	InstancePrivileges []ExportedInstancePrivilege `json:"instance_privileges"`
+	Questionnaires     []ExportedQuestionnaire     `json:"owned_questionnaires"`
+	Submissions        []ExportedSubmission        `json:"submissions"`
+	Notifications      []ExportedNotification      `json:"notifications"`
+	WalletBalance      uint                        `json:"wallet_balance"`
+	WalletHistory      []ExportedWalletEntry       `json:"wallet_history"`
+	files              []exportedFile
+	fileStorage        storage.IFileStorage
+}
+
+type ExportedProfile struct {
+	ID              uuid.UUID `json:"id"`
+	Username        string    `json:"username"`
+	Email           string    `json:"email"`
+	NationalID      string    `json:"national_id"`
+	FirstName       string    `json:"first_name"`
+	LastName        string    `json:"last_name"`
+	City            string    `json:"city"`
+	PreferredLocale string    `json:"preferred_locale"`
+	DateOfBirth     time.Time `json:"date_of_birth"`
+	IsTwoFAEnabled  bool      `json:"two_fa_enabled"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+type ExportedRole struct {
+	ID                 uuid.UUID                   `json:"id"`
+	Name               string                      `json:"name"`
+	Privileges         []string                    `json:"privileges"`
+	InstancePrivileges []ExportedInstancePrivilege `json:"instance_privileges"`
+}
+
+type ExportedInstancePrivilege struct {
+	QuestionnaireID uuid.UUID `json:"questionnaire_id"`
+	Privilege       string    `json:"privilege"`
+}
+
+type ExportedQuestionnaire struct {
+	ID         uuid.UUID  `json:"id"`
+	Title      string     `json:"title"`
+	ArchivedAt *time.Time `json:"archived_at,omitempty"`
+}
+
+type ExportedSubmission struct {
+	ID                 uuid.UUID              `json:"id"`
+	QuestionnaireID    uuid.UUID              `json:"questionnaire_id"`
+	QuestionnaireTitle string                 `json:"questionnaire_title"`
+	Status             model.SubmissionStatus `json:"status"`
+	StartedAt          time.Time              `json:"started_at"`
+	UpdatedAt          time.Time              `json:"updated_at"`
+	Variables          map[string]string      `json:"variables,omitempty"`
+	Answers            []ExportedAnswer       `json:"answers"`
+}
+
+type ExportedAnswer struct {
+	QuestionID   uuid.UUID  `json:"question_id"`
+	QuestionText string     `json:"question_text"`
+	Text         *string    `json:"text,omitempty"`
+	OptionID     *uuid.UUID `json:"option_id,omitempty"`
+	OptionText   string     `json:"option_text,omitempty"`
+	FileName     string     `json:"file_name,omitempty"`
+	File         string     `json:"file,omitempty"` // path inside the archive
+}
+
+type ExportedNotification struct {
+	Message   string    `json:"message"`
+	IsRead    bool      `json:"is_read"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ExportedWalletEntry is one of the user's ledger entries. The other side of the
+// transaction is left out, it belongs to someone else.
+type ExportedWalletEntry struct {
+	TransactionID uuid.UUID                   `json:"transaction_id"`
+	Type          model.WalletTransactionType `json:"type"`
+	Description   string                      `json:"description"`
+	Amount        int64                       `json:"amount"`
+	BalanceAfter  int64                       `json:"balance_after"`
+	CreatedAt     time.Time                   `json:"created_at"`
+}
+
+type exportedFile struct {
+	key  string
+	name string
+}
+
+// walletExportPageSize is how many ledger entries are loaded at once for an export.
+const walletExportPageSize = 100
+
+// Export collects everything tied to the user.
+func (s *AccountService) Export(ctx context.Context, userCtx context.Context, userID uuid.UUID) (*AccountExport, error) {
+	user, err := s.userRepo.FindByIDWithNotifications(ctx, userCtx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	export := &AccountExport{
+		ExportedAt: time.Now(),
+		Profile: ExportedProfile{
+			ID:              user.ID,
+			Username:        user.Username,
+			Email:           user.Email,
+			NationalID:      user.NationalID,
+			FirstName:       user.FirstName,
+			LastName:        user.LastName,
+			City:            user.City,
+			PreferredLocale: user.PreferredLocale,
+			DateOfBirth:     user.DateOfBirth,
+			IsTwoFAEnabled:  user.IsTwoFAEnabled,
+			CreatedAt:       user.CreatedAt,
+		},
+		Questionnaires: []ExportedQuestionnaire{},
+		Submissions:    []ExportedSubmission{},
+		Notifications:  []ExportedNotification{},
+		WalletBalance:  user.Wallet,
+		WalletHistory:  []ExportedWalletEntry{},
+		fileStorage:    s.fileStorage,
+	}
+	for _, notification := range user.NotificationList {
+		export.Notifications = append(export.Notifications, ExportedNotification{
+			Message:   notification.Message,
+			IsRead:    notification.IsRead,
+			CreatedAt: notification.CreatedAt,
+		})
+	}
+
+	if export.Role, err = s.exportRole(ctx, userCtx, user.RoleId); err != nil {
+		return nil, err
+	}
+
+	instancePrivileges, err := s.userPrivilegeOnInstanceRepo.GetUserPrivilegesOnInstance(ctx, userCtx, userID)
+	if err != nil {
+		return nil, err
+	}
+	export.InstancePrivileges = make([]ExportedInstancePrivilege, len(instancePrivileges))
+	for i, privilege := range instancePrivileges {
+		export.InstancePrivileges[i] = ExportedInstancePrivilege{
+			QuestionnaireID: privilege.QuestionnaireId,
+			Privilege:       privilege.PrivilegeId,
+		}
+	}
+
+	questionnaires, err := s.questionnaireRepo.GetByOwnerId(ctx, userCtx, userID)
+	if err != nil && !errors.Is(err, apperrors.ErrQuestionnaireNotFound) {
+		return nil, err
+	}
+	for _, qn := range questionnaires {
+		export.Questionnaires = append(export.Questionnaires, ExportedQuestionnaire{
+			ID:         qn.Id,
+			Title:      qn.Title,
+			ArchivedAt: qn.ArchivedAt,
+		})
+	}
+
+	submissions, err := s.submissionRepo.GetAllByUserID(ctx, userCtx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, submission := range submissions {
+		exported := ExportedSubmission{
+			ID:                 submission.ID,
+			QuestionnaireID:    submission.QuestionnaireId,
+			QuestionnaireTitle: submission.Questionnaire.Title,
+			Status:             submission.Status,
+			StartedAt:          submission.CreatedAt,
+			UpdatedAt:          submission.UpdatedAt,
+			Variables:          submission.VariableMap(),
+			Answers:            []ExportedAnswer{},
+		}
+		for _, answer := range submission.Answers {
+			exportedAnswer := ExportedAnswer{
+				QuestionID:   answer.QuestionID,
+				QuestionText: answer.Question.QuestionText,
+				Text:         answer.Text,
+				OptionID:     answer.OptionID,
+				FileName:     answer.FileName,
+			}
+			if answer.Option != nil {
+				exportedAnswer.OptionText = answer.Option.Text
+			}
+			if answer.FilePath != "" {
+				exportedAnswer.File = path.Join("files", submission.ID.String(), answer.ID.String()+"-"+sanitizeFileName(answer.FileName))
+				export.files = append(export.files, exportedFile{key: answer.FilePath, name: exportedAnswer.File})
+			}
+			exported.Answers = append(exported.Answers, exportedAnswer)
+		}
+		export.Submissions = append(export.Submissions, exported)
+	}
+
+	for page := 1; ; page++ {
+		entries, totalRecords, err := s.walletRepo.GetEntriesByAccountID(ctx, userCtx, userID, page, walletExportPageSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			exported := ExportedWalletEntry{
+				TransactionID: entry.TransactionID,
+				Amount:        entry.Amount,
+				BalanceAfter:  entry.BalanceAfter,
+				CreatedAt:     entry.CreatedAt,
+			}
+			if entry.Transaction != nil {
+				exported.Type = entry.Transaction.Type
+				exported.Description = entry.Transaction.Description
+			}
+			export.WalletHistory = append(export.WalletHistory, exported)
+		}
+		if len(entries) == 0 || int64(len(export.WalletHistory)) >= totalRecords {
+			break
+		}
+	}
+
+	return export, nil
+}
+
+func (s *AccountService) exportRole(ctx context.Context, userCtx context.Context, roleID uuid.UUID) (ExportedRole, error) {
+	role, err := s.roleRepo.GetById(ctx, userCtx, roleID)
+	if err != nil {
+		return ExportedRole{}, err
+	}
+	exported := ExportedRole{
+		ID:                 role.ID,
+		Name:               role.Name,
+		Privileges:         []string{},
+		InstancePrivileges: []ExportedInstancePrivilege{},
+	}
+
+	privileges, err := s.rolePrivilegeRepo.GetRolePrivileges(ctx, userCtx, roleID)
+	if err != nil {
+		return ExportedRole{}, err
+	}
+	for _, privilege := range privileges {
+		exported.Privileges = append(exported.Privileges, privilege.PrivilegeId)
+	}
+
+	instancePrivileges, err := s.rolePrivilegeOnInstanceRepo.GetRolePrivilegesOnInstance(ctx, userCtx, roleID)
+	if err != nil {
+		return ExportedRole{}, err
+	}
+	for _, privilege := range instancePrivileges {
+		exported.InstancePrivileges = append(exported.InstancePrivileges, ExportedInstancePrivilege{
+			QuestionnaireID: privilege.QuestionnaireId,
+			Privilege:       privilege.PrivilegeId,
+		})
+	}
+	return exported, nil
+}
+
+func (e *AccountExport) FileName() string {
+	return fmt.Sprintf("account-%s.zip", e.Profile.ID)
+}
+
+// WriteZip writes a zip archive with account.json and every file the user
+// uploaded below files/, where the "file" field of the answers points to.
+func (e *AccountExport) WriteZip(ctx context.Context, w io.Writer) error {
+	archive := zip.NewWriter(w)
+
+	jsonFile, err := archive.Create("account.json")
+	if err != nil {
+		return err
+	}
+	encoder := json.NewEncoder(jsonFile)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(e); err != nil {
+		return err
+	}
+
+	for _, file := range e.files {
+		if err := copyToZip(ctx, e.fileStorage, archive, file.key, file.name); err != nil {
+			return err
+		}
+	}
+	return archive.Close()
+}
+
+// Delete removes the personal data of the account after checking the password.
+// Every questionnaire the user owns must be transferred or archived first. Answers
+// stay in the results of their questionnaires without pointing to a person: on
+// anonymous questionnaires they are kept as given, on the others only the chosen
+// options are kept, since texts and files were given under the user's name. Open
+// slot listings are cancelled and unfinished submissions are ended.
+func (s *AccountService) Delete(ctx context.Context, userCtx context.Context, userID uuid.UUID, password string) error {
+	user, err := s.userRepo.FindByID(ctx, userCtx, userID)
+	if err != nil {
+		return err
+	}
+	if !user.CheckPassword(password) {
+		return apperrors.ErrInvalidCredentials
+	}
+
+	questionnaires, err := s.questionnaireRepo.GetByOwnerId(ctx, userCtx, userID)
+	if err != nil && !errors.Is(err, apperrors.ErrQuestionnaireNotFound) {
+		return err
+	}
+	for _, qn := range questionnaires {
+		if qn.ArchivedAt == nil {
+			return apperrors.ErrOwnsActiveQuestionnaires
+		}
+	}
+
+	listings, err := s.submitSlotRepo.GetOpenListingsBySeller(ctx, userCtx, userID)
+	if err != nil {
+		return err
+	}
+	for _, listing := range listings {
+		if _, err := s.submitSlotService.CancelListing(ctx, userCtx, userID, listing.ID); err != nil {
+			return err
+		}
+	}
+
+	if err := s.submissionRepo.CancelInProgressByUserID(ctx, userCtx, userID); err != nil {
+		return err
+	}
+	submissions, err := s.submissionRepo.GetAllByUserID(ctx, userCtx, userID)
+	if err != nil {
+		return err
+	}
+	var files []string
+	for _, submission := range submissions {
+		if submission.Questionnaire.Anonymous {
+			continue
+		}
+		for _, answer := range submission.Answers {
+			if answer.FilePath != "" {
+				files = append(files, answer.FilePath)
+			}
+		}
+		if err := s.submissionRepo.StripPersonalData(ctx, userCtx, submission.ID); err != nil {
+			return err
+		}
+	}
+
+	if err := s.userRepo.Anonymize(ctx, userCtx, userID); err != nil {
+		return err
+	}
+
+	// files cannot be restored, so they go last once everything else succeeded
+	for _, key := range files {
+		if err := s.fileStorage.Delete(ctx, key); err != nil {
+			logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+				Service: logmessages.LogAccountService,
+				Message: fmt.Sprintf("failed to delete answer file %s: %v", key, err),
+			})
+		}
+	}
+	return nil
+}
+
+// ArchiveQuestionnaire closes the owner's questionnaire for good. Its results stay
+// available.
+func (s *AccountService) ArchiveQuestionnaire(ctx context.Context, userCtx context.Context, userID, questionnaireID uuid.UUID) error {
+	qn, err := s.questionnaireRepo.GetById(ctx, userCtx, questionnaireID)
+	if err != nil {
+		return err
+	}
+	if qn.OwnerId != userID {
+		return apperrors.ErrLackOfAuthorization
+	}
+	if qn.ArchivedAt != nil {
+		return nil
+	}
+	return s.questionnaireRepo.Update(ctx, userCtx, questionnaireID, map[string]interface{}{
+		"archived_at": time.Now(),
+	})
+}
+
+// TransferQuestionnaire makes another user the owner of the questionnaire and lets
+// them know.
+func (s *AccountService) TransferQuestionnaire(ctx context.Context, userCtx context.Context, userID, questionnaireID uuid.UUID, newOwnerUsername string) error {
+	qn, err := s.questionnaireRepo.GetById(ctx, userCtx, questionnaireID)
+	if err != nil {
+		return err
+	}
+	if qn.OwnerId != userID {
+		return apperrors.ErrLackOfAuthorization
+	}
+
+	newOwner, err := s.userRepo.FindByUsername(ctx, userCtx, newOwnerUsername)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apperrors.ErrUserNotFound
+		}
+		return err
+	}
+	if newOwner.AnonymizedAt != nil {
+		return apperrors.ErrUserNotFound
+	}
+	if newOwner.ID == userID {
+		return apperrors.ErrTransferToSelf
+	}
+
+	err = s.questionnaireRepo.Update(ctx, userCtx, questionnaireID, map[string]interface{}{
+		"owner_id": newOwner.ID,
+	})
+	if err != nil {
+		return err
+	}
+	return s.userRepo.CreateNotification(ctx, userCtx, newOwner.ID, &model.Notification{
+		Message: fmt.Sprintf("You are now the owner of the questionnaire %q", qn.Title),
+	})
+}
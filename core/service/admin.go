@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"golizilla/adapters/persistence/logger"
 	"golizilla/core/domain/model"
 	"golizilla/core/port/repository"
 
@@ -13,15 +14,26 @@ type IAdminService interface {
 	GetAllQuestions(ctx, userCtx context.Context, page int, pageSize int) (PaginatedQuestions, error)
 	GetAllQuestionnaires(ctx, userCtx context.Context, page int, pageSize int) (PaginatedQuestionnaires, error)
 	GetAllRoles(ctx, userCtx context.Context, page int, pageSize int) (PaginatedRoles, error)
-	GetAnswersByUserIDAndQuestionnaireID(ctx, userCtx context.Context, userID, questionnaireID uuid.UUID, page, pageSize int) (PaginatedUserQuestionnaireAnswer, error)
+	GetAnswersByUserIDAndQuestionnaireID(ctx, userCtx context.Context, actorID, userID, questionnaireID uuid.UUID, page, pageSize int) (PaginatedUserQuestionnaireAnswer, error)
+	GetAccessLogs(ctx, userCtx context.Context, page int, pageSize int) (PaginatedAdminAccessLogs, error)
 }
 
 type AdminService struct {
-	adminRepo repository.IAdminRepository
+	adminRepo          repository.IAdminRepository
+	adminAccessLogRepo repository.IAdminAccessLogRepository
+	roleService        IRoleService
 }
 
-func NewAdminService(adminRepo repository.IAdminRepository) IAdminService {
-	return &AdminService{adminRepo: adminRepo}
+func NewAdminService(
+	adminRepo repository.IAdminRepository,
+	adminAccessLogRepo repository.IAdminAccessLogRepository,
+	roleService IRoleService,
+) IAdminService {
+	return &AdminService{
+		adminRepo:          adminRepo,
+		adminAccessLogRepo: adminAccessLogRepo,
+		roleService:        roleService,
+	}
 }
 
 type PaginatedUsers struct {
@@ -117,10 +129,16 @@ func (s *AdminService) GetAllQuestionnaires(
 	return result, nil
 }
 
+// RoleWithPrivileges is a role with its privileges, inherited ones included.
+type RoleWithPrivileges struct {
+	Role                model.Role
+	EffectivePrivileges []string
+}
+
 type PaginatedRoles struct {
-	Data  []model.Role `json:"data"`
-	Pages int          `json:"pages"`
-	Page  int          `json:"page"`
+	Data  []RoleWithPrivileges `json:"data"`
+	Pages int                  `json:"pages"`
+	Page  int                  `json:"page"`
 }
 
 func (s *AdminService) GetAllRoles(
@@ -138,9 +156,18 @@ func (s *AdminService) GetAllRoles(
 	// Calculate total pages based on total records and page size
 	totalPages := int((totalRecords + int64(pageSize) - 1) / int64(pageSize))
 
+	data := make([]RoleWithPrivileges, len(roles))
+	for i, role := range roles {
+		privileges, err := s.roleService.EffectivePrivileges(ctx, userCtx, role.ID)
+		if err != nil {
+			return PaginatedRoles{}, err
+		}
+		data[i] = RoleWithPrivileges{Role: role, EffectivePrivileges: privileges}
+	}
+
 	// Prepare the output struct
 	result := PaginatedRoles{
-		Data:  roles,
+		Data:  data,
 		Pages: totalPages,
 		Page:  page,
 	}
@@ -154,10 +181,23 @@ type PaginatedUserQuestionnaireAnswer struct {
 	Page  int            `json:"page"`
 }
 
+// GetAnswersByUserIDAndQuestionnaireID returns a page of the answers of a user.
+// The access is logged before any answer is returned.
 func (s *AdminService) GetAnswersByUserIDAndQuestionnaireID(
-	ctx, userCtx context.Context, userID, questionnaireID uuid.UUID, page, pageSize int,
+	ctx, userCtx context.Context, actorID, userID, questionnaireID uuid.UUID, page, pageSize int,
 ) (PaginatedUserQuestionnaireAnswer, error) {
 
+	accessLog := &model.AdminAccessLog{
+		ActorId:         actorID,
+		TargetUserId:    userID,
+		QuestionnaireId: &questionnaireID,
+		Action:          model.AdminAccessViewAnswers,
+	}
+	accessLog.TraceID, _ = ctx.Value(logger.TraceIDKey).(string)
+	if err := s.adminAccessLogRepo.Add(ctx, userCtx, accessLog); err != nil {
+		return PaginatedUserQuestionnaireAnswer{}, err
+	}
+
 	answers, totalRecords, err := s.adminRepo.GetAnswersByUserIDAndQuestionnaireID(
 		ctx, userCtx, userID, questionnaireID, page, pageSize)
 	if err != nil {
@@ -175,4 +215,33 @@ func (s *AdminService) GetAnswersByUserIDAndQuestionnaireID(
 	}
 
 	return result, nil
-}
\ No newline at end of file
+}
+
+type PaginatedAdminAccessLogs struct {
+	Data  []model.AdminAccessLog `json:"data"`
+	Pages int                    `json:"pages"`
+	Page  int                    `json:"page"`
+}
+
+// GetAccessLogs returns a page of the admin access trail, newest first.
+func (s *AdminService) GetAccessLogs(
+	ctx context.Context,
+	userCtx context.Context,
+	page int,
+	pageSize int,
+) (PaginatedAdminAccessLogs, error) {
+
+	accessLogs, totalRecords, err := s.adminAccessLogRepo.GetPage(ctx, userCtx, page, pageSize)
+	if err != nil {
+		return PaginatedAdminAccessLogs{}, err
+	}
+
+	// Calculate total pages based on total records and page size
+	totalPages := int((totalRecords + int64(pageSize) - 1) / int64(pageSize))
+
+	return PaginatedAdminAccessLogs{
+		Data:  accessLogs,
+		Pages: totalPages,
+		Page:  page,
+	}, nil
+}
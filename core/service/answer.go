@@ -4,39 +4,95 @@ import (
 	"context"
 	"golizilla/core/domain/model"
 	"golizilla/core/port/repository"
+	"golizilla/internal/apperrors"
 
 	"github.com/google/uuid"
 )
 
 type IAnswerService interface {
 	Create(ctx context.Context, userCtx context.Context, answer *model.Answer) (uuid.UUID, error)
-	Update(ctx context.Context, userCtx context.Context, answer *model.Answer) error
-	Delete(ctx context.Context, userCtx context.Context, id uuid.UUID) error
+	Update(ctx context.Context, userCtx context.Context, userID uuid.UUID, answer *model.Answer) error
+	Delete(ctx context.Context, userCtx context.Context, userID, id uuid.UUID) error
 	GetByID(ctx context.Context, userCtx context.Context, id uuid.UUID) (*model.Answer, error)
 }
 
 type AnswerService struct {
-	answerRepo repository.IAnswerRepository
+	answerRepo     repository.IAnswerRepository
+	submissionRepo repository.ISubmissionRepository
+	coreService    ICoreService
 }
 
-func NewAnswerService(repo repository.IAnswerRepository) IAnswerService {
+func NewAnswerService(
+	repo repository.IAnswerRepository,
+	submissionRepo repository.ISubmissionRepository,
+	coreService ICoreService,
+) IAnswerService {
 	return &AnswerService{
-		answerRepo: repo,
+		answerRepo:     repo,
+		submissionRepo: submissionRepo,
+		coreService:    coreService,
 	}
 }
 
+// Create stores an answer of the user in one of their in-progress submissions.
+// It is saved like a page with a single answer, so the question must be on the
+// current page and the answer must follow the same rules.
 func (s *AnswerService) Create(ctx context.Context, userCtx context.Context, answer *model.Answer) (uuid.UUID, error) {
-	return s.answerRepo.Create(ctx, userCtx, answer)
+	if _, err := s.checkSubmission(ctx, userCtx, answer.UserID, answer.UserSubmissionID); err != nil {
+		return uuid.Nil, err
+	}
+	if err := s.coreService.SubmitPage(ctx, userCtx, answer.UserID, answer.UserSubmissionID, []*model.Answer{answer}); err != nil {
+		return uuid.Nil, err
+	}
+	stored, err := s.answerRepo.GetBySubmissionAndQuestion(ctx, userCtx, answer.UserSubmissionID, answer.QuestionID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return stored.ID, nil
 }
 
-func (s *AnswerService) Update(ctx context.Context, userCtx context.Context, answer *model.Answer) error {
-	return s.answerRepo.Update(ctx, userCtx, answer)
+// Update changes an answer of the user while its question is on the current page.
+func (s *AnswerService) Update(ctx context.Context, userCtx context.Context, userID uuid.UUID, answer *model.Answer) error {
+	if answer.UserID != userID {
+		return apperrors.ErrLackOfAuthorization
+	}
+	if _, err := s.checkSubmission(ctx, userCtx, userID, answer.UserSubmissionID); err != nil {
+		return err
+	}
+	return s.coreService.SubmitPage(ctx, userCtx, userID, answer.UserSubmissionID, []*model.Answer{answer})
 }
 
-func (s *AnswerService) Delete(ctx context.Context, userCtx context.Context, id uuid.UUID) error {
-	return s.answerRepo.Delete(ctx, userCtx, id)
+// Delete removes an answer of the user while its question is on the current page.
+func (s *AnswerService) Delete(ctx context.Context, userCtx context.Context, userID, id uuid.UUID) error {
+	answer, err := s.answerRepo.GetByID(ctx, userCtx, id)
+	if err != nil {
+		return err
+	}
+	if answer.UserID != userID {
+		return apperrors.ErrLackOfAuthorization
+	}
+	if _, err := s.checkSubmission(ctx, userCtx, userID, answer.UserSubmissionID); err != nil {
+		return err
+	}
+	return s.coreService.DeleteAnswer(ctx, userCtx, answer)
 }
 
 func (s *AnswerService) GetByID(ctx context.Context, userCtx context.Context, id uuid.UUID) (*model.Answer, error) {
 	return s.answerRepo.GetByID(ctx, userCtx, id)
 }
+
+// checkSubmission returns the submission if it belongs to the user and is still
+// in progress.
+func (s *AnswerService) checkSubmission(ctx context.Context, userCtx context.Context, userID, submissionID uuid.UUID) (*model.UserSubmission, error) {
+	submission, err := s.submissionRepo.GetSubmissionByID(ctx, userCtx, submissionID)
+	if err != nil {
+		return nil, err
+	}
+	if submission.UserId != userID {
+		return nil, apperrors.ErrLackOfAuthorization
+	}
+	if submission.Status != model.SubmissionsStatusInProgress {
+		return nil, apperrors.ErrSubmissionNotInProgress
+	}
+	return submission, nil
+}
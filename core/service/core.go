@@ -2,24 +2,42 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	myContext "golizilla/adapters/http/handler/context"
 	"golizilla/adapters/persistence/logger"
 	"golizilla/core/domain/model"
 	"golizilla/core/port/repository"
+	"golizilla/core/port/scanner"
+	"golizilla/core/port/storage"
 	"golizilla/internal/apperrors"
 	logmessages "golizilla/internal/logmessages"
+	"io"
+	"slices"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 )
 
 type ICoreService interface {
-	Start(ctx context.Context, userCtx context.Context, userID, questionnaireID uuid.UUID) (uuid.UUID, *model.Question, error)
+	Start(ctx context.Context, userCtx context.Context, userID, questionnaireID uuid.UUID, variables map[string]string) (uuid.UUID, *model.Question, error)
 	Submit(ctx context.Context, userCtx context.Context, submissionID, questionID uuid.UUID, answer *model.Answer) error
-	Back(ctx context.Context, userCtx context.Context, submissionID uuid.UUID) (*model.Question, error)
-	Next(ctx context.Context, userCtx context.Context, submissionID uuid.UUID) (*model.Question, error)
+	Back(ctx context.Context, userCtx context.Context, userID, submissionID uuid.UUID) (*model.Question, error)
+	Next(ctx context.Context, userCtx context.Context, userID, submissionID uuid.UUID) (*model.Question, error)
 	End(ctx context.Context, userCtx context.Context, submissionID uuid.UUID) error
 	CheckExpire(ctx context.Context, userCtx context.Context, submissionID uuid.UUID) error
+	SubmitFile(ctx context.Context, userCtx context.Context, userID, submissionID, questionID uuid.UUID, fileName string, size int64, body io.ReadSeeker) error
+	DeleteSubmission(ctx context.Context, userCtx context.Context, userID, submissionID uuid.UUID) error
+	TimeRemaining(ctx context.Context, userCtx context.Context, submissionID uuid.UUID, question *model.Question) (*time.Duration, error)
+	GetPage(ctx context.Context, userCtx context.Context, userID, submissionID uuid.UUID) (*Page, error)
+	SubmitPage(ctx context.Context, userCtx context.Context, userID, submissionID uuid.UUID, answers []*model.Answer) error
+	DeleteAnswer(ctx context.Context, userCtx context.Context, answer *model.Answer) error
+	NextPage(ctx context.Context, userCtx context.Context, userID, submissionID uuid.UUID) (*Page, error)
+	BackPage(ctx context.Context, userCtx context.Context, userID, submissionID uuid.UUID) (*Page, error)
+	RenderQuestion(ctx context.Context, userCtx context.Context, userID, submissionID uuid.UUID, question *model.Question) error
+	SubmitOffline(ctx context.Context, userCtx context.Context, userID uuid.UUID, offline *OfflineSubmission) (*model.UserSubmission, bool, error)
 }
 
 type CoreService struct {
@@ -27,6 +45,17 @@ type CoreService struct {
 	submissionRepo    repository.ISubmissionRepository
 	questionnaireRepo repository.IQuestionnaireRepository
 	answerRepo        repository.IAnswerRepository
+	submitSlotRepo    repository.ISubmitSlotRepository
+	questionVisitRepo repository.IQuestionVisitRepository
+	questionPoolRepo  repository.IQuestionPoolRepository
+	sectionRepo       repository.ISectionRepository
+	eligibility       IEligibilityService
+	fileStorage       storage.IFileStorage
+	virusScanner      scanner.IVirusScanner
+	fileMaxSize       int64
+	fileAllowedTypes  []string
+	textMaxLength     int
+	clockTolerance    time.Duration
 }
 
 func NewCoreService(
@@ -34,16 +63,40 @@ func NewCoreService(
 	submissionRepo repository.ISubmissionRepository,
 	questionnaireRepo repository.IQuestionnaireRepository,
 	answerRepo repository.IAnswerRepository,
+	submitSlotRepo repository.ISubmitSlotRepository,
+	questionVisitRepo repository.IQuestionVisitRepository,
+	questionPoolRepo repository.IQuestionPoolRepository,
+	sectionRepo repository.ISectionRepository,
+	eligibility IEligibilityService,
+	fileStorage storage.IFileStorage,
+	virusScanner scanner.IVirusScanner,
+	fileMaxSize int64,
+	fileAllowedTypes []string,
+	textMaxLength int,
+	clockTolerance time.Duration,
 ) ICoreService {
 	return &CoreService{
 		questionRepo:      questionRepo,
 		submissionRepo:    submissionRepo,
 		questionnaireRepo: questionnaireRepo,
 		answerRepo:        answerRepo,
+		submitSlotRepo:    submitSlotRepo,
+		questionVisitRepo: questionVisitRepo,
+		questionPoolRepo:  questionPoolRepo,
+		sectionRepo:       sectionRepo,
+		eligibility:       eligibility,
+		fileStorage:       fileStorage,
+		virusScanner:      virusScanner,
+		fileMaxSize:       fileMaxSize,
+		fileAllowedTypes:  fileAllowedTypes,
+		textMaxLength:     textMaxLength,
+		clockTolerance:    clockTolerance,
 	}
 }
 
-func (c *CoreService) Start(ctx context.Context, userCtx context.Context, userID, questionnaireID uuid.UUID) (uuid.UUID, *model.Question, error) {
+// Start creates a submission storing the hidden variables supplied with it and
+// returns the first question.
+func (c *CoreService) Start(ctx context.Context, userCtx context.Context, userID, questionnaireID uuid.UUID, variables map[string]string) (uuid.UUID, *model.Question, error) {
 	qn, err := c.questionnaireRepo.GetById(ctx, userCtx, questionnaireID)
 	if err != nil {
 		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
@@ -60,24 +113,13 @@ func (c *CoreService) Start(ctx context.Context, userCtx context.Context, userID
 		return uuid.Nil, nil, apperrors.ErrQuestionnaireNotFound
 	}
 
-	// check limit on submission
-	if qn.SubmitLimit != 0 {
+	if err := c.eligibility.Check(ctx, userCtx, userID, qn); err != nil {
+		return uuid.Nil, nil, err
+	}
 
-		submitCountOk, err := c.submissionRepo.SubmitCount(ctx, userCtx, userID, questionnaireID, qn.SubmitLimit)
-		if err != nil {
-			logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
-				Service: logmessages.LogCoreService,
-				Message: fmt.Sprintf("failed to get submit count: %v", err.Error()),
-			})
-			return uuid.Nil, nil, fmt.Errorf("failed to get submit count: %w", err)
-		}
-		if !submitCountOk {
-			logger.GetLogger().LogWarningFromContext(ctx, logger.LogFields{
-				Service: logmessages.LogCoreService,
-				Message: apperrors.ErrSubmissionLimit.Error(),
-			})
-			return uuid.Nil, nil, apperrors.ErrSubmissionLimit
-		}
+	// check limit on submission
+	if err := c.checkSubmitLimit(ctx, userCtx, userID, qn); err != nil {
+		return uuid.Nil, nil, err
 	}
 
 	// Create new submission
@@ -87,6 +129,13 @@ func (c *CoreService) Start(ctx context.Context, userCtx context.Context, userID
 		QuestionnaireId: questionnaireID,
 		Status:          model.SubmissionsStatusInProgress,
 	}
+	for name, value := range variables {
+		submission.Variables = append(submission.Variables, model.SubmissionVariable{
+			UserSubmissionID: submission.ID,
+			Name:             name,
+			Value:            value,
+		})
+	}
 	if err := c.submissionRepo.CreateSubmission(ctx, userCtx, submission); err != nil {
 		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
 			Service: logmessages.LogCoreService,
@@ -103,6 +152,15 @@ func (c *CoreService) Start(ctx context.Context, userCtx context.Context, userID
 		})
 		return uuid.Nil, nil, err
 	}
+	pools, err := c.questionPoolRepo.GetByQuestionnaireID(ctx, userCtx, questionnaireID)
+	if err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogCoreService,
+			Message: fmt.Sprintf("failed to get question pools: %v", err.Error()),
+		})
+		return uuid.Nil, nil, err
+	}
+	questions = groupSections(drawQuestions(questions, pools))
 	if len(questions) == 0 {
 		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
 			Service: logmessages.LogCoreService,
@@ -112,6 +170,14 @@ func (c *CoreService) Start(ctx context.Context, userCtx context.Context, userID
 	}
 
 	submission.CurrentQuestionIndex = 0
+	submission.DrawnQuestions = make([]model.SubmissionQuestion, len(questions))
+	for i, question := range questions {
+		submission.DrawnQuestions[i] = model.SubmissionQuestion{
+			UserSubmissionID: submission.ID,
+			QuestionID:       question.ID,
+			Position:         i,
+		}
+	}
 	if err := c.submissionRepo.UpdateSubmission(ctx, userCtx, submission); err != nil {
 		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
 			Service: logmessages.LogCoreService,
@@ -119,19 +185,124 @@ func (c *CoreService) Start(ctx context.Context, userCtx context.Context, userID
 		})
 		return submission.ID, nil, err
 	}
+	if err := c.enterPage(ctx, userCtx, submission, questions, 0); err != nil {
+		return submission.ID, nil, err
+	}
 
 	return submission.ID, questions[0], nil
 }
 
+// checkSubmitLimit makes sure the user may submit the questionnaire once more.
+func (c *CoreService) checkSubmitLimit(ctx context.Context, userCtx context.Context, userID uuid.UUID, qn *model.Questionnaire) error {
+	if qn.SubmitLimit == 0 {
+		return nil
+	}
+
+	// a parallel start or slot listing must not use the same free slot
+	if err := c.submitSlotRepo.LockSlots(ctx, userCtx, userID, qn.Id); err != nil {
+		return fmt.Errorf("failed to lock submit slots: %w", err)
+	}
+
+	// bought and sold slots move the limit of this user
+	slotDelta, err := c.submitSlotRepo.GetSlotDelta(ctx, userCtx, userID, qn.Id)
+	if err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogCoreService,
+			Message: fmt.Sprintf("failed to get submit slot delta: %v", err.Error()),
+		})
+		return fmt.Errorf("failed to get submit slot delta: %w", err)
+	}
+	submitLimit := int(qn.SubmitLimit) + slotDelta
+	if submitLimit <= 0 {
+		return apperrors.ErrSubmissionLimit
+	}
+
+	submitCountOk, err := c.submissionRepo.SubmitCount(ctx, userCtx, userID, qn.Id, uint(submitLimit))
+	if err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogCoreService,
+			Message: fmt.Sprintf("failed to get submit count: %v", err.Error()),
+		})
+		return fmt.Errorf("failed to get submit count: %w", err)
+	}
+	if !submitCountOk {
+		logger.GetLogger().LogWarningFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogCoreService,
+			Message: apperrors.ErrSubmissionLimit.Error(),
+		})
+		return apperrors.ErrSubmissionLimit
+	}
+	return nil
+}
+
 func (c *CoreService) Submit(ctx context.Context, userCtx context.Context, submissionID, questionID uuid.UUID, answer *model.Answer) error {
+	submission, currentQuestion, err := c.getCurrentQuestion(ctx, userCtx, submissionID, questionID)
+	if err != nil {
+		return err
+	}
+	if currentQuestion.FileUpload || currentQuestion.Descriptive != answer.Descriptive {
+		return apperrors.ErrAnswerKindMismatch
+	}
+	if answer.Descriptive {
+		if err := c.checkAnswerText(currentQuestion, answer.Text); err != nil {
+			return err
+		}
+	}
+
+	answer.QuestionID = questionID
+	if err := c.answerRepo.Replace(ctx, userCtx, answer); err != nil {
+		return err
+	}
+	if err := c.questionVisitRepo.MarkAnswered(ctx, userCtx, submissionID, questionID, time.Now()); err != nil {
+		return err
+	}
+
+	return c.submissionRepo.UpdateSubmission(ctx, userCtx, submission)
+}
+
+// getCurrentQuestion loads an in-progress, unexpired submission and makes sure
+// questionID is on the page the respondent is currently on.
+func (c *CoreService) getCurrentQuestion(ctx context.Context, userCtx context.Context, submissionID, questionID uuid.UUID) (*model.UserSubmission, *model.Question, error) {
+	submission, questions, err := c.getActiveSubmission(ctx, userCtx, submissionID)
+	if err != nil {
+		return nil, nil, err
+	}
+	// the answer was meant for the page whose time ran out
+	if moved, err := c.advanceExpired(ctx, userCtx, submission, questions); err != nil || moved {
+		if err == nil {
+			err = apperrors.ErrQuestionTimeUp
+		}
+		return nil, nil, err
+	}
+
+	start, end := pageBounds(questions, submission.CurrentQuestionIndex)
+	index := slices.IndexFunc(questions[start:end], func(question *model.Question) bool { return question.ID == questionID })
+	if index < 0 {
+		return nil, nil, apperrors.ErrSubmissionNotFoundQuestion
+	}
+	currentQuestion := questions[start+index]
+
+	timeUp, err := c.isTimeUp(ctx, userCtx, submissionID, currentQuestion)
+	if err != nil {
+		return nil, nil, err
+	}
+	if timeUp {
+		return nil, nil, apperrors.ErrQuestionTimeUp
+	}
 
+	return submission, currentQuestion, nil
+}
+
+// getActiveSubmission loads an in-progress, unexpired submission with the questions
+// drawn for it.
+func (c *CoreService) getActiveSubmission(ctx context.Context, userCtx context.Context, submissionID uuid.UUID) (*model.UserSubmission, []*model.Question, error) {
 	submission, err := c.submissionRepo.GetSubmissionByID(ctx, userCtx, submissionID)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 
 	if submission.Status != model.SubmissionsStatusInProgress {
-		return apperrors.ErrSubmissionNotInProgress
+		return nil, nil, apperrors.ErrSubmissionNotInProgress
 	}
 
 	qn, err := c.questionnaireRepo.GetById(ctx, userCtx, submission.QuestionnaireId)
@@ -140,90 +311,113 @@ func (c *CoreService) Submit(ctx context.Context, userCtx context.Context, submi
 			Service: logmessages.LogCoreService,
 			Message: fmt.Sprintf("failed to get questionnaire: %v", err.Error()),
 		})
-		return fmt.Errorf("failed to get questionnaire: %w", err)
+		return nil, nil, fmt.Errorf("failed to get questionnaire: %w", err)
 	}
 	if qn == nil {
 		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
 			Service: logmessages.LogCoreService,
 			Message: apperrors.ErrQuestionnaireNotFound.Error(),
 		})
-		return apperrors.ErrQuestionnaireNotFound
+		return nil, nil, apperrors.ErrQuestionnaireNotFound
 	}
 
 	if time.Since(submission.CreatedAt).Minutes() > float64(qn.AnswerTime) {
-		return apperrors.ErrQuestionnareExpired
+		return nil, nil, apperrors.ErrQuestionnareExpired
 	}
 
-	questions, err := c.getQuestionsForQuestionnaire(ctx, userCtx, submission.QuestionnaireId)
+	questions, err := c.getQuestionsForSubmission(ctx, userCtx, submission)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 	if submission.CurrentQuestionIndex < 0 || submission.CurrentQuestionIndex >= len(questions) {
-		return apperrors.ErrSubmissionNoQuestion
-	}
-
-	currentQuestion := questions[submission.CurrentQuestionIndex]
-	if currentQuestion.ID != questionID {
-		return apperrors.ErrSubmissionNotFoundQuestion
+		return nil, nil, apperrors.ErrSubmissionNoQuestion
 	}
-
-	answer.QuestionID = questionID
-	_, err = c.answerRepo.Create(ctx, userCtx, answer)
-	if err != nil {
-		return err
-	}
-
-	return c.submissionRepo.UpdateSubmission(ctx, userCtx, submission)
+	return submission, questions, nil
 }
 
-func (c *CoreService) Back(ctx context.Context, userCtx context.Context, submissionID uuid.UUID) (*model.Question, error) {
+func (c *CoreService) Back(ctx context.Context, userCtx context.Context, userID, submissionID uuid.UUID) (*model.Question, error) {
 	submission, err := c.submissionRepo.GetSubmissionByID(ctx, userCtx, submissionID)
 	if err != nil {
 		return nil, err
 	}
+	if submission.UserId != userID {
+		return nil, apperrors.ErrLackOfAuthorization
+	}
 
-	// TODO: Check questionnaire settings if back is allowed. For now, we assume allowed.
 	if submission.CurrentQuestionIndex > 0 {
-		submission.CurrentQuestionIndex--
-		if err := c.submissionRepo.UpdateSubmission(ctx, userCtx, submission); err != nil {
+		questions, err := c.getQuestionsForSubmission(ctx, userCtx, submission)
+		if err != nil {
 			return nil, err
 		}
 
-		questionnare, err := c.questionnaireRepo.GetById(ctx, userCtx, submission.QuestionnaireId)
+		index, err := c.previousQuestion(ctx, userCtx, submission, questions, submission.CurrentQuestionIndex)
 		if err != nil {
 			return nil, err
 		}
-		if !questionnare.BackCompatible {
-			return nil, apperrors.ErrBackIsNotAllowed
+		if index < 0 {
+			return nil, fmt.Errorf("cannot go back")
 		}
 
-		questions, err := c.getQuestionsForQuestionnaire(ctx, userCtx, submission.QuestionnaireId)
-		if err != nil {
+		// BackCompatible applies to pages, moving within a page is always allowed
+		start, _ := pageBounds(questions, submission.CurrentQuestionIndex)
+		if index < start {
+			if err := c.checkBackAllowed(ctx, userCtx, submission); err != nil {
+				return nil, err
+			}
+		}
+
+		submission.CurrentQuestionIndex = index
+		if err := c.submissionRepo.UpdateSubmission(ctx, userCtx, submission); err != nil {
 			return nil, err
 		}
-		return c.questionRepo.GetByID(ctx, userCtx, questions[submission.CurrentQuestionIndex].ID)
+		if index < start {
+			if err := c.enterPage(ctx, userCtx, submission, questions, index); err != nil {
+				return nil, err
+			}
+		}
+		return c.questionRepo.GetByID(ctx, userCtx, questions[index].ID)
 	}
 
 	return nil, fmt.Errorf("cannot go back")
 }
 
-func (c *CoreService) Next(ctx context.Context, userCtx context.Context, submissionID uuid.UUID) (*model.Question, error) {
+func (c *CoreService) Next(ctx context.Context, userCtx context.Context, userID, submissionID uuid.UUID) (*model.Question, error) {
 	submission, err := c.submissionRepo.GetSubmissionByID(ctx, userCtx, submissionID)
 	if err != nil {
 		return nil, err
 	}
+	if submission.UserId != userID {
+		return nil, apperrors.ErrLackOfAuthorization
+	}
 
-	questions, err := c.getQuestionsForQuestionnaire(ctx, userCtx, submission.QuestionnaireId)
+	questions, err := c.getQuestionsForSubmission(ctx, userCtx, submission)
 	if err != nil {
 		return nil, err
 	}
 
-	if submission.CurrentQuestionIndex+1 < len(questions) {
-		submission.CurrentQuestionIndex++
+	next := submission.CurrentQuestionIndex + 1
+	_, end := pageBounds(questions, submission.CurrentQuestionIndex)
+	if next == end {
+		if err := c.checkRequiredAnswered(ctx, userCtx, submission, questions, submission.CurrentQuestionIndex); err != nil {
+			return nil, err
+		}
+		// leaving the page, follow the branching of its answers
+		if next, err = c.pageExit(ctx, userCtx, submission, questions, submission.CurrentQuestionIndex); err != nil {
+			return nil, err
+		}
+	}
+
+	if next < len(questions) {
+		submission.CurrentQuestionIndex = next
 		if err := c.submissionRepo.UpdateSubmission(ctx, userCtx, submission); err != nil {
 			return nil, err
 		}
-		return c.questionRepo.GetByID(ctx, userCtx, questions[submission.CurrentQuestionIndex].ID)
+		if next >= end {
+			if err := c.enterPage(ctx, userCtx, submission, questions, next); err != nil {
+				return nil, err
+			}
+		}
+		return c.questionRepo.GetByID(ctx, userCtx, questions[next].ID)
 	}
 
 	return nil, fmt.Errorf("no more questions")
@@ -236,9 +430,424 @@ func (c *CoreService) End(ctx context.Context, userCtx context.Context, submissi
 	}
 
 	submission.Status = model.SubmissionsStatusDone
+	if err := c.submissionRepo.UpdateSubmission(ctx, userCtx, submission); err != nil {
+		return err
+	}
+	return c.questionVisitRepo.CloseOpen(ctx, userCtx, submissionID, time.Now())
+}
+
+// Page is what a respondent answers at once: the questions of one section, or a
+// question outside any section on its own.
+type Page struct {
+	Number    int // 1-based
+	Total     int
+	Section   *model.Section
+	Questions []*model.Question
+}
+
+// GetPage returns the page the respondent is currently on.
+func (c *CoreService) GetPage(ctx context.Context, userCtx context.Context, userID, submissionID uuid.UUID) (*Page, error) {
+	submission, questions, err := c.getActiveSubmission(ctx, userCtx, submissionID)
+	if err != nil {
+		return nil, err
+	}
+	if submission.UserId != userID {
+		return nil, apperrors.ErrLackOfAuthorization
+	}
+	if _, err := c.advanceExpired(ctx, userCtx, submission, questions); err != nil {
+		return nil, err
+	}
+	return c.buildPage(ctx, userCtx, questions, submission.CurrentQuestionIndex)
+}
+
+// SubmitPage saves the answers to the questions of the current page, replacing
+// those given before. Every answer is checked first, so either all of them are
+// saved or none.
+func (c *CoreService) SubmitPage(ctx context.Context, userCtx context.Context, userID, submissionID uuid.UUID, answers []*model.Answer) error {
+	submission, questions, err := c.getActiveSubmission(ctx, userCtx, submissionID)
+	if err != nil {
+		return err
+	}
+	if submission.UserId != userID {
+		return apperrors.ErrLackOfAuthorization
+	}
+	// the answers were meant for the page whose time ran out
+	if moved, err := c.advanceExpired(ctx, userCtx, submission, questions); err != nil || moved {
+		if err == nil {
+			err = apperrors.ErrQuestionTimeUp
+		}
+		return err
+	}
+
+	start, end := pageBounds(questions, submission.CurrentQuestionIndex)
+	page := questions[start:end]
+	answered := make(map[uuid.UUID]bool, len(answers))
+	for _, answer := range answers {
+		index := slices.IndexFunc(page, func(question *model.Question) bool { return question.ID == answer.QuestionID })
+		if index < 0 || answered[answer.QuestionID] {
+			return apperrors.ErrPageAnswerNotOnPage
+		}
+		answered[answer.QuestionID] = true
+
+		if err := c.checkPageAnswer(ctx, userCtx, submissionID, page[index], answer); err != nil {
+			return fmt.Errorf("question %s: %w", answer.QuestionID, err)
+		}
+	}
+
+	now := time.Now()
+	for _, answer := range answers {
+		answer.UserID = userID
+		answer.UserSubmissionID = submissionID
+		if err := c.answerRepo.Replace(ctx, userCtx, answer); err != nil {
+			return err
+		}
+		if err := c.questionVisitRepo.MarkAnswered(ctx, userCtx, submissionID, answer.QuestionID, now); err != nil {
+			return err
+		}
+	}
 	return c.submissionRepo.UpdateSubmission(ctx, userCtx, submission)
 }
 
+// DeleteAnswer removes an answer to a question of the current page whose time is
+// not up yet.
+func (c *CoreService) DeleteAnswer(ctx context.Context, userCtx context.Context, answer *model.Answer) error {
+	if _, _, err := c.getCurrentQuestion(ctx, userCtx, answer.UserSubmissionID, answer.QuestionID); err != nil {
+		return err
+	}
+	return c.answerRepo.Delete(ctx, userCtx, answer.ID)
+}
+
+// NextPage moves to the page after the current one, or to the section an answer
+// of the current page branches to.
+func (c *CoreService) NextPage(ctx context.Context, userCtx context.Context, userID, submissionID uuid.UUID) (*Page, error) {
+	submission, questions, err := c.getActiveSubmission(ctx, userCtx, submissionID)
+	if err != nil {
+		return nil, err
+	}
+	if submission.UserId != userID {
+		return nil, apperrors.ErrLackOfAuthorization
+	}
+
+	if err := c.checkRequiredAnswered(ctx, userCtx, submission, questions, submission.CurrentQuestionIndex); err != nil {
+		return nil, err
+	}
+	next, err := c.pageExit(ctx, userCtx, submission, questions, submission.CurrentQuestionIndex)
+	if err != nil {
+		return nil, err
+	}
+	if next >= len(questions) {
+		return nil, apperrors.ErrNoMorePages
+	}
+
+	submission.CurrentQuestionIndex = next
+	if err := c.submissionRepo.UpdateSubmission(ctx, userCtx, submission); err != nil {
+		return nil, err
+	}
+	if err := c.enterPage(ctx, userCtx, submission, questions, next); err != nil {
+		return nil, err
+	}
+	return c.buildPage(ctx, userCtx, questions, next)
+}
+
+// BackPage returns to the page the respondent saw before the current one, if the
+// questionnaire allows going back.
+func (c *CoreService) BackPage(ctx context.Context, userCtx context.Context, userID, submissionID uuid.UUID) (*Page, error) {
+	submission, questions, err := c.getActiveSubmission(ctx, userCtx, submissionID)
+	if err != nil {
+		return nil, err
+	}
+	if submission.UserId != userID {
+		return nil, apperrors.ErrLackOfAuthorization
+	}
+	if err := c.checkBackAllowed(ctx, userCtx, submission); err != nil {
+		return nil, err
+	}
+
+	start, _ := pageBounds(questions, submission.CurrentQuestionIndex)
+	previous, err := c.previousQuestion(ctx, userCtx, submission, questions, start)
+	if err != nil {
+		return nil, err
+	}
+	if previous < 0 {
+		return nil, fmt.Errorf("cannot go back")
+	}
+	previous, _ = pageBounds(questions, previous)
+
+	submission.CurrentQuestionIndex = previous
+	if err := c.submissionRepo.UpdateSubmission(ctx, userCtx, submission); err != nil {
+		return nil, err
+	}
+	if err := c.enterPage(ctx, userCtx, submission, questions, previous); err != nil {
+		return nil, err
+	}
+	return c.buildPage(ctx, userCtx, questions, previous)
+}
+
+// checkPageAnswer validates one answer of a page against its question.
+func (c *CoreService) checkPageAnswer(ctx context.Context, userCtx context.Context, submissionID uuid.UUID, question *model.Question, answer *model.Answer) error {
+	timeUp, err := c.isTimeUp(ctx, userCtx, submissionID, question)
+	if err != nil {
+		return err
+	}
+	if timeUp {
+		return apperrors.ErrQuestionTimeUp
+	}
+	return c.checkAnswer(ctx, userCtx, question, answer)
+}
+
+// checkAnswer validates the text or the option of a text or choice answer.
+func (c *CoreService) checkAnswer(ctx context.Context, userCtx context.Context, question *model.Question, answer *model.Answer) error {
+	if question.FileUpload || question.Descriptive != answer.Descriptive {
+		return apperrors.ErrAnswerKindMismatch
+	}
+	if answer.Descriptive {
+		return c.checkAnswerText(question, answer.Text)
+	}
+	full, err := c.questionRepo.GetByID(ctx, userCtx, question.ID)
+	if err != nil {
+		return err
+	}
+	if answer.OptionID == nil || !slices.ContainsFunc(full.Options, func(option model.Option) bool { return option.ID == *answer.OptionID }) {
+		return apperrors.ErrOptionNotFound
+	}
+	return nil
+}
+
+// buildPage loads the section and the full questions of the page of questions[index].
+func (c *CoreService) buildPage(ctx context.Context, userCtx context.Context, questions []*model.Question, index int) (*Page, error) {
+	start, end := pageBounds(questions, index)
+	page := &Page{Questions: make([]*model.Question, 0, end-start)}
+	for i := 0; i < len(questions); i = pageEnd(questions, i) {
+		page.Total++
+		if i <= start {
+			page.Number = page.Total
+		}
+	}
+
+	if sectionID := questions[start].SectionID; sectionID != nil {
+		section, err := c.sectionRepo.GetByID(ctx, userCtx, *sectionID)
+		if err != nil {
+			return nil, err
+		}
+		section.Questions = nil
+		page.Section = section
+	}
+	for _, question := range questions[start:end] {
+		full, err := c.questionRepo.GetByID(ctx, userCtx, question.ID)
+		if err != nil {
+			return nil, err
+		}
+		page.Questions = append(page.Questions, full)
+	}
+	return page, nil
+}
+
+// pageExit returns where the respondent goes after the page of questions[index]:
+// the first question of the section the page branches to, or the next page. Only
+// jumps forward are followed, and the last answered option with a jump wins.
+func (c *CoreService) pageExit(ctx context.Context, userCtx context.Context, submission *model.UserSubmission, questions []*model.Question, index int) (int, error) {
+	start, end := pageBounds(questions, index)
+
+	chosen := make(map[uuid.UUID]uuid.UUID, len(submission.Answers))
+	for _, answer := range submission.Answers {
+		if answer.OptionID != nil {
+			chosen[answer.QuestionID] = *answer.OptionID
+		}
+	}
+
+	var target *uuid.UUID
+	for _, question := range questions[start:end] {
+		optionID, ok := chosen[question.ID]
+		if !ok {
+			continue
+		}
+		full, err := c.questionRepo.GetByID(ctx, userCtx, question.ID)
+		if err != nil {
+			return 0, err
+		}
+		for _, option := range full.Options {
+			if option.ID == optionID && option.JumpSectionID != nil {
+				target = option.JumpSectionID
+			}
+		}
+	}
+
+	if target != nil {
+		for i := end; i < len(questions); i++ {
+			if questions[i].SectionID != nil && *questions[i].SectionID == *target {
+				return i, nil
+			}
+		}
+	}
+	return end, nil
+}
+
+// checkRequiredAnswered keeps the respondent on the page of questions[index] until
+// its required questions are answered. Questions whose time is up no longer hold
+// the respondent back.
+func (c *CoreService) checkRequiredAnswered(ctx context.Context, userCtx context.Context, submission *model.UserSubmission, questions []*model.Question, index int) error {
+	start, end := pageBounds(questions, index)
+	for _, question := range questions[start:end] {
+		if !question.Required || slices.ContainsFunc(submission.Answers, func(answer model.Answer) bool { return answer.QuestionID == question.ID }) {
+			continue
+		}
+		timeUp, err := c.isTimeUp(ctx, userCtx, submission.ID, question)
+		if err != nil {
+			return err
+		}
+		if !timeUp {
+			return fmt.Errorf("question %s: %w", question.ID, apperrors.ErrRequiredQuestionUnanswered)
+		}
+	}
+	return nil
+}
+
+// advanceExpired moves the submission past every page on which an "advance"
+// question ran out of time, following the branching of the page like Next does.
+// The last page is kept, the respondent can only end the submission from there.
+// It reports whether the submission moved.
+func (c *CoreService) advanceExpired(ctx context.Context, userCtx context.Context, submission *model.UserSubmission, questions []*model.Question) (bool, error) {
+	moved := false
+	for {
+		expired, err := c.hasExpiredAdvance(ctx, userCtx, submission.ID, questions, submission.CurrentQuestionIndex)
+		if err != nil {
+			return false, err
+		}
+		if !expired {
+			break
+		}
+		next, err := c.pageExit(ctx, userCtx, submission, questions, submission.CurrentQuestionIndex)
+		if err != nil {
+			return false, err
+		}
+		if next >= len(questions) {
+			break
+		}
+		submission.CurrentQuestionIndex = next
+		if err := c.enterPage(ctx, userCtx, submission, questions, next); err != nil {
+			return false, err
+		}
+		moved = true
+	}
+
+	if moved {
+		if err := c.submissionRepo.UpdateSubmission(ctx, userCtx, submission); err != nil {
+			return false, err
+		}
+	}
+	return moved, nil
+}
+
+// hasExpiredAdvance reports whether an "advance" question on the page of
+// questions[index] ran out of time.
+func (c *CoreService) hasExpiredAdvance(ctx context.Context, userCtx context.Context, submissionID uuid.UUID, questions []*model.Question, index int) (bool, error) {
+	start, end := pageBounds(questions, index)
+	for _, question := range questions[start:end] {
+		if question.TimeLimitAction != model.TimeLimitActionAdvance {
+			continue
+		}
+		timeUp, err := c.isTimeUp(ctx, userCtx, submissionID, question)
+		if err != nil || timeUp {
+			return timeUp, err
+		}
+	}
+	return false, nil
+}
+
+// previousQuestion returns the index of the last question before from that the
+// respondent may return to, or -1. Questions skipped by branching and questions
+// that advanced on their time limit are passed over.
+func (c *CoreService) previousQuestion(ctx context.Context, userCtx context.Context, submission *model.UserSubmission, questions []*model.Question, from int) (int, error) {
+	visited, err := c.questionVisitRepo.GetVisitedQuestionIDs(ctx, userCtx, submission.ID)
+	if err != nil {
+		return 0, err
+	}
+	for index := from - 1; index >= 0; index-- {
+		question := questions[index]
+		// submissions started before visits were recorded have none
+		if len(visited) > 0 && !slices.Contains(visited, question.ID) {
+			continue
+		}
+		if question.TimeLimitAction == model.TimeLimitActionAdvance {
+			timeUp, err := c.isTimeUp(ctx, userCtx, submission.ID, question)
+			if err != nil {
+				return 0, err
+			}
+			if timeUp {
+				continue
+			}
+		}
+		return index, nil
+	}
+	return -1, nil
+}
+
+func (c *CoreService) checkBackAllowed(ctx context.Context, userCtx context.Context, submission *model.UserSubmission) error {
+	questionnare, err := c.questionnaireRepo.GetById(ctx, userCtx, submission.QuestionnaireId)
+	if err != nil {
+		return err
+	}
+	if !questionnare.BackCompatible {
+		return apperrors.ErrBackIsNotAllowed
+	}
+	return nil
+}
+
+// groupSections moves the questions of a section next to the first of them, so
+// every page is a run of consecutive questions.
+func groupSections(questions []*model.Question) []*model.Question {
+	grouped := make([]*model.Question, 0, len(questions))
+	placed := make(map[uuid.UUID]bool)
+	for _, question := range questions {
+		if question.SectionID == nil {
+			grouped = append(grouped, question)
+			continue
+		}
+		if placed[*question.SectionID] {
+			continue
+		}
+		placed[*question.SectionID] = true
+		for _, member := range questions {
+			if member.SectionID != nil && *member.SectionID == *question.SectionID {
+				grouped = append(grouped, member)
+			}
+		}
+	}
+	return grouped
+}
+
+// pageBounds returns the range [start, end) of the questions on the page of
+// questions[index].
+func pageBounds(questions []*model.Question, index int) (int, int) {
+	start := index
+	for start > 0 && sameSection(questions[start-1], questions[index]) {
+		start--
+	}
+	return start, pageEnd(questions, index)
+}
+
+func pageEnd(questions []*model.Question, index int) int {
+	end := index + 1
+	for end < len(questions) && sameSection(questions[end], questions[index]) {
+		end++
+	}
+	return end
+}
+
+func sameSection(a, b *model.Question) bool {
+	return a.SectionID != nil && b.SectionID != nil && *a.SectionID == *b.SectionID
+}
+
+// getQuestionsForSubmission returns the questions drawn for the submission in the
+// order they are asked.
+func (c *CoreService) getQuestionsForSubmission(ctx context.Context, userCtx context.Context, submission *model.UserSubmission) ([]*model.Question, error) {
+	questions, err := c.getQuestionsForQuestionnaire(ctx, userCtx, submission.QuestionnaireId)
+	if err != nil {
+		return nil, err
+	}
+	return submissionQuestions(submission, questions), nil
+}
+
 // getQuestionsForQuestionnaire retrieves all questions for the given questionnaire from the repo
 func (c *CoreService) getQuestionsForQuestionnaire(ctx context.Context, userCtx context.Context, questionnaireID uuid.UUID) ([]*model.Question, error) {
 	questions, err := c.questionRepo.GetByQuestionnaireID(ctx, userCtx, questionnaireID)
@@ -290,3 +899,230 @@ func (c *CoreService) CheckExpire(ctx context.Context, userCtx context.Context,
 
 	return nil
 }
+
+// SubmitFile answers a file upload question. The file is checked against the
+// question's limits and the virus scanner before it is stored; a previous file for
+// the same question is replaced.
+func (c *CoreService) SubmitFile(ctx context.Context, userCtx context.Context, userID, submissionID, questionID uuid.UUID, fileName string, size int64, body io.ReadSeeker) error {
+	submission, question, err := c.getCurrentQuestion(ctx, userCtx, submissionID, questionID)
+	if err != nil {
+		return err
+	}
+	if submission.UserId != userID {
+		return apperrors.ErrLackOfAuthorization
+	}
+	if !question.FileUpload {
+		return apperrors.ErrAnswerKindMismatch
+	}
+
+	maxSize, allowedTypes := c.fileLimits(question)
+	if size <= 0 || size > maxSize {
+		return apperrors.ErrFileTooLarge
+	}
+
+	contentType, _, err := sniffContentType(body)
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(allowedTypes, contentType) {
+		return apperrors.ErrUnsupportedMediaType
+	}
+
+	if _, err := body.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	result, err := c.virusScanner.Scan(ctx, fileName, io.LimitReader(body, size))
+	if err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogCoreService,
+			Message: fmt.Sprintf("virus scan failed: %v", err),
+		})
+		return err
+	}
+	if !result.Clean {
+		logger.GetLogger().LogWarningFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogCoreService,
+			Message: fmt.Sprintf("infected upload rejected: submission=%s question=%s threat=%s", submissionID, questionID, result.Threat),
+		})
+		return apperrors.ErrFileInfected
+	}
+
+	if _, err := body.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	key := fmt.Sprintf("submissions/%s/%s/%s", submissionID, questionID, uuid.New())
+	if err := c.fileStorage.Save(ctx, key, contentType, io.LimitReader(body, size), size); err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogCoreService,
+			Message: fmt.Sprintf("failed to save answer file: %v", err),
+		})
+		return err
+	}
+	// the answer pointing at it goes away when the request rolls back
+	c.deleteFilesOnRollback(ctx, userCtx, key)
+
+	var previousPath string
+	if previous, err := c.answerRepo.GetBySubmissionAndQuestion(ctx, userCtx, submissionID, questionID); err == nil {
+		previousPath = previous.FilePath
+	}
+
+	answer := &model.Answer{
+		QuestionID:       questionID,
+		UserID:           userID,
+		UserSubmissionID: submissionID,
+		FilePath:         key,
+		FileName:         fileName,
+		FileContentType:  contentType,
+		FileSize:         size,
+	}
+	if _, err := c.answerRepo.Create(ctx, userCtx, answer); err != nil {
+		return err
+	}
+	if err := c.questionVisitRepo.MarkAnswered(ctx, userCtx, submissionID, questionID, time.Now()); err != nil {
+		return err
+	}
+	if err := c.submissionRepo.UpdateSubmission(ctx, userCtx, submission); err != nil {
+		return err
+	}
+
+	if previousPath != "" {
+		c.deleteFilesAfterCommit(ctx, userCtx, previousPath)
+	}
+	return nil
+}
+
+// DeleteSubmission removes a submission, its answers and every uploaded file.
+// Only the owner of the questionnaire may delete submissions.
+func (c *CoreService) DeleteSubmission(ctx context.Context, userCtx context.Context, userID, submissionID uuid.UUID) error {
+	submission, err := c.submissionRepo.GetSubmissionByID(ctx, userCtx, submissionID)
+	if err != nil {
+		return err
+	}
+
+	qn, err := c.questionnaireRepo.GetById(ctx, userCtx, submission.QuestionnaireId)
+	if err != nil {
+		return err
+	}
+	if qn.OwnerId != userID {
+		return apperrors.ErrLackOfAuthorization
+	}
+
+	var paths []string
+	for _, answer := range submission.Answers {
+		if answer.FilePath != "" {
+			paths = append(paths, answer.FilePath)
+		}
+	}
+
+	if err := c.submissionRepo.DeleteSubmission(ctx, userCtx, submissionID); err != nil {
+		return err
+	}
+	c.deleteFilesAfterCommit(ctx, userCtx, paths...)
+	return nil
+}
+
+// TimeRemaining returns how long the respondent may still spend on a question, or
+// nil when the question has no time limit.
+func (c *CoreService) TimeRemaining(ctx context.Context, userCtx context.Context, submissionID uuid.UUID, question *model.Question) (*time.Duration, error) {
+	if question.TimeLimit == 0 {
+		return nil, nil
+	}
+	spent, err := c.questionVisitRepo.GetSpent(ctx, userCtx, submissionID, question.ID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	remaining := max(time.Duration(question.TimeLimit)*time.Second-spent, 0)
+	return &remaining, nil
+}
+
+// enterPage records that the respondent left the page they were on and is now
+// looking at the page of questions[index].
+func (c *CoreService) enterPage(ctx context.Context, userCtx context.Context, submission *model.UserSubmission, questions []*model.Question, index int) error {
+	now := time.Now()
+	if err := c.questionVisitRepo.CloseOpen(ctx, userCtx, submission.ID, now); err != nil {
+		return err
+	}
+	start, end := pageBounds(questions, index)
+	for _, question := range questions[start:end] {
+		err := c.questionVisitRepo.Open(ctx, userCtx, &model.QuestionVisit{
+			UserSubmissionID: submission.ID,
+			QuestionnaireId:  submission.QuestionnaireId,
+			QuestionID:       question.ID,
+			EnteredAt:        now,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isTimeUp reports whether the respondent used up the time limit of a question,
+// counting every visit to it.
+func (c *CoreService) isTimeUp(ctx context.Context, userCtx context.Context, submissionID uuid.UUID, question *model.Question) (bool, error) {
+	remaining, err := c.TimeRemaining(ctx, userCtx, submissionID, question)
+	if err != nil || remaining == nil {
+		return false, err
+	}
+	return *remaining <= 0, nil
+}
+
+// checkAnswerText applies the server-wide length limit and the answer rules of the
+// question to the text of a descriptive answer.
+func (c *CoreService) checkAnswerText(question *model.Question, text *string) error {
+	if text == nil || strings.TrimSpace(*text) == "" {
+		return &model.AnswerValidationError{Field: "text", Rule: "required", Message: "cannot be empty"}
+	}
+	if c.textMaxLength > 0 && utf8.RuneCountInString(*text) > c.textMaxLength {
+		return &model.AnswerValidationError{Field: "text", Rule: "max_length", Message: fmt.Sprintf("must be at most %d characters", c.textMaxLength)}
+	}
+	return question.AnswerRules.Check(*text)
+}
+
+// fileLimits returns the size and type limits of a file upload question, falling
+// back to the server defaults for limits the question does not set.
+func (c *CoreService) fileLimits(question *model.Question) (int64, []string) {
+	maxSize := c.fileMaxSize
+	if question.FileMaxSize > 0 {
+		maxSize = question.FileMaxSize
+	}
+
+	allowedTypes := c.fileAllowedTypes
+	if question.FileAllowedTypes != "" {
+		allowedTypes = nil
+		for _, contentType := range strings.Split(question.FileAllowedTypes, ",") {
+			allowedTypes = append(allowedTypes, strings.TrimSpace(contentType))
+		}
+	}
+	return maxSize, allowedTypes
+}
+
+// deleteFilesAfterCommit removes files the database stops referencing, only once
+// that change is committed so a rollback still finds them.
+func (c *CoreService) deleteFilesAfterCommit(ctx context.Context, userCtx context.Context, paths ...string) {
+	if len(paths) == 0 {
+		return
+	}
+	myContext.OnCommit(userCtx, func() {
+		c.deleteFiles(ctx, paths...)
+	})
+}
+
+// deleteFilesOnRollback removes files stored for a change that is rolled back, so
+// they are not left behind unreferenced.
+func (c *CoreService) deleteFilesOnRollback(ctx context.Context, userCtx context.Context, paths ...string) {
+	myContext.OnRollback(userCtx, func() {
+		c.deleteFiles(ctx, paths...)
+	})
+}
+
+func (c *CoreService) deleteFiles(ctx context.Context, paths ...string) {
+	for _, path := range paths {
+		if err := c.fileStorage.Delete(ctx, path); err != nil && !errors.Is(err, apperrors.ErrFileNotFound) {
+			logger.GetLogger().LogWarningFromContext(ctx, logger.LogFields{
+				Service: logmessages.LogCoreService,
+				Message: fmt.Sprintf("failed to delete answer file %s: %v", path, err),
+			})
+		}
+	}
+}
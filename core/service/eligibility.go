@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"golizilla/core/domain/model"
+	"golizilla/core/port/repository"
+	"golizilla/internal/apperrors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type IEligibilityService interface {
+	Check(ctx context.Context, userCtx context.Context, userID uuid.UUID, questionnaire *model.Questionnaire) error
+	CheckAll(ctx context.Context, userCtx context.Context, userID uuid.UUID, questionnaires []model.Questionnaire) (map[uuid.UUID]string, error)
+	SetRules(ctx context.Context, userCtx context.Context, userID, questionnaireID uuid.UUID, rules model.EligibilityRules) error
+}
+
+type EligibilityService struct {
+	questionnaireRepo repository.IQuestionnaireRepository
+	userRepo          repository.IUserRepository
+	roleService       IRoleService
+}
+
+func NewEligibilityService(
+	questionnaireRepo repository.IQuestionnaireRepository,
+	userRepo repository.IUserRepository,
+	roleService IRoleService,
+) IEligibilityService {
+	return &EligibilityService{
+		questionnaireRepo: questionnaireRepo,
+		userRepo:          userRepo,
+		roleService:       roleService,
+	}
+}
+
+// respondent is what the rules are evaluated against, loaded once per request.
+type respondent struct {
+	user      *model.User
+	roleChain []uuid.UUID
+}
+
+// Check returns ErrNotEligible, wrapping the *model.EligibilityError with the
+// reason, when the user may not answer the questionnaire. Owners are never
+// restricted so they can try their own rules.
+func (s *EligibilityService) Check(ctx context.Context, userCtx context.Context, userID uuid.UUID, questionnaire *model.Questionnaire) error {
+	if questionnaire.Eligibility.IsZero() || questionnaire.OwnerId == userID {
+		return nil
+	}
+	r, err := s.loadRespondent(ctx, userCtx, userID)
+	if err != nil {
+		return err
+	}
+	return checkEligibility(r, questionnaire, time.Now())
+}
+
+// CheckAll returns the reason for every questionnaire of the list the user may
+// not answer.
+func (s *EligibilityService) CheckAll(ctx context.Context, userCtx context.Context, userID uuid.UUID, questionnaires []model.Questionnaire) (map[uuid.UUID]string, error) {
+	reasons := make(map[uuid.UUID]string)
+	var r *respondent
+	now := time.Now()
+	for i := range questionnaires {
+		questionnaire := &questionnaires[i]
+		if questionnaire.Eligibility.IsZero() || questionnaire.OwnerId == userID {
+			continue
+		}
+		if r == nil {
+			var err error
+			if r, err = s.loadRespondent(ctx, userCtx, userID); err != nil {
+				return nil, err
+			}
+		}
+		var eligibilityErr *model.EligibilityError
+		if err := checkEligibility(r, questionnaire, now); errors.As(err, &eligibilityErr) {
+			reasons[questionnaire.Id] = eligibilityErr.Reason
+		}
+	}
+	return reasons, nil
+}
+
+// SetRules replaces the eligibility rules of the questionnaire, for its owner and
+// holders of UpdateQuestionnaireInstance.
+func (s *EligibilityService) SetRules(ctx context.Context, userCtx context.Context, userID, questionnaireID uuid.UUID, rules model.EligibilityRules) error {
+	if _, err := getEditableQuestionnaire(ctx, userCtx, s.questionnaireRepo, s.roleService, userID, questionnaireID); err != nil {
+		return err
+	}
+
+	if err := rules.Validate(); err != nil {
+		return fmt.Errorf("%w: %w", apperrors.ErrInvalidInput, err)
+	}
+	for _, roleID := range model.SplitList(rules.RoleIDs) {
+		if _, err := s.roleService.GetRoleById(ctx, userCtx, uuid.MustParse(roleID)); err != nil {
+			return err
+		}
+	}
+
+	return s.questionnaireRepo.Update(ctx, userCtx, questionnaireID, map[string]interface{}{
+		"eligibility_min_age":              rules.MinAge,
+		"eligibility_max_age":              rules.MaxAge,
+		"eligibility_cities":               rules.Cities,
+		"eligibility_role_ids":             rules.RoleIDs,
+		"eligibility_email_domains":        rules.EmailDomains,
+		"eligibility_min_account_age_days": rules.MinAccountAgeDays,
+	})
+}
+
+func (s *EligibilityService) loadRespondent(ctx context.Context, userCtx context.Context, userID uuid.UUID) (*respondent, error) {
+	user, err := s.userRepo.FindByID(ctx, userCtx, userID)
+	if err != nil {
+		return nil, err
+	}
+	roleChain, err := s.roleService.RoleChain(ctx, userCtx, user.RoleId)
+	if err != nil {
+		return nil, err
+	}
+	return &respondent{user: user, roleChain: roleChain}, nil
+}
+
+func checkEligibility(r *respondent, questionnaire *model.Questionnaire, now time.Time) error {
+	if err := questionnaire.Eligibility.Check(r.user, r.roleChain, now); err != nil {
+		return fmt.Errorf("%w: %w", apperrors.ErrNotEligible, err)
+	}
+	return nil
+}
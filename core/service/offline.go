@@ -0,0 +1,221 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"golizilla/core/domain/model"
+	"golizilla/internal/apperrors"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// OfflineSubmission is a complete answer set collected by a client without a
+// connection. ID is generated by the client, so sending it again is harmless.
+type OfflineSubmission struct {
+	ID              uuid.UUID
+	QuestionnaireID uuid.UUID
+	StartedAt       time.Time
+	FinishedAt      time.Time
+	Answers         []*model.Answer // in the order they were given
+	Variables       map[string]string
+}
+
+// SubmitOffline stores a finished submission with all its answers at once. The
+// answers go through the same rules as online: they must follow the pages and the
+// branching of the questionnaire, may only return to earlier pages when it is
+// back compatible, must answer every required question on the way and must be
+// given within the questionnaire's time window, judged by the client's clock with
+// the configured tolerance. Per-question time limits cannot be checked offline.
+//
+// When a submission with the ID exists already it is returned as is with true, so
+// clients can retry a sync whose response they did not receive.
+func (c *CoreService) SubmitOffline(ctx context.Context, userCtx context.Context, userID uuid.UUID, offline *OfflineSubmission) (*model.UserSubmission, bool, error) {
+	existing, err := c.existingOfflineSubmission(ctx, userCtx, userID, offline)
+	if existing != nil || !errors.Is(err, gorm.ErrRecordNotFound) {
+		return existing, existing != nil, err
+	}
+
+	qn, err := c.questionnaireRepo.GetById(ctx, userCtx, offline.QuestionnaireID)
+	if err != nil {
+		return nil, false, err
+	}
+	if qn.ArchivedAt != nil {
+		return nil, false, apperrors.ErrQuestionnaireArchived
+	}
+	if err := c.checkOfflineTime(qn, offline); err != nil {
+		return nil, false, err
+	}
+	if err := c.eligibility.Check(ctx, userCtx, userID, qn); err != nil {
+		return nil, false, err
+	}
+	if err := c.checkSubmitLimit(ctx, userCtx, userID, qn); err != nil {
+		return nil, false, err
+	}
+
+	pools, err := c.questionPoolRepo.GetByQuestionnaireID(ctx, userCtx, qn.Id)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(pools) > 0 {
+		return nil, false, apperrors.ErrOfflinePoolsUnsupported
+	}
+	questions, err := c.getQuestionsForQuestionnaire(ctx, userCtx, qn.Id)
+	if err != nil {
+		return nil, false, err
+	}
+	questions = groupSections(questions)
+	if len(questions) == 0 {
+		return nil, false, apperrors.ErrQuestionsNotFound
+	}
+
+	answers, err := c.checkOfflineOrder(qn, questions, offline.Answers)
+	if err != nil {
+		return nil, false, err
+	}
+
+	submission := &model.UserSubmission{
+		ID:              offline.ID,
+		UserId:          userID,
+		QuestionnaireId: qn.Id,
+		Status:          model.SubmissionsStatusDone,
+		CreatedAt:       offline.StartedAt,
+		Answers:         answers,
+	}
+	path, err := c.offlinePath(ctx, userCtx, submission, questions)
+	if err != nil {
+		return nil, false, err
+	}
+
+	answered := make(map[uuid.UUID]bool, len(answers))
+	for _, answer := range answers {
+		answered[answer.QuestionID] = true
+	}
+	for _, index := range path {
+		question := questions[index]
+		if question.Required && !answered[question.ID] {
+			return nil, false, fmt.Errorf("question %s: %w", question.ID, apperrors.ErrRequiredQuestionUnanswered)
+		}
+		delete(answered, question.ID)
+	}
+	if len(answered) > 0 {
+		// answers to questions the branching skipped
+		return nil, false, apperrors.ErrOfflineAnswerOrder
+	}
+
+	for i := range submission.Answers {
+		answer := &submission.Answers[i]
+		question := questions[slices.IndexFunc(questions, func(q *model.Question) bool { return q.ID == answer.QuestionID })]
+		if err := c.checkAnswer(ctx, userCtx, question, answer); err != nil {
+			return nil, false, fmt.Errorf("question %s: %w", answer.QuestionID, err)
+		}
+		answer.UserID = userID
+		answer.UserSubmissionID = submission.ID
+	}
+
+	submission.CurrentQuestionIndex = len(path) - 1
+	submission.DrawnQuestions = make([]model.SubmissionQuestion, len(path))
+	for i, index := range path {
+		submission.DrawnQuestions[i] = model.SubmissionQuestion{
+			UserSubmissionID: submission.ID,
+			QuestionID:       questions[index].ID,
+			Position:         i,
+		}
+	}
+	for name, value := range offline.Variables {
+		submission.Variables = append(submission.Variables, model.SubmissionVariable{
+			UserSubmissionID: submission.ID,
+			Name:             name,
+			Value:            value,
+		})
+	}
+
+	if err := c.submissionRepo.CreateSubmission(ctx, userCtx, submission); err != nil {
+		if errors.Is(err, apperrors.ErrSubmissionExists) {
+			// a concurrent sync with the same ID got there first
+			existing, err := c.existingOfflineSubmission(ctx, userCtx, userID, offline)
+			return existing, existing != nil, err
+		}
+		return nil, false, err
+	}
+	return submission, false, nil
+}
+
+// existingOfflineSubmission returns the stored submission with the ID of the
+// offline one, or ErrSubmissionIDConflict when it was made by another user or
+// for another questionnaire.
+func (c *CoreService) existingOfflineSubmission(ctx context.Context, userCtx context.Context, userID uuid.UUID, offline *OfflineSubmission) (*model.UserSubmission, error) {
+	existing, err := c.submissionRepo.GetSubmissionByID(ctx, userCtx, offline.ID)
+	if err != nil {
+		return nil, err
+	}
+	if existing.UserId != userID || existing.QuestionnaireId != offline.QuestionnaireID {
+		return nil, apperrors.ErrSubmissionIDConflict
+	}
+	return existing, nil
+}
+
+// checkOfflineTime makes sure the submission was started while the questionnaire
+// was open and finished within its answer time, allowing for the client's clock
+// to be off by the configured tolerance.
+func (c *CoreService) checkOfflineTime(qn *model.Questionnaire, offline *OfflineSubmission) error {
+	tolerance := c.clockTolerance
+	switch {
+	case offline.FinishedAt.Before(offline.StartedAt),
+		offline.StartedAt.Before(qn.StartTime.Add(-tolerance)),
+		offline.StartedAt.After(qn.EndTime.Add(tolerance)),
+		offline.FinishedAt.After(time.Now().Add(tolerance)),
+		offline.FinishedAt.Sub(offline.StartedAt) > time.Duration(qn.AnswerTime)*time.Minute+tolerance:
+		return apperrors.ErrOfflineTimeWindow
+	}
+	return nil
+}
+
+// checkOfflineOrder returns the last answer given to each question, as answering
+// again replaces the answer online too. Answers must move forward through the pages
+// unless the questionnaire is back compatible.
+func (c *CoreService) checkOfflineOrder(qn *model.Questionnaire, questions []*model.Question, answers []*model.Answer) ([]model.Answer, error) {
+	last := make(map[uuid.UUID]int, len(answers))
+	var result []model.Answer
+	page := 0
+	for _, answer := range answers {
+		index := slices.IndexFunc(questions, func(question *model.Question) bool { return question.ID == answer.QuestionID })
+		if index < 0 {
+			return nil, fmt.Errorf("question %s: %w", answer.QuestionID, apperrors.ErrQuestionNotInQuestionnaire)
+		}
+		start, _ := pageBounds(questions, index)
+		if start < page && !qn.BackCompatible {
+			return nil, apperrors.ErrOfflineAnswerOrder
+		}
+		page = start
+
+		if i, ok := last[answer.QuestionID]; ok {
+			result[i] = *answer
+			continue
+		}
+		last[answer.QuestionID] = len(result)
+		result = append(result, *answer)
+	}
+	return result, nil
+}
+
+// offlinePath returns the indexes of the questions the respondent went through,
+// following the branching of the submission's answers from the first page.
+func (c *CoreService) offlinePath(ctx context.Context, userCtx context.Context, submission *model.UserSubmission, questions []*model.Question) ([]int, error) {
+	var path []int
+	for index := 0; index < len(questions); {
+		_, end := pageBounds(questions, index)
+		for i := index; i < end; i++ {
+			path = append(path, i)
+		}
+		next, err := c.pageExit(ctx, userCtx, submission, questions, index)
+		if err != nil {
+			return nil, err
+		}
+		index = next
+	}
+	return path, nil
+}
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"golizilla/core/domain/model"
+	"golizilla/internal/apperrors"
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// placeholderPattern matches {{q3}} and {{campaign}} in question and option texts.
+var placeholderPattern = regexp.MustCompile(`\{\{\s*([A-Za-z][A-Za-z0-9_]*)\s*\}\}`)
+
+// RenderQuestion fills the placeholders of the question and option texts: {{q3}}
+// with the answer given to the question with index 3, any other name with the
+// hidden variable of that name. Unknown or unanswered placeholders become empty.
+// Values are HTML escaped since they come from respondents. Call it after the
+// question is translated so translated texts are piped too. Only the respondent
+// of the submission may have its answers piped.
+func (c *CoreService) RenderQuestion(ctx context.Context, userCtx context.Context, userID, submissionID uuid.UUID, question *model.Question) error {
+	if !hasPlaceholders(question) {
+		return nil
+	}
+
+	submission, err := c.submissionRepo.GetSubmissionByID(ctx, userCtx, submissionID)
+	if err != nil {
+		return err
+	}
+	if submission.UserId != userID {
+		return apperrors.ErrLackOfAuthorization
+	}
+	questions, err := c.getQuestionsForQuestionnaire(ctx, userCtx, submission.QuestionnaireId)
+	if err != nil {
+		return err
+	}
+	byIndex := make(map[uint]uuid.UUID, len(questions))
+	for _, q := range questions {
+		byIndex[q.Index] = q.ID
+	}
+	answers := make(map[uuid.UUID]model.Answer, len(submission.Answers))
+	for _, answer := range submission.Answers {
+		answers[answer.QuestionID] = answer
+	}
+	variables := submission.VariableMap()
+
+	values := make(map[string]string)
+	var renderErr error
+	render := func(text string) string {
+		return placeholderPattern.ReplaceAllStringFunc(text, func(placeholder string) string {
+			name := placeholderPattern.FindStringSubmatch(placeholder)[1]
+			value, ok := values[name]
+			if !ok {
+				if index, isAnswer := model.AnswerRefIndex(name); isAnswer {
+					if answer, answered := answers[byIndex[index]]; answered {
+						answerValue, err := c.answerValue(ctx, userCtx, &answer)
+						if err != nil && renderErr == nil {
+							renderErr = err
+						}
+						value = answerValue
+					}
+				} else {
+					value = variables[name]
+				}
+				value = html.EscapeString(value)
+				values[name] = value
+			}
+			return value
+		})
+	}
+
+	question.QuestionText = render(question.QuestionText)
+	for i := range question.Options {
+		question.Options[i].Text = render(question.Options[i].Text)
+	}
+	return renderErr
+}
+
+// answerValue returns the answer as it is piped: the text, the chosen option or
+// the name of the uploaded file. Options are piped in the default locale.
+func (c *CoreService) answerValue(ctx context.Context, userCtx context.Context, answer *model.Answer) (string, error) {
+	switch {
+	case answer.Text != nil:
+		return *answer.Text, nil
+	case answer.FilePath != "":
+		return answer.FileName, nil
+	case answer.OptionID != nil:
+		question, err := c.questionRepo.GetByID(ctx, userCtx, answer.QuestionID)
+		if err != nil {
+			return "", err
+		}
+		for _, option := range question.Options {
+			if option.ID == *answer.OptionID {
+				return option.Text, nil
+			}
+		}
+	}
+	return "", nil
+}
+
+func hasPlaceholders(question *model.Question) bool {
+	if strings.Contains(question.QuestionText, "{{") {
+		return true
+	}
+	for _, option := range question.Options {
+		if strings.Contains(option.Text, "{{") {
+			return true
+		}
+	}
+	return false
+}
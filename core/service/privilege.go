@@ -0,0 +1,27 @@
+package service
+
+import (
+	"context"
+	"golizilla/core/domain/model"
+	"golizilla/core/port/repository"
+)
+
+type IPrivilegeService interface {
+	List(ctx context.Context, userCtx context.Context) ([]model.Privilege, error)
+}
+
+type PrivilegeService struct {
+	privilegeRepo repository.IPrivilegeRepository
+}
+
+func NewPrivilegeService(privilegeRepo repository.IPrivilegeRepository) IPrivilegeService {
+	return &PrivilegeService{
+		privilegeRepo: privilegeRepo,
+	}
+}
+
+// List returns every privilege, the table is kept in sync with the registry on
+// startup.
+func (s *PrivilegeService) List(ctx context.Context, userCtx context.Context) ([]model.Privilege, error) {
+	return s.privilegeRepo.GetAll(ctx, userCtx)
+}
@@ -0,0 +1,170 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"golizilla/adapters/persistence/logger"
+	"golizilla/core/domain/model"
+	"golizilla/core/port/repository"
+	"golizilla/internal/apperrors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type IPrivilegeAuditService interface {
+	Record(ctx context.Context, userCtx context.Context, action model.PrivilegeAuditAction, actorID, questionnaireID, targetUserID uuid.UUID, privileges ...string) error
+	RecordForRole(ctx context.Context, userCtx context.Context, action model.PrivilegeAuditAction, actorID, questionnaireID, targetRoleID uuid.UUID, privileges ...string) error
+	ListForQuestionnaire(ctx context.Context, userCtx context.Context, userID, questionnaireID uuid.UUID, filter model.PrivilegeAuditFilter, page, pageSize int) (PaginatedPrivilegeAuditLogs, error)
+	ExportForQuestionnaire(ctx context.Context, userCtx context.Context, userID, questionnaireID uuid.UUID, filter model.PrivilegeAuditFilter) ([]byte, error)
+	List(ctx context.Context, userCtx context.Context, filter model.PrivilegeAuditFilter, page, pageSize int) (PaginatedPrivilegeAuditLogs, error)
+	Export(ctx context.Context, userCtx context.Context, filter model.PrivilegeAuditFilter) ([]byte, error)
+}
+
+type PrivilegeAuditService struct {
+	privilegeAuditRepo repository.IPrivilegeAuditRepository
+	questionnaireRepo  repository.IQuestionnaireRepository
+}
+
+func NewPrivilegeAuditService(
+	privilegeAuditRepo repository.IPrivilegeAuditRepository,
+	questionnaireRepo repository.IQuestionnaireRepository,
+) IPrivilegeAuditService {
+	return &PrivilegeAuditService{
+		privilegeAuditRepo: privilegeAuditRepo,
+		questionnaireRepo:  questionnaireRepo,
+	}
+}
+
+type PaginatedPrivilegeAuditLogs struct {
+	Data  []model.PrivilegeAuditLog `json:"data"`
+	Pages int                       `json:"pages"`
+	Page  int                       `json:"page"`
+}
+
+// Record appends one audit log per privilege granted to or revoked from a user.
+func (s *PrivilegeAuditService) Record(ctx context.Context, userCtx context.Context, action model.PrivilegeAuditAction, actorID, questionnaireID, targetUserID uuid.UUID, privileges ...string) error {
+	return s.record(ctx, userCtx, model.PrivilegeAuditLog{
+		QuestionnaireId: questionnaireID,
+		ActorId:         actorID,
+		TargetUserId:    &targetUserID,
+		Action:          action,
+	}, privileges)
+}
+
+// RecordForRole appends one audit log per privilege granted to or revoked from
+// every user of a role.
+func (s *PrivilegeAuditService) RecordForRole(ctx context.Context, userCtx context.Context, action model.PrivilegeAuditAction, actorID, questionnaireID, targetRoleID uuid.UUID, privileges ...string) error {
+	return s.record(ctx, userCtx, model.PrivilegeAuditLog{
+		QuestionnaireId: questionnaireID,
+		ActorId:         actorID,
+		TargetRoleId:    &targetRoleID,
+		Action:          action,
+	}, privileges)
+}
+
+// record copies the template once per privilege. The trace ID is taken from the
+// request context set up by the context middleware.
+func (s *PrivilegeAuditService) record(ctx context.Context, userCtx context.Context, template model.PrivilegeAuditLog, privileges []string) error {
+	template.TraceID, _ = ctx.Value(logger.TraceIDKey).(string)
+
+	auditLogs := make([]*model.PrivilegeAuditLog, len(privileges))
+	for i, privilege := range privileges {
+		auditLog := template
+		auditLog.Privilege = privilege
+		auditLogs[i] = &auditLog
+	}
+	return s.privilegeAuditRepo.Add(ctx, userCtx, auditLogs...)
+}
+
+// ListForQuestionnaire returns a page of the audit trail of one questionnaire to
+// its owner.
+func (s *PrivilegeAuditService) ListForQuestionnaire(ctx context.Context, userCtx context.Context, userID, questionnaireID uuid.UUID, filter model.PrivilegeAuditFilter, page, pageSize int) (PaginatedPrivilegeAuditLogs, error) {
+	if err := s.checkOwner(ctx, userCtx, userID, questionnaireID); err != nil {
+		return PaginatedPrivilegeAuditLogs{}, err
+	}
+	filter.QuestionnaireID = &questionnaireID
+	return s.List(ctx, userCtx, filter, page, pageSize)
+}
+
+// ExportForQuestionnaire returns the whole matching audit trail of one
+// questionnaire to its owner as CSV.
+func (s *PrivilegeAuditService) ExportForQuestionnaire(ctx context.Context, userCtx context.Context, userID, questionnaireID uuid.UUID, filter model.PrivilegeAuditFilter) ([]byte, error) {
+	if err := s.checkOwner(ctx, userCtx, userID, questionnaireID); err != nil {
+		return nil, err
+	}
+	filter.QuestionnaireID = &questionnaireID
+	return s.Export(ctx, userCtx, filter)
+}
+
+// List returns a page of the audit trail across all questionnaires.
+func (s *PrivilegeAuditService) List(ctx context.Context, userCtx context.Context, filter model.PrivilegeAuditFilter, page, pageSize int) (PaginatedPrivilegeAuditLogs, error) {
+	auditLogs, totalRecords, err := s.privilegeAuditRepo.GetPage(ctx, userCtx, filter, page, pageSize)
+	if err != nil {
+		return PaginatedPrivilegeAuditLogs{}, err
+	}
+
+	// Calculate total pages based on total records and page size
+	totalPages := int((totalRecords + int64(pageSize) - 1) / int64(pageSize))
+
+	return PaginatedPrivilegeAuditLogs{
+		Data:  auditLogs,
+		Pages: totalPages,
+		Page:  page,
+	}, nil
+}
+
+// Export returns the whole matching audit trail across all questionnaires as CSV.
+func (s *PrivilegeAuditService) Export(ctx context.Context, userCtx context.Context, filter model.PrivilegeAuditFilter) ([]byte, error) {
+	auditLogs, err := s.privilegeAuditRepo.GetAll(ctx, userCtx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	header := []string{"id", "created_at", "action", "privilege", "questionnaire_id", "actor_id", "target_user_id", "target_role_id", "trace_id"}
+	if err := writer.Write(header); err != nil {
+		return nil, err
+	}
+	for _, auditLog := range auditLogs {
+		record := []string{
+			auditLog.ID.String(),
+			auditLog.CreatedAt.Format(time.RFC3339),
+			string(auditLog.Action),
+			auditLog.Privilege,
+			auditLog.QuestionnaireId.String(),
+			auditLog.ActorId.String(),
+			optionalUUID(auditLog.TargetUserId),
+			optionalUUID(auditLog.TargetRoleId),
+			auditLog.TraceID,
+		}
+		if err := writer.Write(record); err != nil {
+			return nil, err
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func optionalUUID(id *uuid.UUID) string {
+	if id == nil {
+		return ""
+	}
+	return id.String()
+}
+
+func (s *PrivilegeAuditService) checkOwner(ctx context.Context, userCtx context.Context, userID, questionnaireID uuid.UUID) error {
+	qn, err := s.questionnaireRepo.GetById(ctx, userCtx, questionnaireID)
+	if err != nil {
+		return err
+	}
+	if qn.OwnerId != userID {
+		return apperrors.ErrLackOfAuthorization
+	}
+	return nil
+}
@@ -4,37 +4,74 @@ import (
 	"context"
 	"golizilla/core/domain/model"
 	"golizilla/core/port/repository"
+	"golizilla/internal/apperrors"
+	privilegeconstants "golizilla/internal/privilege"
 
 	"github.com/google/uuid"
 )
 
 type IQuestionService interface {
-	Create(ctx context.Context, userCtx context.Context, question *model.Question) (uuid.UUID, error)
-	Update(ctx context.Context, userCtx context.Context, question *model.Question) error
-	Delete(ctx context.Context, userCtx context.Context, id uuid.UUID) error
+	Create(ctx context.Context, userCtx context.Context, userID uuid.UUID, question *model.Question) (uuid.UUID, error)
+	Update(ctx context.Context, userCtx context.Context, userID uuid.UUID, question *model.Question) error
+	Delete(ctx context.Context, userCtx context.Context, userID, id uuid.UUID) error
 	GetByID(ctx context.Context, userCtx context.Context, id uuid.UUID) (*model.Question, error)
+	GetForUser(ctx context.Context, userCtx context.Context, userID, id uuid.UUID) (*model.Question, error)
 	GetFullByQuestionnaireID(ctx context.Context, userCtx context.Context, questionnaireID uuid.UUID) ([]*model.Question, error)
 }
 
 type QuestionService struct {
-	QuestionRepo repository.IQuestionRepository
+	QuestionRepo      repository.IQuestionRepository
+	questionnaireRepo repository.IQuestionnaireRepository
+	roleService       IRoleService
 }
 
-func NewQuestionService(repo repository.IQuestionRepository) IQuestionService {
+func NewQuestionService(
+	repo repository.IQuestionRepository,
+	questionnaireRepo repository.IQuestionnaireRepository,
+	roleService IRoleService,
+) IQuestionService {
 	return &QuestionService{
-		QuestionRepo: repo,
+		QuestionRepo:      repo,
+		questionnaireRepo: questionnaireRepo,
+		roleService:       roleService,
 	}
 }
 
-func (s *QuestionService) Create(ctx context.Context, userCtx context.Context, question *model.Question) (uuid.UUID, error) {
+func (s *QuestionService) Create(ctx context.Context, userCtx context.Context, userID uuid.UUID, question *model.Question) (uuid.UUID, error) {
+	if err := s.checkAccess(ctx, userCtx, userID, question.QuestionnaireId, privilegeconstants.CreateQuestion); err != nil {
+		return uuid.Nil, err
+	}
 	return s.QuestionRepo.Create(ctx, userCtx, question)
 }
 
-func (s *QuestionService) Update(ctx context.Context, userCtx context.Context, question *model.Question) error {
-	return s.QuestionRepo.Update(ctx, userCtx, question)
+func (s *QuestionService) Update(ctx context.Context, userCtx context.Context, userID uuid.UUID, question *model.Question) error {
+	if err := s.checkAccess(ctx, userCtx, userID, question.QuestionnaireId, privilegeconstants.EditQuestion); err != nil {
+		return err
+	}
+	if err := s.QuestionRepo.Update(ctx, userCtx, question); err != nil {
+		return err
+	}
+	// answer rules, the time limit, the tags and required are written separately so removed ones are cleared too
+	if err := s.QuestionRepo.UpdateAnswerRules(ctx, userCtx, question.ID, question.AnswerRules); err != nil {
+		return err
+	}
+	if err := s.QuestionRepo.UpdateTimeLimit(ctx, userCtx, question.ID, question.TimeLimit, question.TimeLimitAction); err != nil {
+		return err
+	}
+	if err := s.QuestionRepo.UpdateClassification(ctx, userCtx, question.ID, question.Tag, question.Difficulty); err != nil {
+		return err
+	}
+	return s.QuestionRepo.UpdateRequired(ctx, userCtx, question.ID, question.Required)
 }
 
-func (s *QuestionService) Delete(ctx context.Context, userCtx context.Context, id uuid.UUID) error {
+func (s *QuestionService) Delete(ctx context.Context, userCtx context.Context, userID, id uuid.UUID) error {
+	question, err := s.QuestionRepo.GetByID(ctx, userCtx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.checkAccess(ctx, userCtx, userID, question.QuestionnaireId, privilegeconstants.DeleteQuestion); err != nil {
+		return err
+	}
 	return s.QuestionRepo.Delete(ctx, userCtx, id)
 }
 
@@ -42,6 +79,48 @@ func (s *QuestionService) GetByID(ctx context.Context, userCtx context.Context,
 	return s.QuestionRepo.GetByID(ctx, userCtx, id)
 }
 
+// GetForUser returns the question to the questionnaire owner and holders of
+// ViewQuestion.
+func (s *QuestionService) GetForUser(ctx context.Context, userCtx context.Context, userID, id uuid.UUID) (*model.Question, error) {
+	question, err := s.QuestionRepo.GetByID(ctx, userCtx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkAccess(ctx, userCtx, userID, question.QuestionnaireId, privilegeconstants.ViewQuestion); err != nil {
+		return nil, err
+	}
+	return question, nil
+}
+
 func (s *QuestionService) GetFullByQuestionnaireID(ctx context.Context, userCtx context.Context, questionnaireID uuid.UUID) ([]*model.Question, error) {
 	return s.QuestionRepo.GetFullByQuestionnaireID(ctx, userCtx, questionnaireID)
 }
+
+// checkAccess lets the questionnaire owner through and otherwise requires the
+// privilege, either globally or on the questionnaire instance.
+func (s *QuestionService) checkAccess(ctx context.Context, userCtx context.Context, userID, questionnaireID uuid.UUID, privilege string) error {
+	qn, err := s.questionnaireRepo.GetById(ctx, userCtx, questionnaireID)
+	if err != nil {
+		return err
+	}
+	if qn.OwnerId == userID {
+		return nil
+	}
+
+	hasPrivilege, err := s.roleService.HasPrivileges(ctx, userCtx, userID, privilege)
+	if err != nil {
+		return err
+	}
+	if hasPrivilege {
+		return nil
+	}
+
+	hasPrivilege, err = s.roleService.HasPrivilegesOnInsance(ctx, userCtx, userID, questionnaireID, privilege)
+	if err != nil {
+		return err
+	}
+	if !hasPrivilege {
+		return apperrors.ErrLackOfAuthorization
+	}
+	return nil
+}
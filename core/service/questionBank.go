@@ -0,0 +1,317 @@
+package service
+
+import (
+	"context"
+	"golizilla/core/domain/model"
+	"golizilla/core/port/repository"
+	"golizilla/internal/apperrors"
+	privilegeconstants "golizilla/internal/privilege"
+
+	"github.com/google/uuid"
+)
+
+type IQuestionBankService interface {
+	Create(ctx context.Context, userCtx context.Context, userID uuid.UUID, bankQuestion *model.BankQuestion) error
+	Update(ctx context.Context, userCtx context.Context, userID, id uuid.UUID, update *model.BankQuestion) (*model.BankQuestion, error)
+	Delete(ctx context.Context, userCtx context.Context, userID, id uuid.UUID) error
+	GetByID(ctx context.Context, userCtx context.Context, userID, id uuid.UUID) (*model.BankQuestion, error)
+	List(ctx context.Context, userCtx context.Context, userID uuid.UUID, page, pageSize int) (PaginatedBankQuestions, error)
+	UseInQuestionnaire(ctx context.Context, userCtx context.Context, userID, bankQuestionID, questionnaireID uuid.UUID, index uint, linked bool) (*model.Question, error)
+	SyncQuestion(ctx context.Context, userCtx context.Context, userID, questionID uuid.UUID) (*model.Question, error)
+	GetResults(ctx context.Context, userCtx context.Context, userID, id uuid.UUID) (*BankQuestionResults, error)
+}
+
+type QuestionBankService struct {
+	questionBankRepo  repository.IQuestionBankRepository
+	questionRepo      repository.IQuestionRepository
+	questionnaireRepo repository.IQuestionnaireRepository
+	roleService       IRoleService
+}
+
+func NewQuestionBankService(
+	questionBankRepo repository.IQuestionBankRepository,
+	questionRepo repository.IQuestionRepository,
+	questionnaireRepo repository.IQuestionnaireRepository,
+	roleService IRoleService,
+) IQuestionBankService {
+	return &QuestionBankService{
+		questionBankRepo:  questionBankRepo,
+		questionRepo:      questionRepo,
+		questionnaireRepo: questionnaireRepo,
+		roleService:       roleService,
+	}
+}
+
+type PaginatedBankQuestions struct {
+	Data  []model.BankQuestion `json:"data"`
+	Pages int                  `json:"pages"`
+	Page  int                  `json:"page"`
+}
+
+// BankQuestionResults aggregates the answers of every questionnaire question that
+// was created from a bank question, keyed by bank option.
+type BankQuestionResults struct {
+	BankQuestionID uuid.UUID                 `json:"bank_question_id"`
+	Version        uint                      `json:"version"`
+	Usage          []model.BankQuestionUsage `json:"usage"`
+	Options        []BankOptionResult        `json:"options"`
+}
+
+type BankOptionResult struct {
+	BankOptionID    uuid.UUID           `json:"bank_option_id"`
+	Index           uint                `json:"index"`
+	Text            string              `json:"text"`
+	Total           int64               `json:"total"`
+	ByQuestionnaire map[uuid.UUID]int64 `json:"by_questionnaire"`
+}
+
+// Create adds a library entry. Organisation-wide entries need ManageQuestionBank.
+func (s *QuestionBankService) Create(ctx context.Context, userCtx context.Context, userID uuid.UUID, bankQuestion *model.BankQuestion) error {
+	if bankQuestion.Scope == model.BankQuestionScopeOrganization {
+		if err := s.checkManager(ctx, userCtx, userID); err != nil {
+			return err
+		}
+	}
+
+	bankQuestion.OwnerId = userID
+	bankQuestion.Version = 1
+	for i := range bankQuestion.Options {
+		bankQuestion.Options[i].Index = uint(i + 1)
+	}
+	return s.questionBankRepo.Create(ctx, userCtx, bankQuestion)
+}
+
+// Update replaces the text, scope and options of a library entry and bumps its
+// version. Options sent with an ID keep it, so answers stay comparable.
+func (s *QuestionBankService) Update(ctx context.Context, userCtx context.Context, userID, id uuid.UUID, update *model.BankQuestion) (*model.BankQuestion, error) {
+	bankQuestion, err := s.getEditable(ctx, userCtx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if update.Scope == model.BankQuestionScopeOrganization && bankQuestion.Scope != model.BankQuestionScopeOrganization {
+		if err := s.checkManager(ctx, userCtx, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	existing := make(map[uuid.UUID]bool, len(bankQuestion.Options))
+	for _, option := range bankQuestion.Options {
+		existing[option.ID] = true
+	}
+	for i := range update.Options {
+		if update.Options[i].ID != uuid.Nil && !existing[update.Options[i].ID] {
+			return nil, apperrors.ErrOptionNotFound
+		}
+		update.Options[i].Index = uint(i + 1)
+	}
+
+	bankQuestion.QuestionText = update.QuestionText
+	bankQuestion.Descriptive = update.Descriptive
+	bankQuestion.Scope = update.Scope
+	bankQuestion.Options = update.Options
+	bankQuestion.Version++
+	if err := s.questionBankRepo.Update(ctx, userCtx, bankQuestion); err != nil {
+		return nil, err
+	}
+	return bankQuestion, nil
+}
+
+// Delete removes a library entry. Questions created from it keep their text and
+// options but no longer receive updates.
+func (s *QuestionBankService) Delete(ctx context.Context, userCtx context.Context, userID, id uuid.UUID) error {
+	if _, err := s.getEditable(ctx, userCtx, userID, id); err != nil {
+		return err
+	}
+	return s.questionBankRepo.Delete(ctx, userCtx, id)
+}
+
+func (s *QuestionBankService) GetByID(ctx context.Context, userCtx context.Context, userID, id uuid.UUID) (*model.BankQuestion, error) {
+	return s.getVisible(ctx, userCtx, userID, id)
+}
+
+func (s *QuestionBankService) List(ctx context.Context, userCtx context.Context, userID uuid.UUID, page, pageSize int) (PaginatedBankQuestions, error) {
+	bankQuestions, totalRecords, err := s.questionBankRepo.GetVisible(ctx, userCtx, userID, page, pageSize)
+	if err != nil {
+		return PaginatedBankQuestions{}, err
+	}
+
+	// Calculate total pages based on total records and page size
+	totalPages := int((totalRecords + int64(pageSize) - 1) / int64(pageSize))
+
+	return PaginatedBankQuestions{
+		Data:  bankQuestions,
+		Pages: totalPages,
+		Page:  page,
+	}, nil
+}
+
+// UseInQuestionnaire creates a questionnaire question from a library entry. A linked
+// question can pull later versions with SyncQuestion, a copy stays as it is.
+func (s *QuestionBankService) UseInQuestionnaire(ctx context.Context, userCtx context.Context, userID, bankQuestionID, questionnaireID uuid.UUID, index uint, linked bool) (*model.Question, error) {
+	bankQuestion, err := s.getVisible(ctx, userCtx, userID, bankQuestionID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := getEditableQuestionnaire(ctx, userCtx, s.questionnaireRepo, s.roleService, userID, questionnaireID); err != nil {
+		return nil, err
+	}
+
+	question := &model.Question{
+		QuestionnaireId: questionnaireID,
+		Index:           index,
+		QuestionText:    bankQuestion.QuestionText,
+		Descriptive:     bankQuestion.Descriptive,
+		BankQuestionID:  &bankQuestion.ID,
+		BankVersion:     bankQuestion.Version,
+		BankLinked:      linked,
+	}
+	for _, bankOption := range bankQuestion.Options {
+		bankOptionID := bankOption.ID
+		question.Options = append(question.Options, model.Option{
+			Index:        bankOption.Index,
+			Text:         bankOption.Text,
+			BankOptionID: &bankOptionID,
+		})
+	}
+
+	if _, err := s.questionRepo.Create(ctx, userCtx, question); err != nil {
+		return nil, err
+	}
+	return question, nil
+}
+
+// SyncQuestion pulls the latest version of the bank question into a linked
+// questionnaire question. Options are matched by bank option ID.
+func (s *QuestionBankService) SyncQuestion(ctx context.Context, userCtx context.Context, userID, questionID uuid.UUID) (*model.Question, error) {
+	question, err := s.questionRepo.GetByID(ctx, userCtx, questionID)
+	if err != nil {
+		return nil, err
+	}
+	if !question.BankLinked || question.BankQuestionID == nil {
+		return nil, apperrors.ErrQuestionNotLinked
+	}
+	if _, err := getEditableQuestionnaire(ctx, userCtx, s.questionnaireRepo, s.roleService, userID, question.QuestionnaireId); err != nil {
+		return nil, err
+	}
+
+	bankQuestion, err := s.questionBankRepo.GetByID(ctx, userCtx, *question.BankQuestionID)
+	if err != nil {
+		return nil, err
+	}
+	if question.BankVersion >= bankQuestion.Version {
+		return question, nil
+	}
+
+	current := make(map[uuid.UUID]model.Option, len(question.Options))
+	for _, option := range question.Options {
+		if option.BankOptionID != nil {
+			current[*option.BankOptionID] = option
+		}
+	}
+
+	options := make([]model.Option, 0, len(bankQuestion.Options))
+	for _, bankOption := range bankQuestion.Options {
+		option, ok := current[bankOption.ID]
+		if !ok {
+			bankOptionID := bankOption.ID
+			option = model.Option{BankOptionID: &bankOptionID}
+		}
+		delete(current, bankOption.ID)
+		option.Index = bankOption.Index
+		option.Text = bankOption.Text
+		options = append(options, option)
+	}
+
+	var removed []uuid.UUID
+	for _, option := range current {
+		removed = append(removed, option.ID)
+	}
+
+	question.QuestionText = bankQuestion.QuestionText
+	question.Descriptive = bankQuestion.Descriptive
+	question.BankVersion = bankQuestion.Version
+	question.Options = options
+	if err := s.questionBankRepo.ApplyToQuestion(ctx, userCtx, question, removed); err != nil {
+		return nil, err
+	}
+	return question, nil
+}
+
+// GetResults compares the answers to a bank question across every questionnaire
+// that used it.
+func (s *QuestionBankService) GetResults(ctx context.Context, userCtx context.Context, userID, id uuid.UUID) (*BankQuestionResults, error) {
+	bankQuestion, err := s.getEditable(ctx, userCtx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	usage, err := s.questionBankRepo.GetUsage(ctx, userCtx, id)
+	if err != nil {
+		return nil, err
+	}
+	counts, err := s.questionBankRepo.GetOptionCounts(ctx, userCtx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	results := &BankQuestionResults{
+		BankQuestionID: bankQuestion.ID,
+		Version:        bankQuestion.Version,
+		Usage:          usage,
+		Options:        make([]BankOptionResult, 0, len(bankQuestion.Options)),
+	}
+	for _, option := range bankQuestion.Options {
+		result := BankOptionResult{
+			BankOptionID:    option.ID,
+			Index:           option.Index,
+			Text:            option.Text,
+			ByQuestionnaire: make(map[uuid.UUID]int64),
+		}
+		for _, count := range counts {
+			if count.BankOptionID == option.ID {
+				result.Total += count.Count
+				result.ByQuestionnaire[count.QuestionnaireId] += count.Count
+			}
+		}
+		results.Options = append(results.Options, result)
+	}
+	return results, nil
+}
+
+func (s *QuestionBankService) getVisible(ctx context.Context, userCtx context.Context, userID, id uuid.UUID) (*model.BankQuestion, error) {
+	bankQuestion, err := s.questionBankRepo.GetByID(ctx, userCtx, id)
+	if err != nil {
+		return nil, err
+	}
+	if bankQuestion.OwnerId != userID && bankQuestion.Scope != model.BankQuestionScopeOrganization {
+		// personal entries of other users are hidden
+		return nil, apperrors.ErrBankQuestionNotFound
+	}
+	return bankQuestion, nil
+}
+
+// getEditable loads an entry the user may change: their own entries, and
+// organisation-wide entries for users with ManageQuestionBank.
+func (s *QuestionBankService) getEditable(ctx context.Context, userCtx context.Context, userID, id uuid.UUID) (*model.BankQuestion, error) {
+	bankQuestion, err := s.getVisible(ctx, userCtx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if bankQuestion.OwnerId != userID {
+		if err := s.checkManager(ctx, userCtx, userID); err != nil {
+			return nil, err
+		}
+	}
+	return bankQuestion, nil
+}
+
+func (s *QuestionBankService) checkManager(ctx context.Context, userCtx context.Context, userID uuid.UUID) error {
+	hasPrivilege, err := s.roleService.HasPrivileges(ctx, userCtx, userID, privilegeconstants.ManageQuestionBank)
+	if err != nil {
+		return err
+	}
+	if !hasPrivilege {
+		return apperrors.ErrLackOfAuthorization
+	}
+	return nil
+}
@@ -0,0 +1,237 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	myContext "golizilla/adapters/http/handler/context"
+	"golizilla/adapters/persistence/logger"
+	"golizilla/core/domain/model"
+	"golizilla/core/port/repository"
+	"golizilla/core/port/storage"
+	"golizilla/internal/apperrors"
+	logmessages "golizilla/internal/logmessages"
+	privilegeconstants "golizilla/internal/privilege"
+	"io"
+	"mime"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+type IQuestionMediaService interface {
+	Upload(ctx context.Context, userCtx context.Context, userID, questionID uuid.UUID, size int64, body io.Reader) (*model.Question, error)
+	Open(ctx context.Context, userCtx context.Context, userID, questionID uuid.UUID) (io.ReadCloser, *model.Question, error)
+	Delete(ctx context.Context, userCtx context.Context, userID, questionID uuid.UUID) error
+}
+
+type QuestionMediaService struct {
+	questionRepo      repository.IQuestionRepository
+	questionnaireRepo repository.IQuestionnaireRepository
+	submissionRepo    repository.ISubmissionRepository
+	roleService       IRoleService
+	fileStorage       storage.IFileStorage
+	maxSize           int64
+	allowedTypes      []string
+}
+
+func NewQuestionMediaService(
+	questionRepo repository.IQuestionRepository,
+	questionnaireRepo repository.IQuestionnaireRepository,
+	submissionRepo repository.ISubmissionRepository,
+	roleService IRoleService,
+	fileStorage storage.IFileStorage,
+	maxSize int64,
+	allowedTypes []string,
+) IQuestionMediaService {
+	return &QuestionMediaService{
+		questionRepo:      questionRepo,
+		questionnaireRepo: questionnaireRepo,
+		submissionRepo:    submissionRepo,
+		roleService:       roleService,
+		fileStorage:       fileStorage,
+		maxSize:           maxSize,
+		allowedTypes:      allowedTypes,
+	}
+}
+
+// Upload stores the media of a question and replaces the previous file. The
+// content type is sniffed from the data itself; the client's claim is ignored.
+func (s *QuestionMediaService) Upload(ctx context.Context, userCtx context.Context, userID, questionID uuid.UUID, size int64, body io.Reader) (*model.Question, error) {
+	question, err := s.questionRepo.GetByID(ctx, userCtx, questionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkEditAccess(ctx, userCtx, userID, question); err != nil {
+		return nil, err
+	}
+
+	if size <= 0 || size > s.maxSize {
+		return nil, apperrors.ErrFileTooLarge
+	}
+
+	contentType, body, err := sniffContentType(body)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Contains(s.allowedTypes, contentType) {
+		return nil, apperrors.ErrUnsupportedMediaType
+	}
+
+	key := fmt.Sprintf("%s%s", questionMediaPrefix(questionID), uuid.New())
+	if err := s.fileStorage.Save(ctx, key, contentType, io.LimitReader(body, size), size); err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogQuestionMediaService,
+			Message: fmt.Sprintf("failed to save media: %v", err),
+		})
+		return nil, err
+	}
+
+	if err := s.questionRepo.UpdateMetaData(ctx, userCtx, questionID, key, contentType, size); err != nil {
+		s.deleteFile(ctx, key)
+		return nil, err
+	}
+
+	if s.isStoredMedia(question) {
+		s.deleteFileAfterCommit(ctx, userCtx, question.MetaDataPath)
+	}
+
+	question.MetaDataPath = key
+	question.MetaDataContentType = contentType
+	question.MetaDataSize = size
+	return question, nil
+}
+
+// Open returns the stored media of a question to everyone who may view the
+// question: its owner, holders of ViewQuestion and users answering it.
+func (s *QuestionMediaService) Open(ctx context.Context, userCtx context.Context, userID, questionID uuid.UUID) (io.ReadCloser, *model.Question, error) {
+	question, err := s.questionRepo.GetByID(ctx, userCtx, questionID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := s.checkViewAccess(ctx, userCtx, userID, question); err != nil {
+		return nil, nil, err
+	}
+	if !s.isStoredMedia(question) {
+		return nil, nil, apperrors.ErrFileNotFound
+	}
+
+	reader, _, err := s.fileStorage.Open(ctx, question.MetaDataPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	return reader, question, nil
+}
+
+func (s *QuestionMediaService) Delete(ctx context.Context, userCtx context.Context, userID, questionID uuid.UUID) error {
+	question, err := s.questionRepo.GetByID(ctx, userCtx, questionID)
+	if err != nil {
+		return err
+	}
+	if err := s.checkEditAccess(ctx, userCtx, userID, question); err != nil {
+		return err
+	}
+	if !s.isStoredMedia(question) {
+		return apperrors.ErrFileNotFound
+	}
+
+	if err := s.questionRepo.UpdateMetaData(ctx, userCtx, questionID, "", "", 0); err != nil {
+		return err
+	}
+	s.deleteFileAfterCommit(ctx, userCtx, question.MetaDataPath)
+	return nil
+}
+
+func (s *QuestionMediaService) checkEditAccess(ctx context.Context, userCtx context.Context, userID uuid.UUID, question *model.Question) error {
+	qn, err := s.questionnaireRepo.GetById(ctx, userCtx, question.QuestionnaireId)
+	if err != nil {
+		return err
+	}
+	if qn.OwnerId == userID {
+		return nil
+	}
+	return s.checkPrivilege(ctx, userCtx, userID, qn.Id, privilegeconstants.EditQuestion)
+}
+
+func (s *QuestionMediaService) checkViewAccess(ctx context.Context, userCtx context.Context, userID uuid.UUID, question *model.Question) error {
+	qn, err := s.questionnaireRepo.GetById(ctx, userCtx, question.QuestionnaireId)
+	if err != nil {
+		return err
+	}
+	if qn.OwnerId == userID {
+		return nil
+	}
+
+	// respondents see the media while they are answering the questionnaire
+	if _, err := s.submissionRepo.GetActiveSubmissionByUserIDAndQuestionnaire(ctx, userCtx, userID, qn.Id); err == nil {
+		return nil
+	}
+	return s.checkPrivilege(ctx, userCtx, userID, qn.Id, privilegeconstants.ViewQuestion)
+}
+
+// checkPrivilege accepts the privilege both globally and on the questionnaire instance.
+func (s *QuestionMediaService) checkPrivilege(ctx context.Context, userCtx context.Context, userID, questionnaireID uuid.UUID, privilege string) error {
+	hasPrivilege, err := s.roleService.HasPrivileges(ctx, userCtx, userID, privilege)
+	if err != nil {
+		return err
+	}
+	if hasPrivilege {
+		return nil
+	}
+
+	hasPrivilege, err = s.roleService.HasPrivilegesOnInsance(ctx, userCtx, userID, questionnaireID, privilege)
+	if err != nil {
+		return err
+	}
+	if !hasPrivilege {
+		return apperrors.ErrLackOfAuthorization
+	}
+	return nil
+}
+
+// isStoredMedia reports whether MetaDataPath points to a file uploaded for this
+// question, as opposed to a free-form path set before uploads existed.
+func (s *QuestionMediaService) isStoredMedia(question *model.Question) bool {
+	return question.MetaDataContentType != "" && strings.HasPrefix(question.MetaDataPath, questionMediaPrefix(question.ID))
+}
+
+// deleteFileAfterCommit removes media the question stops referencing, only once
+// that change is committed so a rollback still finds it.
+func (s *QuestionMediaService) deleteFileAfterCommit(ctx context.Context, userCtx context.Context, key string) {
+	myContext.OnCommit(userCtx, func() {
+		s.deleteFile(ctx, key)
+	})
+}
+
+func (s *QuestionMediaService) deleteFile(ctx context.Context, key string) {
+	if err := s.fileStorage.Delete(ctx, key); err != nil && !errors.Is(err, apperrors.ErrFileNotFound) {
+		logger.GetLogger().LogWarningFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogQuestionMediaService,
+			Message: fmt.Sprintf("failed to delete media %s: %v", key, err),
+		})
+	}
+}
+
+func questionMediaPrefix(questionID uuid.UUID) string {
+	return fmt.Sprintf("questions/%s/", questionID)
+}
+
+// sniffContentType detects the media type from the first bytes of body and returns
+// a reader that still yields the complete content.
+func sniffContentType(body io.Reader) (string, io.Reader, error) {
+	head := make([]byte, 512)
+	n, err := io.ReadFull(body, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return "", nil, err
+	}
+	head = head[:n]
+
+	contentType, _, err := mime.ParseMediaType(http.DetectContentType(head))
+	if err != nil {
+		return "", nil, apperrors.ErrUnsupportedMediaType
+	}
+	return contentType, io.MultiReader(bytes.NewReader(head), body), nil
+}
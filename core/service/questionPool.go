@@ -0,0 +1,321 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"golizilla/core/domain/model"
+	"golizilla/core/port/repository"
+	"golizilla/internal/apperrors"
+	privilegeconstants "golizilla/internal/privilege"
+	"math/rand/v2"
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+type IQuestionPoolService interface {
+	Create(ctx context.Context, userCtx context.Context, userID, questionnaireID uuid.UUID, pool *model.QuestionPool, questionIDs []uuid.UUID) (*model.QuestionPool, error)
+	Update(ctx context.Context, userCtx context.Context, userID, questionnaireID, id uuid.UUID, update *model.QuestionPool, questionIDs []uuid.UUID) (*model.QuestionPool, error)
+	Delete(ctx context.Context, userCtx context.Context, userID, questionnaireID, id uuid.UUID) error
+	List(ctx context.Context, userCtx context.Context, userID, questionnaireID uuid.UUID) ([]model.QuestionPool, error)
+	GetExamResults(ctx context.Context, userCtx context.Context, userID, questionnaireID uuid.UUID) (*ExamResults, error)
+}
+
+type QuestionPoolService struct {
+	questionPoolRepo  repository.IQuestionPoolRepository
+	questionnaireRepo repository.IQuestionnaireRepository
+	questionRepo      repository.IQuestionRepository
+	submissionRepo    repository.ISubmissionRepository
+	roleService       IRoleService
+}
+
+func NewQuestionPoolService(
+	questionPoolRepo repository.IQuestionPoolRepository,
+	questionnaireRepo repository.IQuestionnaireRepository,
+	questionRepo repository.IQuestionRepository,
+	submissionRepo repository.ISubmissionRepository,
+	roleService IRoleService,
+) IQuestionPoolService {
+	return &QuestionPoolService{
+		questionPoolRepo:  questionPoolRepo,
+		questionnaireRepo: questionnaireRepo,
+		questionRepo:      questionRepo,
+		submissionRepo:    submissionRepo,
+		roleService:       roleService,
+	}
+}
+
+// ExamResults grades every submission on the questions drawn for it. Only choice
+// questions with a correct option are graded.
+type ExamResults struct {
+	QuestionnaireID uuid.UUID            `json:"questionnaire_id"`
+	Questions       []DrawnQuestionStats `json:"questions"`
+	Submissions     []SubmissionGrade    `json:"submissions"`
+}
+
+type DrawnQuestionStats struct {
+	QuestionID  uuid.UUID  `json:"question_id"`
+	Index       uint       `json:"index"`
+	PoolID      *uuid.UUID `json:"pool_id,omitempty"`
+	Drawn       int        `json:"drawn"`
+	Answered    int        `json:"answered"`
+	Correct     int        `json:"correct"`
+	CorrectRate float64    `json:"correct_rate"` // of the submissions that drew the question
+}
+
+// SubmissionGrade is the score of one submission, UserID is left out on anonymous
+// questionnaires.
+type SubmissionGrade struct {
+	SubmissionID uuid.UUID              `json:"submission_id"`
+	UserID       *uuid.UUID             `json:"user_id,omitempty"`
+	Status       model.SubmissionStatus `json:"status"`
+	Drawn        int                    `json:"drawn"`
+	Graded       int                    `json:"graded"`
+	Correct      int                    `json:"correct"`
+	Score        float64                `json:"score"` // percent of graded questions answered correctly
+	Variables    map[string]string      `json:"variables,omitempty"`
+}
+
+func (s *QuestionPoolService) Create(ctx context.Context, userCtx context.Context, userID, questionnaireID uuid.UUID, pool *model.QuestionPool, questionIDs []uuid.UUID) (*model.QuestionPool, error) {
+	if _, err := getEditableQuestionnaire(ctx, userCtx, s.questionnaireRepo, s.roleService, userID, questionnaireID); err != nil {
+		return nil, err
+	}
+	if err := checkPoolDrawCount(pool, questionIDs); err != nil {
+		return nil, err
+	}
+
+	pool.QuestionnaireId = questionnaireID
+	if err := s.questionPoolRepo.Create(ctx, userCtx, pool, questionIDs); err != nil {
+		return nil, err
+	}
+	return s.questionPoolRepo.GetByID(ctx, userCtx, pool.ID)
+}
+
+// Update replaces the settings and the questions of a pool. Submissions that
+// already started keep the questions drawn for them.
+func (s *QuestionPoolService) Update(ctx context.Context, userCtx context.Context, userID, questionnaireID, id uuid.UUID, update *model.QuestionPool, questionIDs []uuid.UUID) (*model.QuestionPool, error) {
+	pool, err := s.getEditablePool(ctx, userCtx, userID, questionnaireID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkPoolDrawCount(update, questionIDs); err != nil {
+		return nil, err
+	}
+
+	pool.Name = update.Name
+	pool.DrawCount = update.DrawCount
+	pool.StratifyBy = update.StratifyBy
+	if err := s.questionPoolRepo.Update(ctx, userCtx, pool, questionIDs); err != nil {
+		return nil, err
+	}
+	return s.questionPoolRepo.GetByID(ctx, userCtx, pool.ID)
+}
+
+func (s *QuestionPoolService) Delete(ctx context.Context, userCtx context.Context, userID, questionnaireID, id uuid.UUID) error {
+	if _, err := s.getEditablePool(ctx, userCtx, userID, questionnaireID, id); err != nil {
+		return err
+	}
+	return s.questionPoolRepo.Delete(ctx, userCtx, id)
+}
+
+func (s *QuestionPoolService) List(ctx context.Context, userCtx context.Context, userID, questionnaireID uuid.UUID) ([]model.QuestionPool, error) {
+	if _, err := getEditableQuestionnaire(ctx, userCtx, s.questionnaireRepo, s.roleService, userID, questionnaireID); err != nil {
+		return nil, err
+	}
+	return s.questionPoolRepo.GetByQuestionnaireID(ctx, userCtx, questionnaireID)
+}
+
+// GetExamResults grades the submissions of a questionnaire for its owner or for
+// users holding SeeResultsOnInstance.
+func (s *QuestionPoolService) GetExamResults(ctx context.Context, userCtx context.Context, userID, questionnaireID uuid.UUID) (*ExamResults, error) {
+	qn, err := s.questionnaireRepo.GetById(ctx, userCtx, questionnaireID)
+	if err != nil {
+		return nil, err
+	}
+	if qn.OwnerId != userID {
+		hasPrivilege, err := s.roleService.HasPrivilegesOnInsance(ctx, userCtx, userID, questionnaireID, privilegeconstants.SeeResultsOnInstance)
+		if err != nil {
+			return nil, err
+		}
+		if !hasPrivilege {
+			return nil, apperrors.ErrLackOfAuthorization
+		}
+	}
+
+	questions, err := s.questionRepo.GetByQuestionnaireID(ctx, userCtx, questionnaireID)
+	if err != nil {
+		return nil, err
+	}
+	submissions, err := s.submissionRepo.GetByQuestionnaireID(ctx, userCtx, questionnaireID)
+	if err != nil {
+		return nil, err
+	}
+
+	results := &ExamResults{
+		QuestionnaireID: questionnaireID,
+		Questions:       make([]DrawnQuestionStats, len(questions)),
+		Submissions:     make([]SubmissionGrade, 0, len(submissions)),
+	}
+	stats := make(map[uuid.UUID]*DrawnQuestionStats, len(questions))
+	for i, question := range questions {
+		results.Questions[i] = DrawnQuestionStats{
+			QuestionID: question.ID,
+			Index:      question.Index,
+			PoolID:     question.PoolID,
+		}
+		stats[question.ID] = &results.Questions[i]
+	}
+
+	for _, submission := range submissions {
+		answers := make(map[uuid.UUID]model.Answer, len(submission.Answers))
+		for _, answer := range submission.Answers {
+			answers[answer.QuestionID] = answer
+		}
+
+		grade := SubmissionGrade{
+			SubmissionID: submission.ID,
+			Status:       submission.Status,
+			Variables:    submission.VariableMap(),
+		}
+		if !qn.Anonymous {
+			userID := submission.UserId
+			grade.UserID = &userID
+		}
+		for _, question := range submissionQuestions(&submission, questions) {
+			stat := stats[question.ID]
+			stat.Drawn++
+			grade.Drawn++
+
+			answer, answered := answers[question.ID]
+			if answered {
+				stat.Answered++
+			}
+			if question.CorrectOptionID == nil {
+				continue
+			}
+			grade.Graded++
+			if answered && answer.OptionID != nil && *answer.OptionID == *question.CorrectOptionID {
+				stat.Correct++
+				grade.Correct++
+			}
+		}
+		if grade.Graded > 0 {
+			grade.Score = float64(grade.Correct) * 100 / float64(grade.Graded)
+		}
+		results.Submissions = append(results.Submissions, grade)
+	}
+	for i := range results.Questions {
+		if results.Questions[i].Drawn > 0 {
+			results.Questions[i].CorrectRate = float64(results.Questions[i].Correct) / float64(results.Questions[i].Drawn)
+		}
+	}
+	return results, nil
+}
+
+// getEditablePool loads a pool of the questionnaire if the user may edit it.
+func (s *QuestionPoolService) getEditablePool(ctx context.Context, userCtx context.Context, userID, questionnaireID, id uuid.UUID) (*model.QuestionPool, error) {
+	if _, err := getEditableQuestionnaire(ctx, userCtx, s.questionnaireRepo, s.roleService, userID, questionnaireID); err != nil {
+		return nil, err
+	}
+	pool, err := s.questionPoolRepo.GetByID(ctx, userCtx, id)
+	if err != nil {
+		return nil, err
+	}
+	if pool.QuestionnaireId != questionnaireID {
+		return nil, apperrors.ErrQuestionPoolNotFound
+	}
+	return pool, nil
+}
+
+func checkPoolDrawCount(pool *model.QuestionPool, questionIDs []uuid.UUID) error {
+	if pool.DrawCount == 0 || int(pool.DrawCount) > len(questionIDs) {
+		return apperrors.ErrInvalidDrawCount
+	}
+	return nil
+}
+
+// submissionQuestions returns the questions asked in a submission in order,
+// questions deleted since the draw are left out.
+func submissionQuestions(submission *model.UserSubmission, questions []*model.Question) []*model.Question {
+	if len(submission.DrawnQuestions) == 0 {
+		return groupSections(questions)
+	}
+	byID := make(map[uuid.UUID]*model.Question, len(questions))
+	for _, question := range questions {
+		byID[question.ID] = question
+	}
+	drawn := make([]*model.Question, 0, len(submission.DrawnQuestions))
+	for _, sq := range submission.DrawnQuestions {
+		if question, ok := byID[sq.QuestionID]; ok {
+			drawn = append(drawn, question)
+		}
+	}
+	return drawn
+}
+
+// drawQuestions picks the questions of one submission. Questions outside a pool are
+// always asked, of each pool DrawCount questions are drawn at random. Stratified
+// pools split the draw across their tags or difficulties in proportion to how many
+// questions each has. The result keeps the order of questions.
+func drawQuestions(questions []*model.Question, pools []model.QuestionPool) []*model.Question {
+	pooled := make(map[uuid.UUID]bool, len(pools))
+	drawn := make(map[uuid.UUID]bool, len(questions))
+	for _, pool := range pools {
+		pooled[pool.ID] = true
+		strata := make(map[string][]*model.Question)
+		var keys []string
+		for _, question := range questions {
+			if question.PoolID == nil || *question.PoolID != pool.ID {
+				continue
+			}
+			key := pool.StratumOf(question)
+			if _, ok := strata[key]; !ok {
+				keys = append(keys, key)
+			}
+			strata[key] = append(strata[key], question)
+		}
+
+		total := 0
+		for _, key := range keys {
+			total += len(strata[key])
+		}
+		count := min(int(pool.DrawCount), total)
+		if count == 0 {
+			continue
+		}
+
+		// largest remainder, so the quotas add up to count
+		type quota struct {
+			key       string
+			n         int
+			remainder int
+		}
+		quotas := make([]quota, len(keys))
+		assigned := 0
+		for i, key := range keys {
+			share := count * len(strata[key])
+			quotas[i] = quota{key: key, n: share / total, remainder: share % total}
+			assigned += quotas[i].n
+		}
+		slices.SortStableFunc(quotas, func(a, b quota) int { return cmp.Compare(b.remainder, a.remainder) })
+		for i := 0; assigned < count; i++ {
+			quotas[i%len(quotas)].n++
+			assigned++
+		}
+
+		for _, q := range quotas {
+			stratum := strata[q.key]
+			for _, i := range rand.Perm(len(stratum))[:q.n] {
+				drawn[stratum[i].ID] = true
+			}
+		}
+	}
+
+	selected := make([]*model.Question, 0, len(questions))
+	for _, question := range questions {
+		if question.PoolID == nil || !pooled[*question.PoolID] || drawn[question.ID] {
+			selected = append(selected, question)
+		}
+	}
+	return selected
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"golizilla/core/domain/model"
 	respository "golizilla/core/port/repository"
+	"golizilla/internal/apperrors"
+	privilegeconstants "golizilla/internal/privilege"
 	"time"
 
 	"github.com/google/uuid"
@@ -67,6 +69,10 @@ func (q *questionnaireService) IsQuestionnaireActive(ctx context.Context, userCt
 		return false, err // Handles not found and other errors
 	}
 
+	if questionnaire.ArchivedAt != nil {
+		return false, nil
+	}
+
 	currentTime := time.Now()
 	isActive := currentTime.After(questionnaire.StartTime) && currentTime.Before(questionnaire.EndTime)
 	return isActive, nil
@@ -80,3 +86,22 @@ func (q *questionnaireService) IsQuestionnaireAnonymous(ctx context.Context, use
 
 	return questionnaire.Anonymous, nil
 }
+
+// getEditableQuestionnaire loads the questionnaire if the user may edit it: its
+// owner and holders of UpdateQuestionnaireInstance on it.
+func getEditableQuestionnaire(ctx context.Context, userCtx context.Context, questionnaireRepo respository.IQuestionnaireRepository, roleService IRoleService, userID, questionnaireID uuid.UUID) (*model.Questionnaire, error) {
+	qn, err := questionnaireRepo.GetById(ctx, userCtx, questionnaireID)
+	if err != nil {
+		return nil, err
+	}
+	if qn.OwnerId != userID {
+		hasPrivilege, err := roleService.HasPrivilegesOnInsance(ctx, userCtx, userID, questionnaireID, privilegeconstants.UpdateQuestionnaireInstance)
+		if err != nil {
+			return nil, err
+		}
+		if !hasPrivilege {
+			return nil, apperrors.ErrLackOfAuthorization
+		}
+	}
+	return qn, nil
+}
@@ -0,0 +1,240 @@
+package service
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"golizilla/core/domain/model"
+	"golizilla/core/port/repository"
+	"golizilla/core/port/storage"
+	"golizilla/internal/apperrors"
+	privilegeconstants "golizilla/internal/privilege"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type IResultExportService interface {
+	Export(ctx context.Context, userCtx context.Context, userID, questionnaireID uuid.UUID) (*ResultExport, error)
+}
+
+type ResultExportService struct {
+	questionnaireRepo repository.IQuestionnaireRepository
+	questionRepo      repository.IQuestionRepository
+	submissionRepo    repository.ISubmissionRepository
+	roleService       IRoleService
+	fileStorage       storage.IFileStorage
+}
+
+func NewResultExportService(
+	questionnaireRepo repository.IQuestionnaireRepository,
+	questionRepo repository.IQuestionRepository,
+	submissionRepo repository.ISubmissionRepository,
+	roleService IRoleService,
+	fileStorage storage.IFileStorage,
+) IResultExportService {
+	return &ResultExportService{
+		questionnaireRepo: questionnaireRepo,
+		questionRepo:      questionRepo,
+		submissionRepo:    submissionRepo,
+		roleService:       roleService,
+		fileStorage:       fileStorage,
+	}
+}
+
+// ResultExport holds everything needed to write the results of a questionnaire.
+// It is loaded up front so writing the archive does not touch the database.
+type ResultExport struct {
+	Questionnaire *model.Questionnaire
+	questions     map[uuid.UUID]*model.Question
+	submissions   []model.UserSubmission
+	fileStorage   storage.IFileStorage
+}
+
+// Export loads the results of a questionnaire for its owner or for users holding
+// SeeResultsOnInstance.
+func (s *ResultExportService) Export(ctx context.Context, userCtx context.Context, userID, questionnaireID uuid.UUID) (*ResultExport, error) {
+	qn, err := s.questionnaireRepo.GetById(ctx, userCtx, questionnaireID)
+	if err != nil {
+		return nil, err
+	}
+	if qn.OwnerId != userID {
+		hasPrivilege, err := s.roleService.HasPrivilegesOnInsance(ctx, userCtx, userID, questionnaireID, privilegeconstants.SeeResultsOnInstance)
+		if err != nil {
+			return nil, err
+		}
+		if !hasPrivilege {
+			return nil, apperrors.ErrLackOfAuthorization
+		}
+	}
+
+	questions, err := s.questionRepo.GetByQuestionnaireID(ctx, userCtx, questionnaireID)
+	if err != nil {
+		return nil, err
+	}
+	submissions, err := s.submissionRepo.GetByQuestionnaireID(ctx, userCtx, questionnaireID)
+	if err != nil {
+		return nil, err
+	}
+
+	export := &ResultExport{
+		Questionnaire: qn,
+		questions:     make(map[uuid.UUID]*model.Question, len(questions)),
+		submissions:   submissions,
+		fileStorage:   s.fileStorage,
+	}
+	for _, question := range questions {
+		export.questions[question.ID] = question
+	}
+	return export, nil
+}
+
+func (e *ResultExport) FileName() string {
+	return fmt.Sprintf("results-%s.zip", e.Questionnaire.Id)
+}
+
+// WriteZip writes a zip archive with results.csv and every uploaded answer file
+// below files/. The "file" column of the CSV points to the file inside the archive,
+// hidden variables follow in one "var_<name>" column each.
+func (e *ResultExport) WriteZip(ctx context.Context, w io.Writer) error {
+	archive := zip.NewWriter(w)
+
+	type exportFile struct {
+		key  string
+		name string
+	}
+	var files []exportFile
+
+	csvFile, err := archive.Create("results.csv")
+	if err != nil {
+		return err
+	}
+	writer := csv.NewWriter(csvFile)
+	variableNames := e.variableNames()
+	header := []string{"submission_id", "respondent_id", "status", "started_at", "question_index", "question_text", "option_id", "answer", "file"}
+	for _, name := range variableNames {
+		header = append(header, "var_"+name)
+	}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	for _, submission := range e.submissions {
+		respondent := submission.UserId.String()
+		if e.Questionnaire.Anonymous {
+			respondent = ""
+		}
+
+		variables := submission.VariableMap()
+		answers := submission.Answers
+		sort.SliceStable(answers, func(i, j int) bool {
+			return e.questionIndex(answers[i].QuestionID) < e.questionIndex(answers[j].QuestionID)
+		})
+
+		for _, answer := range answers {
+			question := e.questions[answer.QuestionID]
+			if question == nil {
+				continue
+			}
+
+			// the answer is exported in the default locale, options keep one ID
+			// across translations so option_id identifies the choice in any language
+			var optionID, value, filePath string
+			if answer.OptionID != nil {
+				optionID = answer.OptionID.String()
+			}
+			switch {
+			case answer.FilePath != "":
+				value = answer.FileName
+				filePath = path.Join("files", submission.ID.String(), answer.ID.String()+"-"+sanitizeFileName(answer.FileName))
+				files = append(files, exportFile{key: answer.FilePath, name: filePath})
+			case answer.Text != nil:
+				value = *answer.Text
+			case answer.Option != nil:
+				value = answer.Option.Text
+			}
+
+			record := []string{
+				submission.ID.String(),
+				respondent,
+				string(submission.Status),
+				submission.CreatedAt.UTC().Format(time.RFC3339),
+				fmt.Sprint(question.Index),
+				question.QuestionText,
+				optionID,
+				value,
+				filePath,
+			}
+			for _, name := range variableNames {
+				record = append(record, variables[name])
+			}
+			if err := writer.Write(record); err != nil {
+				return err
+			}
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		if err := copyToZip(ctx, e.fileStorage, archive, file.key, file.name); err != nil {
+			return err
+		}
+	}
+	return archive.Close()
+}
+
+// copyToZip adds the stored file under key to the archive as name.
+func copyToZip(ctx context.Context, fileStorage storage.IFileStorage, archive *zip.Writer, key string, name string) error {
+	reader, _, err := fileStorage.Open(ctx, key)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", key, err)
+	}
+	defer reader.Close()
+
+	entry, err := archive.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(entry, reader)
+	return err
+}
+
+// variableNames returns the names of all hidden variables of the submissions, sorted.
+func (e *ResultExport) variableNames() []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, submission := range e.submissions {
+		for _, variable := range submission.Variables {
+			if !seen[variable.Name] {
+				seen[variable.Name] = true
+				names = append(names, variable.Name)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (e *ResultExport) questionIndex(questionID uuid.UUID) uint {
+	if question := e.questions[questionID]; question != nil {
+		return question.Index
+	}
+	return 0
+}
+
+// sanitizeFileName keeps user supplied names from creating directories in the archive.
+func sanitizeFileName(name string) string {
+	name = path.Base(strings.ReplaceAll(name, "\\", "/"))
+	if name == "." || name == "/" || name == ".." {
+		return "file"
+	}
+	return name
+}
@@ -2,13 +2,18 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"golizilla/adapters/persistence/logger"
 	"golizilla/core/domain/model"
 	"golizilla/core/port/repository"
+	"golizilla/internal/apperrors"
 	logmessages "golizilla/internal/logmessages"
+	privilegeconstants "golizilla/internal/privilege"
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type IRoleService interface {
@@ -19,8 +24,19 @@ type IRoleService interface {
 	HasPrivileges(ctx context.Context, userCtx context.Context, id uuid.UUID, privileges ...string) (bool, error)
 	AddPrivilegeOnInstance(ctx context.Context, userCtx context.Context, roleId uuid.UUID, questionnaireId uuid.UUID, privileges ...string) error
 	DeletePrivilegeOnInstance(ctx context.Context, userCtx context.Context, roleId uuid.UUID, questionnaireId uuid.UUID, privileges ...string) error
+	AddUserPrivilegeOnInstance(ctx context.Context, userCtx context.Context, userId uuid.UUID, questionnaireId uuid.UUID, privileges ...string) error
+	DeleteUserPrivilegeOnInstance(ctx context.Context, userCtx context.Context, userId uuid.UUID, questionnaireId uuid.UUID, privileges ...string) error
 	DeltePrivilege(ctx context.Context, userCtx context.Context, roleId uuid.UUID, privileges ...string) error
 	HasPrivilegesOnInsance(ctx context.Context, userCtx context.Context, userId uuid.UUID, questionnariId uuid.UUID, privileges ...string) (bool, error)
+	RenameRole(ctx context.Context, userCtx context.Context, roleId uuid.UUID, name, description string) (*model.Role, error)
+	DeleteRole(ctx context.Context, userCtx context.Context, roleId uuid.UUID) error
+	AttachPrivileges(ctx context.Context, userCtx context.Context, actorId uuid.UUID, roleId uuid.UUID, privileges ...string) error
+	DetachPrivileges(ctx context.Context, userCtx context.Context, roleId uuid.UUID, privileges ...string) error
+	AssignRole(ctx context.Context, userCtx context.Context, actorId uuid.UUID, roleId uuid.UUID, userIds ...uuid.UUID) error
+	GetRoleMembers(ctx context.Context, userCtx context.Context, roleId uuid.UUID, page, pageSize int) (PaginatedRoleMembers, error)
+	SetParentRole(ctx context.Context, userCtx context.Context, actorId uuid.UUID, roleId uuid.UUID, parentId *uuid.UUID) error
+	EffectivePrivileges(ctx context.Context, userCtx context.Context, roleId uuid.UUID) ([]string, error)
+	RoleChain(ctx context.Context, userCtx context.Context, roleId uuid.UUID) ([]uuid.UUID, error)
 }
 
 type roleService struct {
@@ -28,18 +44,23 @@ type roleService struct {
 	userRepo                    repository.IUserRepository
 	rolePrivilegeRepo           repository.IRolePrivilegeRepository
 	rolePrivilegeOnInstanceRepo repository.IRolePrivilegeOnInstanceRepository
+	userPrivilegeOnInstanceRepo repository.IUserPrivilegeOnInstanceRepository
+	hierarchyCache              *roleHierarchyCache
 }
 
 func NewRoleService(roleRepo repository.IRoleRepository,
 	userRepo repository.IUserRepository,
 	rolePrivilegeRepo repository.IRolePrivilegeRepository,
-	rolePrivilegeOnInstanceRepo repository.IRolePrivilegeOnInstanceRepository) IRoleService {
+	rolePrivilegeOnInstanceRepo repository.IRolePrivilegeOnInstanceRepository,
+	userPrivilegeOnInstanceRepo repository.IUserPrivilegeOnInstanceRepository) IRoleService {
 
 	return &roleService{
 		roleRepo:                    roleRepo,
 		userRepo:                    userRepo,
 		rolePrivilegeRepo:           rolePrivilegeRepo,
 		rolePrivilegeOnInstanceRepo: rolePrivilegeOnInstanceRepo,
+		userPrivilegeOnInstanceRepo: userPrivilegeOnInstanceRepo,
+		hierarchyCache:              newRoleHierarchyCache(),
 	}
 }
 
@@ -66,16 +87,23 @@ func (s *roleService) AddPrivilege(ctx context.Context, userCtx context.Context,
 			return err
 		}
 	}
+	s.invalidateHierarchy(userCtx)
 
 	return nil
 }
 
+// AddPrivilegeOnInstance grants the privileges on the questionnaire to every user
+// of the role. Use AddUserPrivilegeOnInstance to grant them to a single user.
 func (s *roleService) AddPrivilegeOnInstance(ctx context.Context, userCtx context.Context, roleId uuid.UUID, questionnaireId uuid.UUID, privileges ...string) error {
+	if err := checkInstancePrivileges(privileges); err != nil {
+		return err
+	}
 	for _, privilege := range privileges {
 		rolePrivilegeOnInstance := &model.RolePrivilegeOnInstance{
 			RoleId:          roleId,
 			PrivilegeId:     privilege,
 			QuestionnaireId: questionnaireId,
+			Explicit:        true,
 		}
 		if err := s.rolePrivilegeOnInstanceRepo.Add(ctx, userCtx, rolePrivilegeOnInstance); err != nil {
 			return err
@@ -95,12 +123,67 @@ func (s *roleService) DeletePrivilegeOnInstance(ctx context.Context, userCtx con
 	return nil
 }
 
+// AddUserPrivilegeOnInstance grants the privileges on the questionnaire to the user
+// only, other users of their role are not affected.
+func (s *roleService) AddUserPrivilegeOnInstance(ctx context.Context, userCtx context.Context, userId uuid.UUID, questionnaireId uuid.UUID, privileges ...string) error {
+	if err := checkInstancePrivileges(privileges); err != nil {
+		return err
+	}
+	if _, err := s.userRepo.FindByID(ctx, userCtx, userId); err != nil {
+		return err
+	}
+	for _, privilege := range privileges {
+		userPrivilegeOnInstance := &model.UserPrivilegeOnInstance{
+			UserId:          userId,
+			PrivilegeId:     privilege,
+			QuestionnaireId: questionnaireId,
+		}
+		if err := s.userPrivilegeOnInstanceRepo.Add(ctx, userCtx, userPrivilegeOnInstance); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeleteUserPrivilegeOnInstance revokes the privileges on the questionnaire granted
+// to the user. It fails with ErrPrivilegeGrantedByRole when the role of the user,
+// or one it inherits from, grants one of them too, since the user would keep it.
+func (s *roleService) DeleteUserPrivilegeOnInstance(ctx context.Context, userCtx context.Context, userId uuid.UUID, questionnaireId uuid.UUID, privileges ...string) error {
+	user, err := s.userRepo.FindByID(ctx, userCtx, userId)
+	if err != nil {
+		return err
+	}
+	role, err := s.resolveRole(ctx, userCtx, user.RoleId)
+	if err != nil {
+		return err
+	}
+	for _, privilege := range privileges {
+		granted, err := s.rolePrivilegeOnInstanceRepo.HasPrivilegesOnInsance(ctx, userCtx, role.chain, questionnaireId, privilege)
+		if err != nil {
+			return err
+		}
+		if granted {
+			return fmt.Errorf("%w: %s", apperrors.ErrPrivilegeGrantedByRole, privilege)
+		}
+	}
+
+	for _, privilege := range privileges {
+		if err := s.userPrivilegeOnInstanceRepo.Delete(ctx, userCtx, userId, privilege, questionnaireId); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (s *roleService) DeltePrivilege(ctx context.Context, userCtx context.Context, roleId uuid.UUID, privileges ...string) error {
 	for _, privilege := range privileges {
 		if err := s.rolePrivilegeRepo.Delete(ctx, userCtx, roleId, privilege); err != nil {
 			return err
 		}
 	}
+	s.invalidateHierarchy(userCtx)
 
 	return nil
 }
@@ -108,9 +191,12 @@ func (s *roleService) DeltePrivilege(ctx context.Context, userCtx context.Contex
 func (s *roleService) GetRoleById(ctx context.Context, userCtx context.Context, id uuid.UUID) (*model.Role, error) {
 	role, err := s.roleRepo.GetById(ctx, userCtx, id)
 	if err != nil {
-		//log
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrRoleNotFound
+		}
+		return nil, err
 	}
-	return role, err
+	return role, nil
 }
 
 func (s *roleService) GetRoleByUserId(ctx context.Context, userCtx context.Context, userId uuid.UUID) (*model.Role, error) {
@@ -133,20 +219,31 @@ func (s *roleService) HasPrivileges(ctx context.Context, userCtx context.Context
 		//log
 		return false, err
 	}
-	rolePrivileges, err := s.rolePrivilegeRepo.GetRolePrivilegesByPrivileges(ctx, userCtx, user.RoleId, privileges...)
+	role, err := s.resolveRole(ctx, userCtx, user.RoleId)
 	if err != nil {
 		//log
 		return false, err
 	}
 
-	if len(rolePrivileges) == len(privileges) {
-		return true, nil
-	} else {
-		return false, nil
+	for _, privilege := range privileges {
+		if !role.privileges[privilege] {
+			return false, nil
+		}
 	}
+	return true, nil
 }
 
+// HasPrivilegesOnInsance reports whether any of the privileges was granted on the
+// questionnaire to the user, to their role or to one of its ancestors.
 func (s *roleService) HasPrivilegesOnInsance(ctx context.Context, userCtx context.Context, userId uuid.UUID, questionnariId uuid.UUID, privileges ...string) (bool, error) {
+	hasPrivilege, err := s.userPrivilegeOnInstanceRepo.HasPrivilegesOnInsance(ctx, userCtx, userId, questionnariId, privileges...)
+	if err != nil {
+		return false, err
+	}
+	if hasPrivilege {
+		return true, nil
+	}
+
 	user, err := s.userRepo.FindByID(ctx, userCtx, userId)
 	if err != nil {
 		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
@@ -155,7 +252,11 @@ func (s *roleService) HasPrivilegesOnInsance(ctx context.Context, userCtx contex
 		})
 		return false, err
 	}
-	hasPrivilege, err := s.rolePrivilegeOnInstanceRepo.HasPrivilegesOnInsance(ctx, userCtx, user.RoleId, questionnariId, privileges...)
+	role, err := s.resolveRole(ctx, userCtx, user.RoleId)
+	if err != nil {
+		return false, err
+	}
+	hasPrivilege, err = s.rolePrivilegeOnInstanceRepo.HasPrivilegesOnInsance(ctx, userCtx, role.chain, questionnariId, privileges...)
 	if err != nil {
 		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
 			Service: logmessages.LogRoleService,
@@ -166,3 +267,250 @@ func (s *roleService) HasPrivilegesOnInsance(ctx context.Context, userCtx contex
 
 	return hasPrivilege, err
 }
+
+type PaginatedRoleMembers struct {
+	Data  []model.User `json:"data"`
+	Pages int          `json:"pages"`
+	Page  int          `json:"page"`
+}
+
+func (s *roleService) RenameRole(ctx context.Context, userCtx context.Context, roleId uuid.UUID, name, description string) (*model.Role, error) {
+	role, err := s.getManageableRole(ctx, userCtx, roleId)
+	if err != nil {
+		return nil, err
+	}
+	role.Name = name
+	role.Description = description
+	if err := s.roleRepo.Update(ctx, userCtx, role); err != nil {
+		return nil, err
+	}
+	s.notifyMembers(ctx, userCtx, roleId, fmt.Sprintf("Your role was renamed to %s", name))
+	return role, nil
+}
+
+// DeleteRole deletes a role that has no members anymore.
+func (s *roleService) DeleteRole(ctx context.Context, userCtx context.Context, roleId uuid.UUID) error {
+	if _, err := s.getManageableRole(ctx, userCtx, roleId); err != nil {
+		return err
+	}
+	members, err := s.roleRepo.CountMembers(ctx, userCtx, roleId)
+	if err != nil {
+		return err
+	}
+	if members > 0 {
+		return apperrors.ErrRoleInUse
+	}
+	children, err := s.roleRepo.CountChildren(ctx, userCtx, roleId)
+	if err != nil {
+		return err
+	}
+	if children > 0 {
+		return apperrors.ErrRoleHasChildren
+	}
+	if err := s.roleRepo.DeleteWithGrants(ctx, userCtx, roleId); err != nil {
+		return err
+	}
+	s.invalidateHierarchy(userCtx)
+	return nil
+}
+
+// AttachPrivileges adds global privileges to the role. The actor must hold every
+// one of them, so nobody can hand out more than they have.
+func (s *roleService) AttachPrivileges(ctx context.Context, userCtx context.Context, actorId uuid.UUID, roleId uuid.UUID, privileges ...string) error {
+	if _, err := s.GetRoleById(ctx, userCtx, roleId); err != nil {
+		return err
+	}
+	hasPrivileges, err := s.HasPrivileges(ctx, userCtx, actorId, privileges...)
+	if err != nil {
+		return err
+	}
+	if !hasPrivileges {
+		return apperrors.ErrLackOfAuthorization
+	}
+
+	existing, err := s.rolePrivilegeRepo.GetRolePrivilegesByPrivileges(ctx, userCtx, roleId, privileges...)
+	if err != nil {
+		return err
+	}
+	attached := make(map[string]bool, len(existing))
+	for _, rolePrivilege := range existing {
+		attached[rolePrivilege.PrivilegeId] = true
+	}
+	var added []string
+	for _, privilege := range privileges {
+		if !attached[privilege] {
+			attached[privilege] = true
+			added = append(added, privilege)
+		}
+	}
+	if len(added) == 0 {
+		return nil
+	}
+	if err := s.AddPrivilege(ctx, userCtx, roleId, added...); err != nil {
+		return err
+	}
+	s.notifyMembers(ctx, userCtx, roleId, fmt.Sprintf("Your role changed. permissions added : %v", added))
+	return nil
+}
+
+// DetachPrivileges removes global privileges from the role.
+func (s *roleService) DetachPrivileges(ctx context.Context, userCtx context.Context, roleId uuid.UUID, privileges ...string) error {
+	if _, err := s.getManageableRole(ctx, userCtx, roleId); err != nil {
+		return err
+	}
+	if err := s.DeltePrivilege(ctx, userCtx, roleId, privileges...); err != nil {
+		return err
+	}
+	s.notifyMembers(ctx, userCtx, roleId, fmt.Sprintf("Your role changed. permissions deleted : %v", privileges))
+	return nil
+}
+
+// AssignRole moves the users to the role. The actor must hold every global
+// privilege of the role and of the current role of each user, inherited ones
+// included, so nobody can demote users above them. The last super admin cannot
+// be moved away.
+func (s *roleService) AssignRole(ctx context.Context, userCtx context.Context, actorId uuid.UUID, roleId uuid.UUID, userIds ...uuid.UUID) error {
+	role, err := s.GetRoleById(ctx, userCtx, roleId)
+	if err != nil {
+		return err
+	}
+	if err := s.checkHoldsRolePrivileges(ctx, userCtx, actorId, roleId); err != nil {
+		return err
+	}
+
+	checked := map[uuid.UUID]bool{roleId: true}
+	for _, userId := range userIds {
+		user, err := s.userRepo.FindByID(ctx, userCtx, userId)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return apperrors.ErrUserNotFound
+			}
+			return err
+		}
+		if user.RoleId == roleId {
+			continue
+		}
+		if !checked[user.RoleId] {
+			if err := s.checkHoldsRolePrivileges(ctx, userCtx, actorId, user.RoleId); err != nil {
+				return err
+			}
+			checked[user.RoleId] = true
+		}
+		if err := s.checkNotLastSuperAdmin(ctx, userCtx, user.RoleId); err != nil {
+			return err
+		}
+		if err := s.userRepo.UpdateRole(ctx, userCtx, userId, roleId); err != nil {
+			return err
+		}
+		s.notify(ctx, userCtx, userId, fmt.Sprintf("Your role changed to %s", role.Name))
+	}
+	return nil
+}
+
+// checkHoldsRolePrivileges makes sure the actor holds every global privilege of
+// the role, inherited ones included.
+func (s *roleService) checkHoldsRolePrivileges(ctx context.Context, userCtx context.Context, actorId, roleId uuid.UUID) error {
+	privileges, err := s.EffectivePrivileges(ctx, userCtx, roleId)
+	if err != nil {
+		return err
+	}
+	if len(privileges) == 0 {
+		return nil
+	}
+	hasPrivileges, err := s.HasPrivileges(ctx, userCtx, actorId, privileges...)
+	if err != nil {
+		return err
+	}
+	if !hasPrivileges {
+		return apperrors.ErrLackOfAuthorization
+	}
+	return nil
+}
+
+func (s *roleService) GetRoleMembers(ctx context.Context, userCtx context.Context, roleId uuid.UUID, page, pageSize int) (PaginatedRoleMembers, error) {
+	if _, err := s.GetRoleById(ctx, userCtx, roleId); err != nil {
+		return PaginatedRoleMembers{}, err
+	}
+	members, totalRecords, err := s.roleRepo.GetMembers(ctx, userCtx, roleId, page, pageSize)
+	if err != nil {
+		return PaginatedRoleMembers{}, err
+	}
+
+	// Calculate total pages based on total records and page size
+	totalPages := int((totalRecords + int64(pageSize) - 1) / int64(pageSize))
+
+	return PaginatedRoleMembers{
+		Data:  members,
+		Pages: totalPages,
+		Page:  page,
+	}, nil
+}
+
+// getManageableRole returns the role unless it is the protected super admin role.
+func (s *roleService) getManageableRole(ctx context.Context, userCtx context.Context, roleId uuid.UUID) (*model.Role, error) {
+	role, err := s.GetRoleById(ctx, userCtx, roleId)
+	if err != nil {
+		return nil, err
+	}
+	if role.Name == model.SuperAdminRoleName {
+		return nil, apperrors.ErrRoleProtected
+	}
+	return role, nil
+}
+
+func (s *roleService) checkNotLastSuperAdmin(ctx context.Context, userCtx context.Context, roleId uuid.UUID) error {
+	role, err := s.GetRoleById(ctx, userCtx, roleId)
+	if err != nil {
+		return err
+	}
+	if role.Name != model.SuperAdminRoleName {
+		return nil
+	}
+	members, err := s.roleRepo.CountMembers(ctx, userCtx, roleId)
+	if err != nil {
+		return err
+	}
+	if members <= 1 {
+		return apperrors.ErrRoleProtected
+	}
+	return nil
+}
+
+func (s *roleService) notifyMembers(ctx context.Context, userCtx context.Context, roleId uuid.UUID, message string) {
+	members, err := s.roleRepo.GetMemberIDs(ctx, userCtx, roleId)
+	if err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogRoleService,
+			Message: err.Error(),
+		})
+		return
+	}
+	for _, userId := range members {
+		s.notify(ctx, userCtx, userId, message)
+	}
+}
+
+// notify is best effort, like the notifications sent by GiveAcess.
+func (s *roleService) notify(ctx context.Context, userCtx context.Context, userId uuid.UUID, message string) {
+	err := s.userRepo.CreateNotification(ctx, userCtx, userId, &model.Notification{
+		Message: message,
+	})
+	if err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogRoleService,
+			Message: err.Error(),
+		})
+	}
+}
+
+// checkInstancePrivileges rejects privileges the registry does not allow on a
+// single questionnaire.
+func checkInstancePrivileges(privileges []string) error {
+	for _, privilege := range privileges {
+		definition, ok := privilegeconstants.Lookup(privilege)
+		if !ok || !definition.CanSetOnInstance {
+			return fmt.Errorf("%w: %s", apperrors.ErrPrivilegeNotSettableOnInstance, privilege)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,199 @@
+package service
+
+import (
+	"context"
+	"errors"
+	myContext "golizilla/adapters/http/handler/context"
+	"golizilla/adapters/persistence/logger"
+	"golizilla/internal/apperrors"
+	logmessages "golizilla/internal/logmessages"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// roleHierarchyCacheTTL bounds how long a changed hierarchy can still be served
+// by another server instance, which does not see the invalidation, or by a
+// request that resolved it while the change was not committed yet.
+const roleHierarchyCacheTTL = time.Minute
+
+// resolvedRole is a role with its ancestors and the privileges it holds directly
+// or through them.
+type resolvedRole struct {
+	chain      []uuid.UUID // the role first, then its parent, up to the root
+	privileges map[string]bool
+	expiresAt  time.Time
+}
+
+type roleHierarchyCache struct {
+	mu      sync.RWMutex
+	entries map[uuid.UUID]resolvedRole
+	// generation counts invalidations, an entry loaded before one is not stored
+	generation uint64
+}
+
+func newRoleHierarchyCache() *roleHierarchyCache {
+	return &roleHierarchyCache{entries: make(map[uuid.UUID]resolvedRole)}
+}
+
+// get returns the cached entry and the generation a miss has to be loaded in.
+func (c *roleHierarchyCache) get(roleId uuid.UUID) (resolvedRole, uint64, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	entry, ok := c.entries[roleId]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return resolvedRole{}, c.generation, false
+	}
+	return entry, c.generation, true
+}
+
+func (c *roleHierarchyCache) put(roleId uuid.UUID, entry resolvedRole, generation uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if generation != c.generation {
+		return
+	}
+	entry.expiresAt = time.Now().Add(roleHierarchyCacheTTL)
+	c.entries[roleId] = entry
+}
+
+// invalidate drops every entry, a change to one role reaches all its descendants.
+func (c *roleHierarchyCache) invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[uuid.UUID]resolvedRole)
+	c.generation++
+}
+
+// invalidateHierarchy drops the cache right away, so the request sees its own
+// change, and again once the change is committed, as parallel requests may have
+// cached the old state until then. On rollback it is dropped too, since the
+// request may have cached the change that never happened.
+func (s *roleService) invalidateHierarchy(userCtx context.Context) {
+	s.hierarchyCache.invalidate()
+	myContext.OnCommit(userCtx, s.hierarchyCache.invalidate)
+	myContext.OnRollback(userCtx, s.hierarchyCache.invalidate)
+}
+
+// resolveRole returns the role with its ancestors and effective privileges.
+func (s *roleService) resolveRole(ctx context.Context, userCtx context.Context, roleId uuid.UUID) (resolvedRole, error) {
+	entry, generation, ok := s.hierarchyCache.get(roleId)
+	if ok {
+		return entry, nil
+	}
+
+	chain, err := s.loadChain(ctx, userCtx, roleId)
+	if err != nil {
+		return resolvedRole{}, err
+	}
+	rolePrivileges, err := s.rolePrivilegeRepo.GetRolesPrivileges(ctx, userCtx, chain...)
+	if err != nil {
+		return resolvedRole{}, err
+	}
+	entry = resolvedRole{
+		chain:      chain,
+		privileges: make(map[string]bool, len(rolePrivileges)),
+	}
+	for _, rolePrivilege := range rolePrivileges {
+		entry.privileges[rolePrivilege.PrivilegeId] = true
+	}
+
+	s.hierarchyCache.put(roleId, entry, generation)
+	return entry, nil
+}
+
+// loadChain walks from the role up to its root. SetParentRole refuses cycles, a
+// cycle found here was written around it and is cut off where it closes.
+func (s *roleService) loadChain(ctx context.Context, userCtx context.Context, roleId uuid.UUID) ([]uuid.UUID, error) {
+	var chain []uuid.UUID
+	visited := make(map[uuid.UUID]bool)
+	for id := &roleId; id != nil; {
+		if visited[*id] {
+			logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+				Service: logmessages.LogRoleService,
+				Message: apperrors.ErrRoleCycle.Error(),
+			})
+			break
+		}
+		role, err := s.roleRepo.GetById(ctx, userCtx, *id)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, apperrors.ErrRoleNotFound
+			}
+			return nil, err
+		}
+		visited[role.ID] = true
+		chain = append(chain, role.ID)
+		id = role.ParentId
+	}
+	return chain, nil
+}
+
+// EffectivePrivileges returns the global privileges of the role, including the
+// inherited ones, sorted by ID.
+func (s *roleService) EffectivePrivileges(ctx context.Context, userCtx context.Context, roleId uuid.UUID) ([]string, error) {
+	entry, err := s.resolveRole(ctx, userCtx, roleId)
+	if err != nil {
+		return nil, err
+	}
+	privileges := make([]string, 0, len(entry.privileges))
+	for privilege := range entry.privileges {
+		privileges = append(privileges, privilege)
+	}
+	sort.Strings(privileges)
+	return privileges, nil
+}
+
+// RoleChain returns the role followed by the roles it inherits from.
+func (s *roleService) RoleChain(ctx context.Context, userCtx context.Context, roleId uuid.UUID) ([]uuid.UUID, error) {
+	entry, err := s.resolveRole(ctx, userCtx, roleId)
+	if err != nil {
+		return nil, err
+	}
+	return entry.chain, nil
+}
+
+// SetParentRole makes the role inherit from the parent, nil detaches it. The actor
+// must hold every privilege the parent brings, and the parent must not descend
+// from the role.
+func (s *roleService) SetParentRole(ctx context.Context, userCtx context.Context, actorId uuid.UUID, roleId uuid.UUID, parentId *uuid.UUID) error {
+	if _, err := s.getManageableRole(ctx, userCtx, roleId); err != nil {
+		return err
+	}
+
+	if parentId != nil {
+		chain, err := s.loadChain(ctx, userCtx, *parentId)
+		if err != nil {
+			return err
+		}
+		for _, id := range chain {
+			if id == roleId {
+				return apperrors.ErrRoleCycle
+			}
+		}
+
+		privileges, err := s.EffectivePrivileges(ctx, userCtx, *parentId)
+		if err != nil {
+			return err
+		}
+		if len(privileges) > 0 {
+			hasPrivileges, err := s.HasPrivileges(ctx, userCtx, actorId, privileges...)
+			if err != nil {
+				return err
+			}
+			if !hasPrivileges {
+				return apperrors.ErrLackOfAuthorization
+			}
+		}
+	}
+
+	if err := s.roleRepo.UpdateParent(ctx, userCtx, roleId, parentId); err != nil {
+		return err
+	}
+	s.invalidateHierarchy(userCtx)
+	s.notifyMembers(ctx, userCtx, roleId, "Your role changed. inherited permissions were updated")
+	return nil
+}
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"golizilla/core/domain/model"
+	"golizilla/core/port/repository"
+	"golizilla/internal/apperrors"
+
+	"github.com/google/uuid"
+)
+
+type ISectionService interface {
+	Create(ctx context.Context, userCtx context.Context, userID, questionnaireID uuid.UUID, section *model.Section, questionIDs []uuid.UUID) (*model.Section, error)
+	Update(ctx context.Context, userCtx context.Context, userID, questionnaireID, id uuid.UUID, update *model.Section, questionIDs []uuid.UUID) (*model.Section, error)
+	Delete(ctx context.Context, userCtx context.Context, userID, questionnaireID, id uuid.UUID) error
+	List(ctx context.Context, userCtx context.Context, userID, questionnaireID uuid.UUID) ([]model.Section, error)
+	SetBranching(ctx context.Context, userCtx context.Context, userID, questionID uuid.UUID, jumps map[uuid.UUID]*uuid.UUID) error
+}
+
+type SectionService struct {
+	sectionRepo       repository.ISectionRepository
+	questionnaireRepo repository.IQuestionnaireRepository
+	questionRepo      repository.IQuestionRepository
+	roleService       IRoleService
+}
+
+func NewSectionService(
+	sectionRepo repository.ISectionRepository,
+	questionnaireRepo repository.IQuestionnaireRepository,
+	questionRepo repository.IQuestionRepository,
+	roleService IRoleService,
+) ISectionService {
+	return &SectionService{
+		sectionRepo:       sectionRepo,
+		questionnaireRepo: questionnaireRepo,
+		questionRepo:      questionRepo,
+		roleService:       roleService,
+	}
+}
+
+func (s *SectionService) Create(ctx context.Context, userCtx context.Context, userID, questionnaireID uuid.UUID, section *model.Section, questionIDs []uuid.UUID) (*model.Section, error) {
+	if _, err := getEditableQuestionnaire(ctx, userCtx, s.questionnaireRepo, s.roleService, userID, questionnaireID); err != nil {
+		return nil, err
+	}
+
+	section.QuestionnaireId = questionnaireID
+	if err := s.sectionRepo.Create(ctx, userCtx, section, questionIDs); err != nil {
+		return nil, err
+	}
+	return s.sectionRepo.GetByID(ctx, userCtx, section.ID)
+}
+
+// Update replaces the title, description and questions of a section.
+func (s *SectionService) Update(ctx context.Context, userCtx context.Context, userID, questionnaireID, id uuid.UUID, update *model.Section, questionIDs []uuid.UUID) (*model.Section, error) {
+	section, err := s.getEditableSection(ctx, userCtx, userID, questionnaireID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	section.Title = update.Title
+	section.Description = update.Description
+	if err := s.sectionRepo.Update(ctx, userCtx, section, questionIDs); err != nil {
+		return nil, err
+	}
+	return s.sectionRepo.GetByID(ctx, userCtx, section.ID)
+}
+
+func (s *SectionService) Delete(ctx context.Context, userCtx context.Context, userID, questionnaireID, id uuid.UUID) error {
+	if _, err := s.getEditableSection(ctx, userCtx, userID, questionnaireID, id); err != nil {
+		return err
+	}
+	return s.sectionRepo.Delete(ctx, userCtx, id)
+}
+
+func (s *SectionService) List(ctx context.Context, userCtx context.Context, userID, questionnaireID uuid.UUID) ([]model.Section, error) {
+	if _, err := getEditableQuestionnaire(ctx, userCtx, s.questionnaireRepo, s.roleService, userID, questionnaireID); err != nil {
+		return nil, err
+	}
+	return s.sectionRepo.GetByQuestionnaireID(ctx, userCtx, questionnaireID)
+}
+
+// SetBranching makes options of a choice question skip ahead to a section of the
+// same questionnaire. Jumps only ever go forward, a target at or before the page of
+// the question is ignored while answering.
+func (s *SectionService) SetBranching(ctx context.Context, userCtx context.Context, userID, questionID uuid.UUID, jumps map[uuid.UUID]*uuid.UUID) error {
+	question, err := s.questionRepo.GetByID(ctx, userCtx, questionID)
+	if err != nil {
+		return err
+	}
+	if question.Descriptive || question.FileUpload {
+		return apperrors.ErrAnswerKindMismatch
+	}
+	if _, err := getEditableQuestionnaire(ctx, userCtx, s.questionnaireRepo, s.roleService, userID, question.QuestionnaireId); err != nil {
+		return err
+	}
+
+	for _, sectionID := range jumps {
+		if sectionID == nil {
+			continue
+		}
+		section, err := s.sectionRepo.GetByID(ctx, userCtx, *sectionID)
+		if err != nil {
+			return err
+		}
+		if section.QuestionnaireId != question.QuestionnaireId {
+			return apperrors.ErrSectionNotFound
+		}
+	}
+	return s.sectionRepo.SetOptionJumps(ctx, userCtx, questionID, jumps)
+}
+
+// getEditableSection loads a section of the questionnaire if the user may edit it.
+func (s *SectionService) getEditableSection(ctx context.Context, userCtx context.Context, userID, questionnaireID, id uuid.UUID) (*model.Section, error) {
+	if _, err := getEditableQuestionnaire(ctx, userCtx, s.questionnaireRepo, s.roleService, userID, questionnaireID); err != nil {
+		return nil, err
+	}
+	section, err := s.sectionRepo.GetByID(ctx, userCtx, id)
+	if err != nil {
+		return nil, err
+	}
+	if section.QuestionnaireId != questionnaireID {
+		return nil, apperrors.ErrSectionNotFound
+	}
+	return section, nil
+}
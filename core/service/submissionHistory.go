@@ -0,0 +1,228 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"golizilla/core/domain/model"
+	"golizilla/core/port/repository"
+	"golizilla/internal/apperrors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ISubmissionHistoryService interface {
+	List(ctx context.Context, userCtx context.Context, userID uuid.UUID, page, pageSize int) (PaginatedSubmissionHistory, error)
+	Get(ctx context.Context, userCtx context.Context, userID, submissionID uuid.UUID) (*SubmissionReview, error)
+}
+
+type SubmissionHistoryService struct {
+	submissionRepo    repository.ISubmissionRepository
+	questionnaireRepo repository.IQuestionnaireRepository
+	questionRepo      repository.IQuestionRepository
+}
+
+func NewSubmissionHistoryService(
+	submissionRepo repository.ISubmissionRepository,
+	questionnaireRepo repository.IQuestionnaireRepository,
+	questionRepo repository.IQuestionRepository,
+) ISubmissionHistoryService {
+	return &SubmissionHistoryService{
+		submissionRepo:    submissionRepo,
+		questionnaireRepo: questionnaireRepo,
+		questionRepo:      questionRepo,
+	}
+}
+
+// SubmissionSummary is one entry of a respondent's history. Score is only set on
+// finished submissions of questionnaires that allow answer review and have
+// questions with a correct option.
+type SubmissionSummary struct {
+	SubmissionID       uuid.UUID              `json:"submission_id"`
+	QuestionnaireID    uuid.UUID              `json:"questionnaire_id"`
+	QuestionnaireTitle string                 `json:"questionnaire_title"`
+	Status             model.SubmissionStatus `json:"status"`
+	StartedAt          time.Time              `json:"started_at"`
+	UpdatedAt          time.Time              `json:"updated_at"`
+	Score              *float64               `json:"score,omitempty"` // percent of graded questions answered correctly
+	AnswerReview       bool                   `json:"answer_review"`
+}
+
+type PaginatedSubmissionHistory struct {
+	Data  []SubmissionSummary `json:"data"`
+	Pages int                 `json:"pages"`
+	Page  int                 `json:"page"`
+}
+
+// SubmissionReview shows the respondent the answers of one finished submission.
+type SubmissionReview struct {
+	SubmissionSummary
+	Answers []ReviewedAnswer `json:"answers"`
+}
+
+// ReviewedAnswer is a question of the submission with the respondent's answer, if
+// any. Correct is only set on questions with a correct option.
+type ReviewedAnswer struct {
+	QuestionID   uuid.UUID  `json:"question_id"`
+	Index        uint       `json:"index"`
+	QuestionText string     `json:"question_text"`
+	Answered     bool       `json:"answered"`
+	Text         *string    `json:"text,omitempty"`
+	OptionID     *uuid.UUID `json:"option_id,omitempty"`
+	OptionText   string     `json:"option_text,omitempty"`
+	FileName     string     `json:"file_name,omitempty"`
+	Correct      *bool      `json:"correct,omitempty"`
+}
+
+// List returns a page of the user's submissions, newest first.
+func (s *SubmissionHistoryService) List(ctx context.Context, userCtx context.Context, userID uuid.UUID, page, pageSize int) (PaginatedSubmissionHistory, error) {
+	submissions, totalRecords, err := s.submissionRepo.GetByUserID(ctx, userCtx, userID, page, pageSize)
+	if err != nil {
+		return PaginatedSubmissionHistory{}, err
+	}
+
+	questions := make(map[uuid.UUID][]*model.Question)
+	history := make([]SubmissionSummary, len(submissions))
+	for i := range submissions {
+		submission := &submissions[i]
+		qnQuestions, ok := questions[submission.QuestionnaireId]
+		if !ok && submission.Questionnaire.AnswerReview {
+			if qnQuestions, err = s.questionRepo.GetByQuestionnaireID(ctx, userCtx, submission.QuestionnaireId); err != nil {
+				return PaginatedSubmissionHistory{}, err
+			}
+			questions[submission.QuestionnaireId] = qnQuestions
+		}
+		history[i] = newSubmissionSummary(submission, &submission.Questionnaire, qnQuestions)
+	}
+
+	// Calculate total pages based on total records and page size
+	totalPages := int((totalRecords + int64(pageSize) - 1) / int64(pageSize))
+
+	return PaginatedSubmissionHistory{
+		Data:  history,
+		Pages: totalPages,
+		Page:  page,
+	}, nil
+}
+
+// Get returns the user's answers of a finished submission, if its questionnaire
+// allows answer review. Submissions of other users are reported as not found.
+func (s *SubmissionHistoryService) Get(ctx context.Context, userCtx context.Context, userID, submissionID uuid.UUID) (*SubmissionReview, error) {
+	submission, err := s.submissionRepo.GetSubmissionByID(ctx, userCtx, submissionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrSubmissionNotFound
+		}
+		return nil, err
+	}
+	if submission.UserId != userID {
+		return nil, apperrors.ErrSubmissionNotFound
+	}
+
+	qn, err := s.questionnaireRepo.GetById(ctx, userCtx, submission.QuestionnaireId)
+	if err != nil {
+		return nil, err
+	}
+	if !qn.AnswerReview || submission.Status == model.SubmissionsStatusInProgress {
+		return nil, apperrors.ErrAnswerReviewDisabled
+	}
+
+	questions, err := s.questionRepo.GetByQuestionnaireID(ctx, userCtx, qn.Id)
+	if err != nil {
+		return nil, err
+	}
+	answers, err := reviewAnswers(ctx, userCtx, s.questionRepo, submission, questions)
+	if err != nil {
+		return nil, err
+	}
+	return &SubmissionReview{
+		SubmissionSummary: newSubmissionSummary(submission, qn, questions),
+		Answers:           answers,
+	}, nil
+}
+
+// reviewAnswers lists the questions asked in the submission in order, each with
+// the answer given to it.
+func reviewAnswers(ctx context.Context, userCtx context.Context, questionRepo repository.IQuestionRepository, submission *model.UserSubmission, questions []*model.Question) ([]ReviewedAnswer, error) {
+	answers := make(map[uuid.UUID]model.Answer, len(submission.Answers))
+	for _, answer := range submission.Answers {
+		answers[answer.QuestionID] = answer
+	}
+
+	reviewedAnswers := []ReviewedAnswer{}
+	for _, question := range submissionQuestions(submission, questions) {
+		reviewed := ReviewedAnswer{
+			QuestionID:   question.ID,
+			Index:        question.Index,
+			QuestionText: question.QuestionText,
+		}
+		answer, answered := answers[question.ID]
+		if answered {
+			reviewed.Answered = true
+			reviewed.Text = answer.Text
+			reviewed.OptionID = answer.OptionID
+			reviewed.FileName = answer.FileName
+			if answer.OptionID != nil {
+				full, err := questionRepo.GetByID(ctx, userCtx, question.ID)
+				if err != nil {
+					return nil, err
+				}
+				for _, option := range full.Options {
+					if option.ID == *answer.OptionID {
+						reviewed.OptionText = option.Text
+					}
+				}
+			}
+		}
+		if question.CorrectOptionID != nil {
+			correct := answered && answer.OptionID != nil && *answer.OptionID == *question.CorrectOptionID
+			reviewed.Correct = &correct
+		}
+		reviewedAnswers = append(reviewedAnswers, reviewed)
+	}
+	return reviewedAnswers, nil
+}
+
+func newSubmissionSummary(submission *model.UserSubmission, qn *model.Questionnaire, questions []*model.Question) SubmissionSummary {
+	summary := SubmissionSummary{
+		SubmissionID:       submission.ID,
+		QuestionnaireID:    submission.QuestionnaireId,
+		QuestionnaireTitle: qn.Title,
+		Status:             submission.Status,
+		StartedAt:          submission.CreatedAt,
+		UpdatedAt:          submission.UpdatedAt,
+		AnswerReview:       qn.AnswerReview,
+	}
+	if qn.AnswerReview && submission.Status == model.SubmissionsStatusDone {
+		summary.Score = submissionScore(submission, questions)
+	}
+	return summary
+}
+
+// submissionScore grades the choice questions with a correct option that were
+// asked in the submission, or returns nil when there are none.
+func submissionScore(submission *model.UserSubmission, questions []*model.Question) *float64 {
+	chosen := make(map[uuid.UUID]uuid.UUID, len(submission.Answers))
+	for _, answer := range submission.Answers {
+		if answer.OptionID != nil {
+			chosen[answer.QuestionID] = *answer.OptionID
+		}
+	}
+
+	graded, correct := 0, 0
+	for _, question := range submissionQuestions(submission, questions) {
+		if question.CorrectOptionID == nil {
+			continue
+		}
+		graded++
+		if optionID, ok := chosen[question.ID]; ok && optionID == *question.CorrectOptionID {
+			correct++
+		}
+	}
+	if graded == 0 {
+		return nil
+	}
+	score := float64(correct) * 100 / float64(graded)
+	return &score
+}
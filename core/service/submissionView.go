@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"golizilla/core/domain/model"
+	"golizilla/core/port/repository"
+	"golizilla/internal/apperrors"
+	privilegeconstants "golizilla/internal/privilege"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ISubmissionViewService interface {
+	List(ctx context.Context, userCtx context.Context, userID, questionnaireID uuid.UUID, filter model.SubmissionFilter, page, pageSize int) (PaginatedSubmissions, error)
+	Get(ctx context.Context, userCtx context.Context, userID, questionnaireID, submissionID uuid.UUID) (*SubmissionDetail, error)
+}
+
+type SubmissionViewService struct {
+	submissionRepo    repository.ISubmissionRepository
+	questionnaireRepo repository.IQuestionnaireRepository
+	questionRepo      repository.IQuestionRepository
+	roleService       IRoleService
+}
+
+func NewSubmissionViewService(
+	submissionRepo repository.ISubmissionRepository,
+	questionnaireRepo repository.IQuestionnaireRepository,
+	questionRepo repository.IQuestionRepository,
+	roleService IRoleService,
+) ISubmissionViewService {
+	return &SubmissionViewService{
+		submissionRepo:    submissionRepo,
+		questionnaireRepo: questionnaireRepo,
+		questionRepo:      questionRepo,
+		roleService:       roleService,
+	}
+}
+
+// SubmissionItem is one submission as seen by the questionnaire owner. UserID is
+// left out on anonymous questionnaires.
+type SubmissionItem struct {
+	SubmissionID uuid.UUID              `json:"submission_id"`
+	UserID       *uuid.UUID             `json:"user_id,omitempty"`
+	Status       model.SubmissionStatus `json:"status"`
+	StartedAt    time.Time              `json:"started_at"`
+	UpdatedAt    time.Time              `json:"updated_at"`
+	Answered     int                    `json:"answered"`
+	Score        *float64               `json:"score,omitempty"` // percent of graded questions answered correctly
+	Variables    map[string]string      `json:"variables,omitempty"`
+}
+
+type PaginatedSubmissions struct {
+	Data  []SubmissionItem `json:"data"`
+	Pages int              `json:"pages"`
+	Page  int              `json:"page"`
+}
+
+type SubmissionDetail struct {
+	SubmissionItem
+	Answers []ReviewedAnswer `json:"answers"`
+}
+
+// List returns a page of the questionnaire's submissions to its owner or to users
+// holding SeeVoteOnInstance.
+func (s *SubmissionViewService) List(ctx context.Context, userCtx context.Context, userID, questionnaireID uuid.UUID, filter model.SubmissionFilter, page, pageSize int) (PaginatedSubmissions, error) {
+	qn, err := s.getViewableQuestionnaire(ctx, userCtx, userID, questionnaireID)
+	if err != nil {
+		return PaginatedSubmissions{}, err
+	}
+
+	questions, err := s.questionRepo.GetByQuestionnaireID(ctx, userCtx, questionnaireID)
+	if err != nil {
+		return PaginatedSubmissions{}, err
+	}
+	submissions, totalRecords, err := s.submissionRepo.GetPageByQuestionnaireID(ctx, userCtx, questionnaireID, filter, page, pageSize)
+	if err != nil {
+		return PaginatedSubmissions{}, err
+	}
+
+	items := make([]SubmissionItem, len(submissions))
+	for i := range submissions {
+		items[i] = newSubmissionItem(qn, &submissions[i], questions)
+	}
+
+	// Calculate total pages based on total records and page size
+	totalPages := int((totalRecords + int64(pageSize) - 1) / int64(pageSize))
+
+	return PaginatedSubmissions{
+		Data:  items,
+		Pages: totalPages,
+		Page:  page,
+	}, nil
+}
+
+// Get returns one submission of the questionnaire with its answers in question
+// order.
+func (s *SubmissionViewService) Get(ctx context.Context, userCtx context.Context, userID, questionnaireID, submissionID uuid.UUID) (*SubmissionDetail, error) {
+	qn, err := s.getViewableQuestionnaire(ctx, userCtx, userID, questionnaireID)
+	if err != nil {
+		return nil, err
+	}
+
+	submission, err := s.submissionRepo.GetSubmissionByID(ctx, userCtx, submissionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrSubmissionNotFound
+		}
+		return nil, err
+	}
+	if submission.QuestionnaireId != questionnaireID {
+		return nil, apperrors.ErrSubmissionNotFound
+	}
+
+	questions, err := s.questionRepo.GetByQuestionnaireID(ctx, userCtx, questionnaireID)
+	if err != nil {
+		return nil, err
+	}
+	answers, err := reviewAnswers(ctx, userCtx, s.questionRepo, submission, questions)
+	if err != nil {
+		return nil, err
+	}
+	return &SubmissionDetail{
+		SubmissionItem: newSubmissionItem(qn, submission, questions),
+		Answers:        answers,
+	}, nil
+}
+
+func (s *SubmissionViewService) getViewableQuestionnaire(ctx context.Context, userCtx context.Context, userID, questionnaireID uuid.UUID) (*model.Questionnaire, error) {
+	qn, err := s.questionnaireRepo.GetById(ctx, userCtx, questionnaireID)
+	if err != nil {
+		return nil, err
+	}
+	if qn.OwnerId != userID {
+		hasPrivilege, err := s.roleService.HasPrivilegesOnInsance(ctx, userCtx, userID, questionnaireID, privilegeconstants.SeeVoteOnInstance)
+		if err != nil {
+			return nil, err
+		}
+		if !hasPrivilege {
+			return nil, apperrors.ErrLackOfAuthorization
+		}
+	}
+	return qn, nil
+}
+
+func newSubmissionItem(qn *model.Questionnaire, submission *model.UserSubmission, questions []*model.Question) SubmissionItem {
+	item := SubmissionItem{
+		SubmissionID: submission.ID,
+		Status:       submission.Status,
+		StartedAt:    submission.CreatedAt,
+		UpdatedAt:    submission.UpdatedAt,
+		Answered:     len(submission.Answers),
+		Score:        submissionScore(submission, questions),
+		Variables:    submission.VariableMap(),
+	}
+	if !qn.Anonymous {
+		userID := submission.UserId
+		item.UserID = &userID
+	}
+	return item
+}
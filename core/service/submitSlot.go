@@ -0,0 +1,313 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"golizilla/adapters/persistence/logger"
+	"golizilla/core/domain/model"
+	"golizilla/core/port/repository"
+	"golizilla/internal/apperrors"
+	logmessages "golizilla/internal/logmessages"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ISubmitSlotService interface {
+	SetTradingEnabled(ctx context.Context, userCtx context.Context, ownerID, questionnaireID uuid.UUID, enabled bool) error
+	CreateListing(ctx context.Context, userCtx context.Context, sellerID, questionnaireID uuid.UUID, price uint) (*model.SubmitSlotListing, error)
+	CancelListing(ctx context.Context, userCtx context.Context, sellerID, listingID uuid.UUID) (*model.SubmitSlotListing, error)
+	BuyListing(ctx context.Context, userCtx context.Context, buyerID, listingID uuid.UUID) (*model.SubmitSlotListing, error)
+	GetOpenListings(ctx context.Context, userCtx context.Context, questionnaireID uuid.UUID, page, pageSize int) (PaginatedSubmitSlotListings, error)
+	GetAuditLogs(ctx context.Context, userCtx context.Context, ownerID, questionnaireID uuid.UUID, page, pageSize int) (PaginatedSubmitSlotAuditLogs, error)
+}
+
+type SubmitSlotService struct {
+	submitSlotRepo    repository.ISubmitSlotRepository
+	questionnaireRepo repository.IQuestionnaireRepository
+	submissionRepo    repository.ISubmissionRepository
+	walletRepo        repository.IWalletRepository
+}
+
+func NewSubmitSlotService(
+	submitSlotRepo repository.ISubmitSlotRepository,
+	questionnaireRepo repository.IQuestionnaireRepository,
+	submissionRepo repository.ISubmissionRepository,
+	walletRepo repository.IWalletRepository,
+) ISubmitSlotService {
+	return &SubmitSlotService{
+		submitSlotRepo:    submitSlotRepo,
+		questionnaireRepo: questionnaireRepo,
+		submissionRepo:    submissionRepo,
+		walletRepo:        walletRepo,
+	}
+}
+
+type PaginatedSubmitSlotListings struct {
+	Data  []model.SubmitSlotListing `json:"data"`
+	Pages int                       `json:"pages"`
+	Page  int                       `json:"page"`
+}
+
+type PaginatedSubmitSlotAuditLogs struct {
+	Data  []model.SubmitSlotAuditLog `json:"data"`
+	Pages int                        `json:"pages"`
+	Page  int                        `json:"page"`
+}
+
+func (s *SubmitSlotService) SetTradingEnabled(ctx context.Context, userCtx context.Context, ownerID, questionnaireID uuid.UUID, enabled bool) error {
+	if err := s.checkOwner(ctx, userCtx, ownerID, questionnaireID); err != nil {
+		return err
+	}
+
+	if err := s.questionnaireRepo.Update(ctx, userCtx, questionnaireID, map[string]interface{}{"slot_trading_enabled": enabled}); err != nil {
+		return err
+	}
+
+	action := model.SubmitSlotAuditTradingDisabled
+	if enabled {
+		action = model.SubmitSlotAuditTradingEnabled
+	}
+	return s.submitSlotRepo.AddAuditLog(ctx, userCtx, &model.SubmitSlotAuditLog{
+		QuestionnaireId: questionnaireID,
+		ActorId:         ownerID,
+		Action:          action,
+	})
+}
+
+// CreateListing puts one of the seller's unused submission slots up for sale. The
+// slot is removed from the seller's limit right away so it cannot be used meanwhile.
+func (s *SubmitSlotService) CreateListing(ctx context.Context, userCtx context.Context, sellerID, questionnaireID uuid.UUID, price uint) (*model.SubmitSlotListing, error) {
+	if price == 0 {
+		return nil, apperrors.ErrInvalidTransferAmount
+	}
+
+	qn, err := s.getTradableQuestionnaire(ctx, userCtx, questionnaireID)
+	if err != nil {
+		return nil, err
+	}
+
+	// count submissions and listed slots only once parallel requests are done
+	if err := s.submitSlotRepo.LockSlots(ctx, userCtx, sellerID, questionnaireID); err != nil {
+		return nil, err
+	}
+	limit, err := s.effectiveSubmitLimit(ctx, userCtx, sellerID, qn)
+	if err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		return nil, apperrors.ErrNoUnusedSlot
+	}
+	hasUnusedSlot, err := s.submissionRepo.SubmitCount(ctx, userCtx, sellerID, questionnaireID, uint(limit))
+	if err != nil {
+		return nil, err
+	}
+	if !hasUnusedSlot {
+		return nil, apperrors.ErrNoUnusedSlot
+	}
+
+	listing := &model.SubmitSlotListing{
+		QuestionnaireId: questionnaireID,
+		SellerId:        sellerID,
+		Price:           price,
+		Status:          model.SubmitSlotListingOpen,
+	}
+	if err := s.submitSlotRepo.CreateListing(ctx, userCtx, listing); err != nil {
+		return nil, err
+	}
+	if err := s.submitSlotRepo.AddAdjustment(ctx, userCtx, &model.SubmitSlotAdjustment{
+		UserId:          sellerID,
+		QuestionnaireId: questionnaireID,
+		Delta:           -1,
+		ListingID:       &listing.ID,
+	}); err != nil {
+		return nil, err
+	}
+	if err := s.submitSlotRepo.AddAuditLog(ctx, userCtx, &model.SubmitSlotAuditLog{
+		QuestionnaireId: questionnaireID,
+		ActorId:         sellerID,
+		Action:          model.SubmitSlotAuditListed,
+		ListingID:       &listing.ID,
+		Price:           price,
+	}); err != nil {
+		return nil, err
+	}
+
+	return listing, nil
+}
+
+// CancelListing withdraws an open listing and gives the slot back to the seller.
+func (s *SubmitSlotService) CancelListing(ctx context.Context, userCtx context.Context, sellerID, listingID uuid.UUID) (*model.SubmitSlotListing, error) {
+	listing, err := s.submitSlotRepo.GetListingByIDForUpdate(ctx, userCtx, listingID)
+	if err != nil {
+		return nil, err
+	}
+	if listing.SellerId != sellerID {
+		return nil, apperrors.ErrLackOfAuthorization
+	}
+	if listing.Status != model.SubmitSlotListingOpen {
+		return nil, apperrors.ErrSlotListingNotOpen
+	}
+
+	listing.Status = model.SubmitSlotListingCancelled
+	if err := s.submitSlotRepo.UpdateListing(ctx, userCtx, listing); err != nil {
+		return nil, err
+	}
+	if err := s.submitSlotRepo.AddAdjustment(ctx, userCtx, &model.SubmitSlotAdjustment{
+		UserId:          sellerID,
+		QuestionnaireId: listing.QuestionnaireId,
+		Delta:           1,
+		ListingID:       &listing.ID,
+	}); err != nil {
+		return nil, err
+	}
+	if err := s.submitSlotRepo.AddAuditLog(ctx, userCtx, &model.SubmitSlotAuditLog{
+		QuestionnaireId: listing.QuestionnaireId,
+		ActorId:         sellerID,
+		Action:          model.SubmitSlotAuditCancelled,
+		ListingID:       &listing.ID,
+		Price:           listing.Price,
+	}); err != nil {
+		return nil, err
+	}
+
+	return listing, nil
+}
+
+// BuyListing pays the seller from the buyer's wallet and adds the slot to the
+// buyer's submit limit for that questionnaire. Payment, listing and slot changes
+// share the request transaction, so either all of them happen or none do.
+func (s *SubmitSlotService) BuyListing(ctx context.Context, userCtx context.Context, buyerID, listingID uuid.UUID) (*model.SubmitSlotListing, error) {
+	listing, err := s.submitSlotRepo.GetListingByIDForUpdate(ctx, userCtx, listingID)
+	if err != nil {
+		return nil, err
+	}
+	if listing.Status != model.SubmitSlotListingOpen {
+		return nil, apperrors.ErrSlotListingNotOpen
+	}
+	if listing.SellerId == buyerID {
+		return nil, apperrors.ErrSlotSelfPurchase
+	}
+
+	if _, err := s.getTradableQuestionnaire(ctx, userCtx, listing.QuestionnaireId); err != nil {
+		return nil, err
+	}
+
+	transaction := &model.WalletTransaction{
+		Type:        model.WalletTransactionSlotSale,
+		Description: fmt.Sprintf("submission slot %s", listing.ID),
+		Entries: []model.LedgerEntry{
+			{AccountID: buyerID, Amount: -int64(listing.Price)},
+			{AccountID: listing.SellerId, Amount: int64(listing.Price)},
+		},
+	}
+	if err := s.walletRepo.Post(ctx, userCtx, transaction); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	listing.Status = model.SubmitSlotListingSold
+	listing.BuyerId = &buyerID
+	listing.TransactionID = &transaction.ID
+	listing.SoldAt = &now
+	if err := s.submitSlotRepo.UpdateListing(ctx, userCtx, listing); err != nil {
+		return nil, err
+	}
+	if err := s.submitSlotRepo.AddAdjustment(ctx, userCtx, &model.SubmitSlotAdjustment{
+		UserId:          buyerID,
+		QuestionnaireId: listing.QuestionnaireId,
+		Delta:           1,
+		ListingID:       &listing.ID,
+	}); err != nil {
+		return nil, err
+	}
+	if err := s.submitSlotRepo.AddAuditLog(ctx, userCtx, &model.SubmitSlotAuditLog{
+		QuestionnaireId: listing.QuestionnaireId,
+		ActorId:         buyerID,
+		Action:          model.SubmitSlotAuditSold,
+		ListingID:       &listing.ID,
+		TransactionID:   &transaction.ID,
+		Price:           listing.Price,
+	}); err != nil {
+		return nil, err
+	}
+
+	logger.GetLogger().LogInfoFromContext(ctx, logger.LogFields{
+		Service: logmessages.LogSubmitSlotService,
+		Message: fmt.Sprintf("%s: listing_id=%s transaction_id=%s", logmessages.LogSubmitSlotSoldSuccessful, listing.ID, transaction.ID),
+	})
+
+	return listing, nil
+}
+
+func (s *SubmitSlotService) GetOpenListings(ctx context.Context, userCtx context.Context, questionnaireID uuid.UUID, page, pageSize int) (PaginatedSubmitSlotListings, error) {
+	listings, totalRecords, err := s.submitSlotRepo.GetOpenListings(ctx, userCtx, questionnaireID, page, pageSize)
+	if err != nil {
+		return PaginatedSubmitSlotListings{}, err
+	}
+
+	// Calculate total pages based on total records and page size
+	totalPages := int((totalRecords + int64(pageSize) - 1) / int64(pageSize))
+
+	return PaginatedSubmitSlotListings{
+		Data:  listings,
+		Pages: totalPages,
+		Page:  page,
+	}, nil
+}
+
+func (s *SubmitSlotService) GetAuditLogs(ctx context.Context, userCtx context.Context, ownerID, questionnaireID uuid.UUID, page, pageSize int) (PaginatedSubmitSlotAuditLogs, error) {
+	if err := s.checkOwner(ctx, userCtx, ownerID, questionnaireID); err != nil {
+		return PaginatedSubmitSlotAuditLogs{}, err
+	}
+
+	auditLogs, totalRecords, err := s.submitSlotRepo.GetAuditLogs(ctx, userCtx, questionnaireID, page, pageSize)
+	if err != nil {
+		return PaginatedSubmitSlotAuditLogs{}, err
+	}
+
+	// Calculate total pages based on total records and page size
+	totalPages := int((totalRecords + int64(pageSize) - 1) / int64(pageSize))
+
+	return PaginatedSubmitSlotAuditLogs{
+		Data:  auditLogs,
+		Pages: totalPages,
+		Page:  page,
+	}, nil
+}
+
+func (s *SubmitSlotService) checkOwner(ctx context.Context, userCtx context.Context, ownerID, questionnaireID uuid.UUID) error {
+	qn, err := s.questionnaireRepo.GetById(ctx, userCtx, questionnaireID)
+	if err != nil {
+		return err
+	}
+	if qn.OwnerId != ownerID {
+		return apperrors.ErrLackOfAuthorization
+	}
+	return nil
+}
+
+func (s *SubmitSlotService) getTradableQuestionnaire(ctx context.Context, userCtx context.Context, questionnaireID uuid.UUID) (*model.Questionnaire, error) {
+	qn, err := s.questionnaireRepo.GetById(ctx, userCtx, questionnaireID)
+	if err != nil {
+		return nil, err
+	}
+	if qn.SubmitLimit == 0 {
+		return nil, apperrors.ErrSlotTradingUnavailable
+	}
+	if !qn.SlotTradingEnabled {
+		return nil, apperrors.ErrSlotTradingDisabled
+	}
+	if time.Now().After(qn.EndTime) {
+		return nil, apperrors.ErrQuestionnareExpired
+	}
+	return qn, nil
+}
+
+func (s *SubmitSlotService) effectiveSubmitLimit(ctx context.Context, userCtx context.Context, userID uuid.UUID, qn *model.Questionnaire) (int, error) {
+	delta, err := s.submitSlotRepo.GetSlotDelta(ctx, userCtx, userID, qn.Id)
+	if err != nil {
+		return 0, err
+	}
+	return int(qn.SubmitLimit) + delta, nil
+}
@@ -0,0 +1,181 @@
+package service
+
+import (
+	"context"
+	"golizilla/core/port/repository"
+	"golizilla/internal/apperrors"
+	privilegeconstants "golizilla/internal/privilege"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// minFastDwell is the dwell time below which an answer is always considered too fast.
+const minFastDwell = 2 * time.Second
+
+type ITimingService interface {
+	GetAnalytics(ctx context.Context, userCtx context.Context, userID, questionnaireID uuid.UUID, fastRatio float64) (*TimingAnalytics, error)
+}
+
+type TimingService struct {
+	questionnaireRepo repository.IQuestionnaireRepository
+	questionRepo      repository.IQuestionRepository
+	submissionRepo    repository.ISubmissionRepository
+	questionVisitRepo repository.IQuestionVisitRepository
+	roleService       IRoleService
+}
+
+func NewTimingService(
+	questionnaireRepo repository.IQuestionnaireRepository,
+	questionRepo repository.IQuestionRepository,
+	submissionRepo repository.ISubmissionRepository,
+	questionVisitRepo repository.IQuestionVisitRepository,
+	roleService IRoleService,
+) ITimingService {
+	return &TimingService{
+		questionnaireRepo: questionnaireRepo,
+		questionRepo:      questionRepo,
+		submissionRepo:    submissionRepo,
+		questionVisitRepo: questionVisitRepo,
+		roleService:       roleService,
+	}
+}
+
+// TimingAnalytics describes how long respondents spent on each question of a
+// questionnaire. Times are in seconds.
+type TimingAnalytics struct {
+	QuestionnaireID uuid.UUID           `json:"questionnaire_id"`
+	FastRatio       float64             `json:"fast_ratio"`
+	Questions       []QuestionTiming    `json:"questions"`
+	Flagged         []FlaggedSubmission `json:"flagged"`
+}
+
+type QuestionTiming struct {
+	QuestionID  uuid.UUID `json:"question_id"`
+	Index       uint      `json:"index"`
+	TimeLimit   uint      `json:"time_limit,omitempty"`
+	Respondents int       `json:"respondents"`
+	Average     float64   `json:"average"`
+	Median      float64   `json:"median"`
+	Min         float64   `json:"min"`
+	Max         float64   `json:"max"`
+}
+
+// FlaggedSubmission is a submission that answered at least one question much faster
+// than the median respondent. UserID is left out on anonymous questionnaires.
+type FlaggedSubmission struct {
+	SubmissionID uuid.UUID      `json:"submission_id"`
+	UserID       *uuid.UUID     `json:"user_id,omitempty"`
+	Questions    []FastQuestion `json:"questions"`
+}
+
+type FastQuestion struct {
+	QuestionID uuid.UUID `json:"question_id"`
+	Seconds    float64   `json:"seconds"`
+	Median     float64   `json:"median"`
+}
+
+// GetAnalytics returns the dwell-time statistics of a questionnaire to its owner or
+// to users holding SeeResultsOnInstance. An answered question counts as too fast
+// when its dwell time is below fastRatio times the median of that question, and
+// always when it took under two seconds.
+func (s *TimingService) GetAnalytics(ctx context.Context, userCtx context.Context, userID, questionnaireID uuid.UUID, fastRatio float64) (*TimingAnalytics, error) {
+	qn, err := s.questionnaireRepo.GetById(ctx, userCtx, questionnaireID)
+	if err != nil {
+		return nil, err
+	}
+	if qn.OwnerId != userID {
+		hasPrivilege, err := s.roleService.HasPrivilegesOnInsance(ctx, userCtx, userID, questionnaireID, privilegeconstants.SeeResultsOnInstance)
+		if err != nil {
+			return nil, err
+		}
+		if !hasPrivilege {
+			return nil, apperrors.ErrLackOfAuthorization
+		}
+	}
+
+	questions, err := s.questionRepo.GetByQuestionnaireID(ctx, userCtx, questionnaireID)
+	if err != nil {
+		return nil, err
+	}
+	submissions, err := s.submissionRepo.GetByQuestionnaireID(ctx, userCtx, questionnaireID)
+	if err != nil {
+		return nil, err
+	}
+	dwell, err := s.questionVisitRepo.GetDwellByQuestionnaireID(ctx, userCtx, questionnaireID)
+	if err != nil {
+		return nil, err
+	}
+
+	durations := make(map[uuid.UUID][]time.Duration)
+	for _, d := range dwell {
+		durations[d.QuestionID] = append(durations[d.QuestionID], time.Duration(d.DurationMs)*time.Millisecond)
+	}
+
+	analytics := &TimingAnalytics{
+		QuestionnaireID: questionnaireID,
+		FastRatio:       fastRatio,
+		Questions:       make([]QuestionTiming, 0, len(questions)),
+		Flagged:         []FlaggedSubmission{},
+	}
+	medians := make(map[uuid.UUID]time.Duration, len(questions))
+	for _, question := range questions {
+		timing := QuestionTiming{
+			QuestionID: question.ID,
+			Index:      question.Index,
+			TimeLimit:  question.TimeLimit,
+		}
+		if times := durations[question.ID]; len(times) > 0 {
+			slices.Sort(times)
+			var total time.Duration
+			for _, t := range times {
+				total += t
+			}
+			median := times[len(times)/2]
+			if len(times)%2 == 0 {
+				median = (times[len(times)/2-1] + median) / 2
+			}
+			medians[question.ID] = median
+
+			timing.Respondents = len(times)
+			timing.Average = (total / time.Duration(len(times))).Seconds()
+			timing.Median = median.Seconds()
+			timing.Min = times[0].Seconds()
+			timing.Max = times[len(times)-1].Seconds()
+		}
+		analytics.Questions = append(analytics.Questions, timing)
+	}
+
+	fast := make(map[uuid.UUID][]FastQuestion)
+	for _, d := range dwell {
+		median, ok := medians[d.QuestionID]
+		if !ok || !d.Answered {
+			continue
+		}
+		spent := time.Duration(d.DurationMs) * time.Millisecond
+		if spent < max(minFastDwell, time.Duration(fastRatio*float64(median))) {
+			fast[d.UserSubmissionID] = append(fast[d.UserSubmissionID], FastQuestion{
+				QuestionID: d.QuestionID,
+				Seconds:    spent.Seconds(),
+				Median:     median.Seconds(),
+			})
+		}
+	}
+	for _, submission := range submissions {
+		questions, ok := fast[submission.ID]
+		if !ok {
+			continue
+		}
+		flagged := FlaggedSubmission{
+			SubmissionID: submission.ID,
+			Questions:    questions,
+		}
+		if !qn.Anonymous {
+			userID := submission.UserId
+			flagged.UserID = &userID
+		}
+		analytics.Flagged = append(analytics.Flagged, flagged)
+	}
+	return analytics, nil
+}
@@ -0,0 +1,305 @@
+package service
+
+import (
+	"context"
+	"golizilla/core/domain/model"
+	"golizilla/core/port/repository"
+	"golizilla/internal/apperrors"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+type ITranslationService interface {
+	SetQuestionnaireTranslation(ctx context.Context, userCtx context.Context, userID, questionnaireID uuid.UUID, locale, title string) error
+	SetQuestionTranslation(ctx context.Context, userCtx context.Context, userID, questionID uuid.UUID, locale, text string, options map[uuid.UUID]string) error
+	GetTranslations(ctx context.Context, userCtx context.Context, userID, questionnaireID uuid.UUID) (*QuestionnaireTranslations, error)
+	DeleteLocale(ctx context.Context, userCtx context.Context, userID, questionnaireID uuid.UUID, locale string) error
+	LocalizeQuestionnaire(ctx context.Context, userCtx context.Context, userID uuid.UUID, questionnaire *model.Questionnaire, acceptLanguage string) (string, error)
+	LocalizeQuestion(ctx context.Context, userCtx context.Context, userID uuid.UUID, question *model.Question, acceptLanguage string) (string, error)
+}
+
+type TranslationService struct {
+	translationRepo   repository.ITranslationRepository
+	questionnaireRepo repository.IQuestionnaireRepository
+	questionRepo      repository.IQuestionRepository
+	userRepo          repository.IUserRepository
+	roleService       IRoleService
+}
+
+func NewTranslationService(
+	translationRepo repository.ITranslationRepository,
+	questionnaireRepo repository.IQuestionnaireRepository,
+	questionRepo repository.IQuestionRepository,
+	userRepo repository.IUserRepository,
+	roleService IRoleService,
+) ITranslationService {
+	return &TranslationService{
+		translationRepo:   translationRepo,
+		questionnaireRepo: questionnaireRepo,
+		questionRepo:      questionRepo,
+		userRepo:          userRepo,
+		roleService:       roleService,
+	}
+}
+
+// QuestionnaireTranslations lists every translation of a questionnaire, its
+// questions and their options.
+type QuestionnaireTranslations struct {
+	DefaultLocale string
+	Locales       []string
+	Translations  []model.Translation
+}
+
+func (s *TranslationService) SetQuestionnaireTranslation(ctx context.Context, userCtx context.Context, userID, questionnaireID uuid.UUID, locale, title string) error {
+	qn, err := getEditableQuestionnaire(ctx, userCtx, s.questionnaireRepo, s.roleService, userID, questionnaireID)
+	if err != nil {
+		return err
+	}
+	locale, err = checkTranslationLocale(qn, locale)
+	if err != nil {
+		return err
+	}
+
+	return s.translationRepo.Upsert(ctx, userCtx, []model.Translation{{
+		QuestionnaireId: qn.Id,
+		EntityType:      model.TranslationQuestionnaireTitle,
+		EntityID:        qn.Id,
+		Locale:          locale,
+		Text:            title,
+	}})
+}
+
+// SetQuestionTranslation stores the text of a question and, optionally, of its
+// options in one locale. Options are addressed by ID so every translation maps
+// back to the same option.
+func (s *TranslationService) SetQuestionTranslation(ctx context.Context, userCtx context.Context, userID, questionID uuid.UUID, locale, text string, options map[uuid.UUID]string) error {
+	question, err := s.questionRepo.GetByID(ctx, userCtx, questionID)
+	if err != nil {
+		return err
+	}
+	qn, err := getEditableQuestionnaire(ctx, userCtx, s.questionnaireRepo, s.roleService, userID, question.QuestionnaireId)
+	if err != nil {
+		return err
+	}
+	locale, err = checkTranslationLocale(qn, locale)
+	if err != nil {
+		return err
+	}
+
+	var translations []model.Translation
+	if text != "" {
+		translations = append(translations, model.Translation{
+			QuestionnaireId: qn.Id,
+			EntityType:      model.TranslationQuestionText,
+			EntityID:        question.ID,
+			Locale:          locale,
+			Text:            text,
+		})
+	}
+	for optionID, optionText := range options {
+		if !slices.ContainsFunc(question.Options, func(option model.Option) bool { return option.ID == optionID }) {
+			return apperrors.ErrOptionNotFound
+		}
+		translations = append(translations, model.Translation{
+			QuestionnaireId: qn.Id,
+			EntityType:      model.TranslationOptionText,
+			EntityID:        optionID,
+			Locale:          locale,
+			Text:            optionText,
+		})
+	}
+
+	return s.translationRepo.Upsert(ctx, userCtx, translations)
+}
+
+func (s *TranslationService) GetTranslations(ctx context.Context, userCtx context.Context, userID, questionnaireID uuid.UUID) (*QuestionnaireTranslations, error) {
+	qn, err := getEditableQuestionnaire(ctx, userCtx, s.questionnaireRepo, s.roleService, userID, questionnaireID)
+	if err != nil {
+		return nil, err
+	}
+
+	locales, err := s.translationRepo.GetLocales(ctx, userCtx, questionnaireID)
+	if err != nil {
+		return nil, err
+	}
+	translations, err := s.translationRepo.GetByQuestionnaireID(ctx, userCtx, questionnaireID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &QuestionnaireTranslations{
+		DefaultLocale: qn.DefaultLocale,
+		Locales:       locales,
+		Translations:  translations,
+	}, nil
+}
+
+func (s *TranslationService) DeleteLocale(ctx context.Context, userCtx context.Context, userID, questionnaireID uuid.UUID, locale string) error {
+	qn, err := getEditableQuestionnaire(ctx, userCtx, s.questionnaireRepo, s.roleService, userID, questionnaireID)
+	if err != nil {
+		return err
+	}
+	locale, err = checkTranslationLocale(qn, locale)
+	if err != nil {
+		return err
+	}
+	return s.translationRepo.DeleteLocale(ctx, userCtx, questionnaireID, locale)
+}
+
+// LocalizeQuestionnaire replaces the title with its translation in the locale picked
+// for the user and returns that locale.
+func (s *TranslationService) LocalizeQuestionnaire(ctx context.Context, userCtx context.Context, userID uuid.UUID, questionnaire *model.Questionnaire, acceptLanguage string) (string, error) {
+	locale, err := s.resolveLocale(ctx, userCtx, userID, questionnaire, acceptLanguage)
+	if err != nil || locale == questionnaire.DefaultLocale {
+		return locale, err
+	}
+
+	translations, err := s.translationRepo.GetByLocale(ctx, userCtx, questionnaire.Id, locale)
+	if err != nil {
+		return "", err
+	}
+	for _, translation := range translations {
+		if translation.EntityType == model.TranslationQuestionnaireTitle && translation.EntityID == questionnaire.Id {
+			questionnaire.Title = translation.Text
+		}
+	}
+	return locale, nil
+}
+
+// LocalizeQuestion replaces the question and option texts with their translations
+// in the locale picked for the user and returns that locale. Texts without a
+// translation stay in the default locale.
+func (s *TranslationService) LocalizeQuestion(ctx context.Context, userCtx context.Context, userID uuid.UUID, question *model.Question, acceptLanguage string) (string, error) {
+	qn, err := s.questionnaireRepo.GetById(ctx, userCtx, question.QuestionnaireId)
+	if err != nil {
+		return "", err
+	}
+	locale, err := s.resolveLocale(ctx, userCtx, userID, qn, acceptLanguage)
+	if err != nil || locale == qn.DefaultLocale {
+		return locale, err
+	}
+
+	translations, err := s.translationRepo.GetByLocale(ctx, userCtx, qn.Id, locale)
+	if err != nil {
+		return "", err
+	}
+	texts := make(map[uuid.UUID]string, len(translations))
+	for _, translation := range translations {
+		if translation.EntityType == model.TranslationQuestionText || translation.EntityType == model.TranslationOptionText {
+			texts[translation.EntityID] = translation.Text
+		}
+	}
+
+	if text, ok := texts[question.ID]; ok {
+		question.QuestionText = text
+	}
+	for i := range question.Options {
+		if text, ok := texts[question.Options[i].ID]; ok {
+			question.Options[i].Text = text
+		}
+	}
+	return locale, nil
+}
+
+// resolveLocale picks the language of a questionnaire for a user: the profile
+// preference first, then the Accept-Language header, then the default locale.
+func (s *TranslationService) resolveLocale(ctx context.Context, userCtx context.Context, userID uuid.UUID, questionnaire *model.Questionnaire, acceptLanguage string) (string, error) {
+	defaultLocale := questionnaire.DefaultLocale
+	if defaultLocale == "" {
+		defaultLocale = model.DefaultLocale
+	}
+
+	locales, err := s.translationRepo.GetLocales(ctx, userCtx, questionnaire.Id)
+	if err != nil {
+		return "", err
+	}
+	if len(locales) == 0 {
+		return defaultLocale, nil
+	}
+	available := append(locales, defaultLocale)
+
+	var candidates []string
+	if user, err := s.userRepo.FindByID(ctx, userCtx, userID); err == nil && user.PreferredLocale != "" {
+		candidates = append(candidates, user.PreferredLocale)
+	}
+	candidates = append(candidates, parseAcceptLanguage(acceptLanguage)...)
+
+	if locale, ok := matchLocale(candidates, available); ok {
+		return locale, nil
+	}
+	return defaultLocale, nil
+}
+
+func checkTranslationLocale(questionnaire *model.Questionnaire, locale string) (string, error) {
+	locale, ok := model.NormalizeLocale(locale)
+	if !ok {
+		return "", apperrors.ErrInvalidLocale
+	}
+	if locale == questionnaire.DefaultLocale {
+		return "", apperrors.ErrDefaultLocaleTranslation
+	}
+	return locale, nil
+}
+
+// parseAcceptLanguage returns the language tags of an Accept-Language header,
+// most preferred first.
+func parseAcceptLanguage(header string) []string {
+	type weightedTag struct {
+		tag string
+		q   float64
+	}
+
+	var tags []weightedTag
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(part, ";")
+		tag := strings.TrimSpace(fields[0])
+		if tag == "" || tag == "*" {
+			continue
+		}
+		q := 1.0
+		for _, param := range fields[1:] {
+			if value, ok := strings.CutPrefix(strings.TrimSpace(param), "q="); ok {
+				if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+					q = parsed
+				}
+			}
+		}
+		if q > 0 {
+			tags = append(tags, weightedTag{tag: tag, q: q})
+		}
+	}
+	sort.SliceStable(tags, func(i, j int) bool {
+		return tags[i].q > tags[j].q
+	})
+
+	result := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		result = append(result, tag.tag)
+	}
+	return result
+}
+
+// matchLocale returns the first candidate that is available, either exactly or by
+// its primary language, so "fa-IR" matches "fa".
+func matchLocale(candidates []string, available []string) (string, bool) {
+	for _, candidate := range candidates {
+		candidate, ok := model.NormalizeLocale(candidate)
+		if !ok {
+			continue
+		}
+		if slices.Contains(available, candidate) {
+			return candidate, true
+		}
+		language, _, _ := strings.Cut(candidate, "-")
+		for _, locale := range available {
+			if base, _, _ := strings.Cut(locale, "-"); base == language {
+				return locale, true
+			}
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,237 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"golizilla/adapters/persistence/logger"
+	"golizilla/core/domain/model"
+	"golizilla/core/port/repository"
+	"golizilla/core/port/storage"
+	"golizilla/internal/apperrors"
+	logmessages "golizilla/internal/logmessages"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ITrashService interface {
+	List(ctx context.Context, userCtx context.Context, userID uuid.UUID) (*Trash, error)
+	RestoreQuestionnaire(ctx context.Context, userCtx context.Context, userID, questionnaireID uuid.UUID) error
+	RestoreQuestion(ctx context.Context, userCtx context.Context, userID, questionID uuid.UUID) error
+	RestoreAnswer(ctx context.Context, userCtx context.Context, userID, answerID uuid.UUID) error
+	Purge(ctx context.Context) error
+}
+
+type TrashService struct {
+	trashRepo   repository.ITrashRepository
+	answerRepo  repository.IAnswerRepository
+	fileStorage storage.IFileStorage
+	retention   time.Duration
+}
+
+func NewTrashService(
+	trashRepo repository.ITrashRepository,
+	answerRepo repository.IAnswerRepository,
+	fileStorage storage.IFileStorage,
+	retention time.Duration,
+) ITrashService {
+	return &TrashService{
+		trashRepo:   trashRepo,
+		answerRepo:  answerRepo,
+		fileStorage: fileStorage,
+		retention:   retention,
+	}
+}
+
+// Trash lists what an owner deleted from their questionnaires and can still
+// restore. ExpiresAt is when the purge job removes the item for good.
+type Trash struct {
+	Questionnaires []TrashedQuestionnaire `json:"questionnaires"`
+	Questions      []TrashedQuestion      `json:"questions"`
+	Answers        []TrashedAnswer        `json:"answers"`
+}
+
+type TrashedQuestionnaire struct {
+	ID        uuid.UUID `json:"id"`
+	Title     string    `json:"title"`
+	DeletedAt time.Time `json:"deleted_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type TrashedQuestion struct {
+	ID              uuid.UUID `json:"id"`
+	QuestionnaireID uuid.UUID `json:"questionnaire_id"`
+	Index           uint      `json:"index"`
+	QuestionText    string    `json:"question_text"`
+	DeletedAt       time.Time `json:"deleted_at"`
+	ExpiresAt       time.Time `json:"expires_at"`
+}
+
+type TrashedAnswer struct {
+	ID           uuid.UUID `json:"id"`
+	QuestionID   uuid.UUID `json:"question_id"`
+	SubmissionID uuid.UUID `json:"submission_id"`
+	DeletedAt    time.Time `json:"deleted_at"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
+
+// List returns the user's trash within the retention window.
+func (s *TrashService) List(ctx context.Context, userCtx context.Context, userID uuid.UUID) (*Trash, error) {
+	since := time.Now().Add(-s.retention)
+
+	questionnaires, err := s.trashRepo.GetDeletedQuestionnaires(ctx, userCtx, userID, since)
+	if err != nil {
+		return nil, err
+	}
+	questions, err := s.trashRepo.GetDeletedQuestions(ctx, userCtx, userID, since)
+	if err != nil {
+		return nil, err
+	}
+	answers, err := s.trashRepo.GetDeletedAnswers(ctx, userCtx, userID, since)
+	if err != nil {
+		return nil, err
+	}
+
+	trash := &Trash{
+		Questionnaires: make([]TrashedQuestionnaire, len(questionnaires)),
+		Questions:      make([]TrashedQuestion, len(questions)),
+		Answers:        make([]TrashedAnswer, len(answers)),
+	}
+	for i, qn := range questionnaires {
+		trash.Questionnaires[i] = TrashedQuestionnaire{
+			ID:        qn.Id,
+			Title:     qn.Title,
+			DeletedAt: qn.DeletedAt.Time,
+			ExpiresAt: qn.DeletedAt.Time.Add(s.retention),
+		}
+	}
+	for i, question := range questions {
+		trash.Questions[i] = TrashedQuestion{
+			ID:              question.ID,
+			QuestionnaireID: question.QuestionnaireId,
+			Index:           question.Index,
+			QuestionText:    question.QuestionText,
+			DeletedAt:       question.DeletedAt.Time,
+			ExpiresAt:       question.DeletedAt.Time.Add(s.retention),
+		}
+	}
+	for i, answer := range answers {
+		trash.Answers[i] = TrashedAnswer{
+			ID:           answer.ID,
+			QuestionID:   answer.QuestionID,
+			SubmissionID: answer.UserSubmissionID,
+			DeletedAt:    answer.DeletedAt.Time,
+			ExpiresAt:    answer.DeletedAt.Time.Add(s.retention),
+		}
+	}
+	return trash, nil
+}
+
+func (s *TrashService) RestoreQuestionnaire(ctx context.Context, userCtx context.Context, userID, questionnaireID uuid.UUID) error {
+	qn, err := s.trashRepo.GetQuestionnaire(ctx, userCtx, questionnaireID)
+	if err != nil {
+		return trashLookupError(err)
+	}
+	if qn.OwnerId != userID {
+		return apperrors.ErrLackOfAuthorization
+	}
+	if err := s.checkRestorable(qn.DeletedAt); err != nil {
+		return err
+	}
+	return s.trashRepo.Restore(ctx, userCtx, &model.Questionnaire{}, qn.Id)
+}
+
+// RestoreQuestion brings a question back into its questionnaire, which must not be
+// deleted itself.
+func (s *TrashService) RestoreQuestion(ctx context.Context, userCtx context.Context, userID, questionID uuid.UUID) error {
+	question, err := s.trashRepo.GetQuestion(ctx, userCtx, questionID)
+	if err != nil {
+		return trashLookupError(err)
+	}
+	qn, err := s.trashRepo.GetQuestionnaire(ctx, userCtx, question.QuestionnaireId)
+	if err != nil {
+		return err
+	}
+	if qn.OwnerId != userID {
+		return apperrors.ErrLackOfAuthorization
+	}
+	if err := s.checkRestorable(question.DeletedAt); err != nil {
+		return err
+	}
+	if qn.DeletedAt.Valid {
+		return apperrors.ErrTrashParentDeleted
+	}
+	return s.trashRepo.Restore(ctx, userCtx, &model.Question{}, question.ID)
+}
+
+// RestoreAnswer brings an answer back, unless its question or questionnaire is
+// deleted or the respondent has answered the question again since.
+func (s *TrashService) RestoreAnswer(ctx context.Context, userCtx context.Context, userID, answerID uuid.UUID) error {
+	answer, err := s.trashRepo.GetAnswer(ctx, userCtx, answerID)
+	if err != nil {
+		return trashLookupError(err)
+	}
+	question, err := s.trashRepo.GetQuestion(ctx, userCtx, answer.QuestionID)
+	if err != nil {
+		return err
+	}
+	qn, err := s.trashRepo.GetQuestionnaire(ctx, userCtx, question.QuestionnaireId)
+	if err != nil {
+		return err
+	}
+	if qn.OwnerId != userID {
+		return apperrors.ErrLackOfAuthorization
+	}
+	if err := s.checkRestorable(answer.DeletedAt); err != nil {
+		return err
+	}
+	if question.DeletedAt.Valid || qn.DeletedAt.Valid {
+		return apperrors.ErrTrashParentDeleted
+	}
+
+	_, err = s.answerRepo.GetBySubmissionAndQuestion(ctx, userCtx, answer.UserSubmissionID, answer.QuestionID)
+	if err == nil {
+		return apperrors.ErrTrashAnswerReplaced
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	return s.trashRepo.Restore(ctx, userCtx, &model.Answer{}, answer.ID)
+}
+
+// Purge permanently removes everything that stayed in the trash longer than the
+// retention window, including the stored files.
+func (s *TrashService) Purge(ctx context.Context) error {
+	files, err := s.trashRepo.Purge(ctx, ctx, time.Now().Add(-s.retention))
+	if err != nil {
+		return err
+	}
+	for _, key := range files {
+		if err := s.fileStorage.Delete(ctx, key); err != nil {
+			logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+				Service: logmessages.LogTrashService,
+				Message: fmt.Sprintf("failed to delete purged file %s: %v", key, err),
+			})
+		}
+	}
+	return nil
+}
+
+func (s *TrashService) checkRestorable(deletedAt gorm.DeletedAt) error {
+	if !deletedAt.Valid {
+		return apperrors.ErrTrashItemNotFound
+	}
+	if deletedAt.Time.Before(time.Now().Add(-s.retention)) {
+		return apperrors.ErrTrashItemExpired
+	}
+	return nil
+}
+
+func trashLookupError(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return apperrors.ErrTrashItemNotFound
+	}
+	return err
+}
@@ -24,9 +24,8 @@ type IUserService interface {
 	VerifyEmail(ctx context.Context, userCtx context.Context, email, code string) error
 	UpdateUser(ctx context.Context, userCtx context.Context, user *model.User) error
 	// profile services
-	UpdateProfile(ctx context.Context, userCtx context.Context, userID uuid.UUID, user *model.User) error
+	UpdateProfile(ctx context.Context, userCtx context.Context, userID uuid.UUID, user *model.User, preferredLocale *string) error
 	GetNotificationList(ctx context.Context, userCtx context.Context, userId uuid.UUID) ([]*model.Notification, error)
-	TransferMoney(ctx context.Context, userCtx context.Context, srcEmail string, dstEmail string, amount uint) error
 	CreateNotification(ctx context.Context, userCtx context.Context, userId uuid.UUID, notification string) error
 }
 
@@ -132,7 +131,9 @@ func (s *UserService) UpdateUser(ctx context.Context, userCtx context.Context, u
 	return s.UserRepo.Update(ctx, userCtx, user)
 }
 
-func (s *UserService) UpdateProfile(ctx context.Context, userCtx context.Context, userID uuid.UUID, updatedUser *model.User) error {
+// UpdateProfile changes the profile of the user. A nil preferredLocale keeps the
+// current one, an empty one clears it.
+func (s *UserService) UpdateProfile(ctx context.Context, userCtx context.Context, userID uuid.UUID, updatedUser *model.User, preferredLocale *string) error {
 	// Validate input
 	if updatedUser == nil {
 		return fmt.Errorf("updated user information must not be nil")
@@ -158,6 +159,9 @@ func (s *UserService) UpdateProfile(ctx context.Context, userCtx context.Context
 	existingUser.FirstName = updatedUser.FirstName
 	existingUser.LastName = updatedUser.LastName
 	existingUser.City = updatedUser.City
+	if preferredLocale != nil {
+		existingUser.PreferredLocale = *preferredLocale
+	}
 
 	// Save changes to the repository
 	if err := s.UserRepo.Update(ctx, userCtx, existingUser); err != nil {
@@ -183,55 +187,6 @@ func (s *UserService) GetNotificationList(ctx context.Context, userCtx context.C
 	return user.NotificationList, nil
 }
 
-func (s *UserService) TransferMoney(ctx context.Context, userCtx context.Context, srcEmail string, dstEmail string, amount uint) error {
-	// Validate input
-	if srcEmail == "" || dstEmail == "" {
-		return fmt.Errorf("source and destination email addresses must not be empty")
-	}
-	if amount == 0 {
-		return fmt.Errorf("transfer amount must be greater than zero")
-	}
-	if srcEmail == dstEmail {
-		return fmt.Errorf("source and destination email addresses cannot be the same")
-	}
-
-	// Fetch source user
-	src, err := s.UserRepo.FindByEmail(ctx, userCtx, srcEmail)
-	if err != nil {
-		return fmt.Errorf("failed to find source user: %w", err)
-	}
-
-	// Fetch destination user
-	dst, err := s.UserRepo.FindByEmail(ctx, userCtx, dstEmail)
-	if err != nil {
-		return fmt.Errorf("failed to find destination user: %w", err)
-	}
-
-	// Check if source user has sufficient balance
-	if src.Wallet < amount {
-		return fmt.Errorf("insufficient balance in source wallet")
-	}
-
-	// Perform transfer
-	src.Wallet -= amount
-	dst.Wallet += amount
-
-	// Update source user
-	if err := s.UserRepo.Update(ctx, userCtx, src); err != nil {
-		return fmt.Errorf("failed to update source user: %w", err)
-	}
-
-	// Update destination user
-	if err := s.UserRepo.Update(ctx, userCtx, dst); err != nil {
-		// Rollback source user's wallet in case of failure
-		src.Wallet += amount
-		_ = s.UserRepo.Update(ctx, userCtx, src)
-		return fmt.Errorf("failed to update destination user: %w", err)
-	}
-
-	return nil
-}
-
 func (s *UserService) CreateNotification(ctx context.Context, userCtx context.Context, userId uuid.UUID, notificationMsg string) error {
 	return s.UserRepo.CreateNotification(ctx, userCtx, userId, &model.Notification{
 		Message: notificationMsg,
@@ -0,0 +1,284 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"golizilla/adapters/persistence/logger"
+	"golizilla/core/domain/model"
+	"golizilla/core/port/gateway"
+	"golizilla/core/port/repository"
+	"golizilla/internal/apperrors"
+	logmessages "golizilla/internal/logmessages"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type IWalletService interface {
+	GetBalance(ctx context.Context, userCtx context.Context, userID uuid.UUID) (uint, error)
+	Transfer(ctx context.Context, userCtx context.Context, srcUserID uuid.UUID, recipient string, amount uint, description string) (*model.WalletTransaction, error)
+	GetTransactions(ctx context.Context, userCtx context.Context, userID uuid.UUID, page, pageSize int) (PaginatedLedgerEntries, error)
+	GetReconciliationReport(ctx context.Context, userCtx context.Context) (*WalletReconciliationReport, error)
+	// top-up
+	CreateTopUp(ctx context.Context, userCtx context.Context, userID uuid.UUID, amount uint) (*model.TopUpIntent, error)
+	GetTopUp(ctx context.Context, userCtx context.Context, userID uuid.UUID, id uuid.UUID) (*model.TopUpIntent, error)
+	HandlePaymentCallback(ctx context.Context, userCtx context.Context, payload []byte, signature string) (*model.TopUpIntent, error)
+}
+
+type WalletService struct {
+	walletRepo         repository.IWalletRepository
+	userRepo           repository.IUserRepository
+	topUpRepo          repository.ITopUpIntentRepository
+	paymentGateway     gateway.IPaymentGateway
+	paymentCallbackURL string
+}
+
+func NewWalletService(
+	walletRepo repository.IWalletRepository,
+	userRepo repository.IUserRepository,
+	topUpRepo repository.ITopUpIntentRepository,
+	paymentGateway gateway.IPaymentGateway,
+	paymentCallbackURL string,
+) IWalletService {
+	return &WalletService{
+		walletRepo:         walletRepo,
+		userRepo:           userRepo,
+		topUpRepo:          topUpRepo,
+		paymentGateway:     paymentGateway,
+		paymentCallbackURL: paymentCallbackURL,
+	}
+}
+
+type PaginatedLedgerEntries struct {
+	Data  []model.LedgerEntry `json:"data"`
+	Pages int                 `json:"pages"`
+	Page  int                 `json:"page"`
+}
+
+type WalletReconciliationReport struct {
+	Consistent             bool                                `json:"consistent"`
+	MismatchedAccounts     []model.WalletReconciliation        `json:"mismatched_accounts"`
+	UnbalancedTransactions []model.WalletUnbalancedTransaction `json:"unbalanced_transactions"`
+}
+
+func (s *WalletService) GetBalance(ctx context.Context, userCtx context.Context, userID uuid.UUID) (uint, error) {
+	user, err := s.userRepo.FindByID(ctx, userCtx, userID)
+	if err != nil {
+		return 0, err
+	}
+	return user.Wallet, nil
+}
+
+// Transfer moves money from the source user to the recipient, who is looked up
+// by email when the value contains an "@" and by username otherwise.
+func (s *WalletService) Transfer(ctx context.Context, userCtx context.Context, srcUserID uuid.UUID, recipient string, amount uint, description string) (*model.WalletTransaction, error) {
+	if amount == 0 {
+		return nil, apperrors.ErrInvalidTransferAmount
+	}
+
+	var dst *model.User
+	var err error
+	if strings.Contains(recipient, "@") {
+		dst, err = s.userRepo.FindByEmail(ctx, userCtx, recipient)
+	} else {
+		dst, err = s.userRepo.FindByUsername(ctx, userCtx, recipient)
+	}
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to find destination user: %w", err)
+	}
+	if dst.ID == srcUserID {
+		return nil, apperrors.ErrSelfTransfer
+	}
+
+	transaction := &model.WalletTransaction{
+		Type:        model.WalletTransactionTransfer,
+		Description: description,
+		Entries: []model.LedgerEntry{
+			{AccountID: srcUserID, Amount: -int64(amount)},
+			{AccountID: dst.ID, Amount: int64(amount)},
+		},
+	}
+	if err := s.walletRepo.Post(ctx, userCtx, transaction); err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogWalletService,
+			Message: err.Error(),
+		})
+		return nil, err
+	}
+
+	logger.GetLogger().LogInfoFromContext(ctx, logger.LogFields{
+		Service: logmessages.LogWalletService,
+		Message: fmt.Sprintf("%s: transaction_id=%s amount=%d", logmessages.LogWalletTransferSuccessful, transaction.ID, amount),
+	})
+
+	return transaction, nil
+}
+
+func (s *WalletService) GetTransactions(ctx context.Context, userCtx context.Context, userID uuid.UUID, page, pageSize int) (PaginatedLedgerEntries, error) {
+	entries, totalRecords, err := s.walletRepo.GetEntriesByAccountID(ctx, userCtx, userID, page, pageSize)
+	if err != nil {
+		return PaginatedLedgerEntries{}, err
+	}
+
+	// Calculate total pages based on total records and page size
+	totalPages := int((totalRecords + int64(pageSize) - 1) / int64(pageSize))
+
+	return PaginatedLedgerEntries{
+		Data:  entries,
+		Pages: totalPages,
+		Page:  page,
+	}, nil
+}
+
+// GetReconciliationReport compares every cached wallet balance with the sum of its
+// ledger entries and lists transactions that break the double-entry invariant.
+func (s *WalletService) GetReconciliationReport(ctx context.Context, userCtx context.Context) (*WalletReconciliationReport, error) {
+	accounts, err := s.walletRepo.GetMismatchedAccounts(ctx, userCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	transactions, err := s.walletRepo.GetUnbalancedTransactions(ctx, userCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &WalletReconciliationReport{
+		Consistent:             len(accounts) == 0 && len(transactions) == 0,
+		MismatchedAccounts:     accounts,
+		UnbalancedTransactions: transactions,
+	}
+	if !report.Consistent {
+		logger.GetLogger().LogWarningFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogWalletService,
+			Message: fmt.Sprintf("%s: accounts=%d transactions=%d", logmessages.LogWalletReconciliationMismatch, len(accounts), len(transactions)),
+		})
+	}
+
+	return report, nil
+}
+
+// CreateTopUp registers a payment at the gateway. The wallet is only credited once
+// the gateway confirms the payment through HandlePaymentCallback.
+func (s *WalletService) CreateTopUp(ctx context.Context, userCtx context.Context, userID uuid.UUID, amount uint) (*model.TopUpIntent, error) {
+	if amount == 0 {
+		return nil, apperrors.ErrInvalidTransferAmount
+	}
+
+	intent := &model.TopUpIntent{
+		ID:      uuid.New(),
+		UserID:  userID,
+		Amount:  amount,
+		Status:  model.TopUpStatusPending,
+		Gateway: s.paymentGateway.Name(),
+	}
+
+	payment, err := s.paymentGateway.CreatePayment(ctx, gateway.PaymentRequest{
+		IntentID:    intent.ID,
+		UserID:      userID,
+		Amount:      amount,
+		CallbackURL: s.paymentCallbackURL,
+	})
+	if err != nil {
+		logger.GetLogger().LogErrorFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogWalletService,
+			Message: fmt.Sprintf("failed to create payment: %v", err),
+		})
+		return nil, err
+	}
+	intent.Reference = payment.Reference
+	intent.CheckoutURL = payment.CheckoutURL
+
+	if err := s.topUpRepo.Create(ctx, userCtx, intent); err != nil {
+		return nil, err
+	}
+	return intent, nil
+}
+
+func (s *WalletService) GetTopUp(ctx context.Context, userCtx context.Context, userID uuid.UUID, id uuid.UUID) (*model.TopUpIntent, error) {
+	intent, err := s.topUpRepo.GetByID(ctx, userCtx, id)
+	if err != nil {
+		return nil, err
+	}
+	if intent.UserID != userID {
+		return nil, apperrors.ErrTopUpNotFound
+	}
+	return intent, nil
+}
+
+// HandlePaymentCallback verifies a gateway callback and settles the matching top-up.
+// The intent row stays locked until the request transaction ends and settled
+// intents are returned unchanged, so each top-up credits the wallet exactly once
+// no matter how often the gateway retries the callback.
+func (s *WalletService) HandlePaymentCallback(ctx context.Context, userCtx context.Context, payload []byte, signature string) (*model.TopUpIntent, error) {
+	callback, err := s.paymentGateway.VerifyCallback(ctx, payload, signature)
+	if err != nil {
+		logger.GetLogger().LogWarningFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogWalletService,
+			Message: err.Error(),
+		})
+		return nil, err
+	}
+
+	intent, err := s.topUpRepo.GetByReferenceForUpdate(ctx, userCtx, callback.Reference)
+	if err != nil {
+		return nil, err
+	}
+	if intent.ID != callback.IntentID {
+		return nil, apperrors.ErrTopUpNotFound
+	}
+
+	if intent.Status != model.TopUpStatusPending {
+		logger.GetLogger().LogInfoFromContext(ctx, logger.LogFields{
+			Service: logmessages.LogWalletService,
+			Message: fmt.Sprintf("duplicate payment callback ignored: reference=%s status=%s", intent.Reference, intent.Status),
+		})
+		return intent, nil
+	}
+
+	now := time.Now()
+	if callback.Status != gateway.PaymentStatusSucceeded {
+		intent.Status = model.TopUpStatusFailed
+		intent.CompletedAt = &now
+		if err := s.topUpRepo.Update(ctx, userCtx, intent); err != nil {
+			return nil, err
+		}
+		return intent, nil
+	}
+
+	if callback.Amount != intent.Amount {
+		return nil, apperrors.ErrPaymentAmountMismatch
+	}
+
+	transaction := &model.WalletTransaction{
+		Type:        model.WalletTransactionTopUp,
+		Description: fmt.Sprintf("top-up via %s (%s)", intent.Gateway, intent.Reference),
+		Entries: []model.LedgerEntry{
+			{AccountID: model.WalletPaymentGatewayAccountID, Amount: -int64(intent.Amount)},
+			{AccountID: intent.UserID, Amount: int64(intent.Amount)},
+		},
+	}
+	if err := s.walletRepo.Post(ctx, userCtx, transaction); err != nil {
+		return nil, err
+	}
+
+	intent.Status = model.TopUpStatusSucceeded
+	intent.TransactionID = &transaction.ID
+	intent.CompletedAt = &now
+	if err := s.topUpRepo.Update(ctx, userCtx, intent); err != nil {
+		return nil, err
+	}
+
+	logger.GetLogger().LogInfoFromContext(ctx, logger.LogFields{
+		Service: logmessages.LogWalletService,
+		Message: fmt.Sprintf("%s: reference=%s amount=%d", logmessages.LogWalletTopUpSuccessful, intent.Reference, intent.Amount),
+	})
+
+	return intent, nil
+}
@@ -6,31 +6,89 @@ import (
 
 // Define error constants
 var (
-	ErrInvalidInput               = errors.New("invalid input")
-	ErrInvalidCredentials         = errors.New("invalid email or password")
-	ErrInvalidVerificationCode    = errors.New("invalid or expired verification code")
-	ErrAccountLocked              = errors.New("your account is locked. please try again later")
-	ErrRecordAlreadyExists        = errors.New("record already in use")
-	ErrNotFound                   = errors.New("resource not found")
-	ErrInternalServerError        = errors.New("internal server error")
-	ErrInvalidTwoFACode           = errors.New("invalid or expired 2FA code")
-	ErrUserNotFound               = errors.New("user not found")
-	ErrFailedToGenerateToken      = errors.New("failed to generate token")
-	ErrFailedToSendEmail          = errors.New("failed to send verification email")
-	ErrMissingAuthToken           = errors.New("missing authentication token")
-	ErrInvalidAuthToken           = errors.New("invalid or expired authentication token")
-	ErrUnexpectedSigningMethod    = errors.New("unexpected signing method")
-	ErrInvalidTokenClaims         = errors.New("invalid token claims")
-	ErrInvalidUserID              = errors.New("invalid user ID in token")
-	ErrInvalidUserDateOfBirth     = errors.New("invalid user date of birth")
-	ErrLackOfAuthorization        = errors.New("you are not authorized to do this")
-	ErrQuestionsNotFound          = errors.New("no questions available")
-	ErrQuestionnaireNotFound      = errors.New("questionnaire not found")
-	ErrSubmissionLimit            = errors.New("out of submission limit")
-	ErrBackIsNotAllowed           = errors.New("back is not allowed")
-	ErrSubmissionNotFoundQuestion = errors.New("question not found or does not match current index")
-	ErrSubmissionNoQuestion       = errors.New("no current question")
-	ErrSubmissionNotInProgress    = errors.New("submission not in progress")
-	ErrQuestionnareExpired        = errors.New("questionnaire has expired")
+	ErrInvalidInput                   = errors.New("invalid input")
+	ErrInvalidCredentials             = errors.New("invalid email or password")
+	ErrInvalidVerificationCode        = errors.New("invalid or expired verification code")
+	ErrAccountLocked                  = errors.New("your account is locked. please try again later")
+	ErrRecordAlreadyExists            = errors.New("record already in use")
+	ErrNotFound                       = errors.New("resource not found")
+	ErrInternalServerError            = errors.New("internal server error")
+	ErrInvalidTwoFACode               = errors.New("invalid or expired 2FA code")
+	ErrUserNotFound                   = errors.New("user not found")
+	ErrFailedToGenerateToken          = errors.New("failed to generate token")
+	ErrFailedToSendEmail              = errors.New("failed to send verification email")
+	ErrMissingAuthToken               = errors.New("missing authentication token")
+	ErrInvalidAuthToken               = errors.New("invalid or expired authentication token")
+	ErrUnexpectedSigningMethod        = errors.New("unexpected signing method")
+	ErrInvalidTokenClaims             = errors.New("invalid token claims")
+	ErrInvalidUserID                  = errors.New("invalid user ID in token")
+	ErrInvalidUserDateOfBirth         = errors.New("invalid user date of birth")
+	ErrLackOfAuthorization            = errors.New("you are not authorized to do this")
+	ErrQuestionsNotFound              = errors.New("no questions available")
+	ErrQuestionnaireNotFound          = errors.New("questionnaire not found")
+	ErrSubmissionLimit                = errors.New("out of submission limit")
+	ErrBackIsNotAllowed               = errors.New("back is not allowed")
+	ErrSubmissionNotFoundQuestion     = errors.New("question not found or does not match current index")
+	ErrSubmissionNoQuestion           = errors.New("no current question")
+	ErrSubmissionNotInProgress        = errors.New("submission not in progress")
+	ErrQuestionnareExpired            = errors.New("questionnaire has expired")
+	ErrInsufficientBalance            = errors.New("insufficient wallet balance")
+	ErrInvalidTransferAmount          = errors.New("transfer amount must be greater than zero")
+	ErrSelfTransfer                   = errors.New("cannot transfer to your own wallet")
+	ErrUnbalancedTransaction          = errors.New("wallet transaction entries must sum to zero")
+	ErrLedgerEntryImmutable           = errors.New("ledger entries cannot be modified")
+	ErrWalletAccountNotFound          = errors.New("wallet account not found")
+	ErrTopUpNotFound                  = errors.New("top-up not found")
+	ErrInvalidPaymentSignature        = errors.New("invalid payment callback signature")
+	ErrPaymentAmountMismatch          = errors.New("payment amount does not match the top-up")
+	ErrSlotTradingDisabled            = errors.New("slot trading is disabled for this questionnaire")
+	ErrSlotTradingUnavailable         = errors.New("slot trading requires a submit limit")
+	ErrNoUnusedSlot                   = errors.New("no unused submission slot to sell")
+	ErrSlotListingNotFound            = errors.New("slot listing not found")
+	ErrSlotListingNotOpen             = errors.New("slot listing is no longer open")
+	ErrSlotSelfPurchase               = errors.New("cannot buy your own slot")
+	ErrFileNotFound                   = errors.New("file not found")
+	ErrInvalidFileKey                 = errors.New("invalid file key")
+	ErrFileTooLarge                   = errors.New("file is too large")
+	ErrUnsupportedMediaType           = errors.New("unsupported media type")
+	ErrAnswerKindMismatch             = errors.New("answer does not match the question type")
+	ErrFileInfected                   = errors.New("file was rejected by the virus scanner")
+	ErrInvalidLocale                  = errors.New("invalid locale")
+	ErrDefaultLocaleTranslation       = errors.New("the default locale is edited on the questionnaire itself")
+	ErrOptionNotFound                 = errors.New("option not found")
+	ErrBankQuestionNotFound           = errors.New("bank question not found")
+	ErrQuestionNotLinked              = errors.New("question is not linked to the question bank")
+	ErrQuestionTimeUp                 = errors.New("time limit for this question has passed")
+	ErrQuestionPoolNotFound           = errors.New("question pool not found")
+	ErrQuestionNotInQuestionnaire     = errors.New("question does not belong to the questionnaire")
+	ErrInvalidDrawCount               = errors.New("draw count must be between 1 and the number of questions in the pool")
+	ErrSectionNotFound                = errors.New("section not found")
+	ErrNoMorePages                    = errors.New("no more pages")
+	ErrPageAnswerNotOnPage            = errors.New("answer is not for a question on the current page")
+	ErrRequiredQuestionUnanswered     = errors.New("a required question is not answered")
+	ErrSubmissionIDConflict           = errors.New("submission id is already in use")
+	ErrOfflineTimeWindow              = errors.New("answers were not given within the allowed time window")
+	ErrOfflineAnswerOrder             = errors.New("answers do not follow the order of the questionnaire")
+	ErrOfflinePoolsUnsupported        = errors.New("questionnaires with question pools must be answered online")
+	ErrSubmissionNotFound             = errors.New("submission not found")
+	ErrAnswerReviewDisabled           = errors.New("answers of this questionnaire cannot be reviewed")
+	ErrQuestionnaireArchived          = errors.New("questionnaire is archived")
+	ErrOwnsActiveQuestionnaires       = errors.New("transfer or archive your questionnaires before deleting the account")
+	ErrTransferToSelf                 = errors.New("questionnaire is already owned by this user")
+	ErrTrashItemNotFound              = errors.New("item not found in trash")
+	ErrTrashItemExpired               = errors.New("item was deleted too long ago to be restored")
+	ErrTrashParentDeleted             = errors.New("restore the deleted questionnaire or question this item belongs to first")
+	ErrTrashAnswerReplaced            = errors.New("the question was answered again after this answer was deleted")
+	ErrPrivilegeAuditLogImmutable     = errors.New("privilege audit logs cannot be modified")
+	ErrRoleNotFound                   = errors.New("role not found")
+	ErrRoleInUse                      = errors.New("role still has members, assign them to another role first")
+	ErrRoleProtected                  = errors.New("the super admin role cannot be changed this way")
+	ErrAdminAccessLogImmutable        = errors.New("admin access logs cannot be modified")
+	ErrPrivilegeNotSettableOnInstance = errors.New("privilege cannot be granted on a questionnaire")
+	ErrRoleCycle                      = errors.New("a role cannot inherit from itself or from one of its descendants")
+	ErrRoleHasChildren                = errors.New("other roles inherit from this role, move them first")
+	ErrNotEligible                    = errors.New("you are not eligible to answer this questionnaire")
+	ErrSubmissionExists               = errors.New("submission already exists")
+	ErrPrivilegeGrantedByRole         = errors.New("privilege is still granted to the user's role, revoke it from the role")
 	// Add more as needed
 )
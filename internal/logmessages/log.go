@@ -54,7 +54,11 @@ var (
 	LogQuestionnaireGetByIdSuccessful      = "questionnaire Got ById successfully"
 	LogQuestionnaireGetByOwnerIdSuccessful = "questionnaire Got ByOwnerId successfully"
 	LogQuestionnaireGiveAccessSuccessful   = "questionnaire GiveAccess successfully"
+	LogQuestionnaireRoleAccessBegin        = "starting questionnaire role access change"
 	LogQuestionnaireGetResultsEnd          = "questionnaire GetResults ended"
+	LogQuestionnaireExportBegin            = "starting questionnaire Export"
+	LogQuestionnaireTimingBegin            = "starting questionnaire GetTiming"
+	LogQuestionnaireEligibilityBegin       = "starting questionnaire SetEligibility"
 
 	// Question
 	LogQuestionHandler             = "question_handler"
@@ -87,20 +91,30 @@ var (
 	LogAnswerGetByIDSuccessfully = "answer Got ByID successfully"
 
 	// admin
-	LogAdminHandler          = "Admin_handler"
-	LogAdminService          = "admin_service"
-	LogAdminRepository       = "admin_repository"
-	LogAdminModel            = "admin_model"
-	_                        = ""
-	LogAdminGetAllUsersBegin = "starting admin GetAllUsers"
+	LogAdminHandler            = "Admin_handler"
+	LogAdminService            = "admin_service"
+	LogAdminRepository         = "admin_repository"
+	LogAdminModel              = "admin_model"
+	_                          = ""
+	LogAdminGetAllUsersBegin   = "starting admin GetAllUsers"
+	LogAdminGetAnswersBegin    = "starting admin GetAnswersByUserIDAndQuestionnaireID"
+	LogAdminGetAccessLogsBegin = "starting admin GetAccessLogs"
 
 	LogCastUserIdError     = "failed to cast user id"
 	LogLackOfAuthorization = "Lack Of Authorization"
 
 	// role
-	LogRoleService  = "roler_service"
-	_               = ""
-	LogRoleNotFound = "role not found"
+	LogRoleService         = "roler_service"
+	LogRoleHandler         = "role_handler"
+	_                      = ""
+	LogRoleNotFound        = "role not found"
+	LogRoleCreateBegin     = "starting role Create"
+	LogRoleUpdateBegin     = "starting role Update"
+	LogRoleDeleteBegin     = "starting role Delete"
+	LogRolePrivilegesBegin = "starting role privileges change"
+	LogRoleAssignBegin     = "starting role Assign"
+	LogRoleMembersBegin    = "starting role Members"
+	LogRoleParentBegin     = "starting role parent change"
 
 	// role privilege on instance
 	LogRolePrivilegeOnInstance = "rolePrivilegeOnInstace_repository"
@@ -111,5 +125,127 @@ var (
 	// submission
 	LogSubmitRepo = "submission_repository"
 
+	// wallet
+	LogWalletHandler                = "wallet_handler"
+	LogWalletService                = "wallet_service"
+	LogWalletRepository             = "wallet_repository"
+	_                               = ""
+	LogWalletGetBalanceBegin        = "starting wallet GetBalance"
+	LogWalletTransferBegin          = "starting wallet Transfer"
+	LogWalletGetTransactionsBegin   = "starting wallet GetTransactions"
+	LogWalletReconciliationBegin    = "starting wallet Reconciliation"
+	LogWalletTransferSuccessful     = "wallet Transfer successfully"
+	LogWalletReconciliationMismatch = "wallet reconciliation found mismatches"
+	LogWalletTopUpBegin             = "starting wallet TopUp"
+	LogWalletPaymentCallbackBegin   = "starting wallet PaymentCallback"
+	LogWalletTopUpSuccessful        = "wallet TopUp credited successfully"
+
+	// submit slot market
+	LogSubmitSlotHandler            = "submit_slot_handler"
+	LogSubmitSlotService            = "submit_slot_service"
+	LogSubmitSlotRepository         = "submit_slot_repository"
+	_                               = ""
+	LogSubmitSlotSetTradingBegin    = "starting submit slot SetTrading"
+	LogSubmitSlotCreateListingBegin = "starting submit slot CreateListing"
+	LogSubmitSlotCancelListingBegin = "starting submit slot CancelListing"
+	LogSubmitSlotBuyBegin           = "starting submit slot Buy"
+	LogSubmitSlotGetListingsBegin   = "starting submit slot GetListings"
+	LogSubmitSlotGetAuditLogsBegin  = "starting submit slot GetAuditLogs"
+	LogSubmitSlotSoldSuccessful     = "submit slot sold successfully"
+
+	// question media
+	LogQuestionMediaHandler       = "question_media_handler"
+	LogQuestionMediaService       = "question_media_service"
+	_                             = ""
+	LogQuestionMediaUploadBegin   = "starting question media Upload"
+	LogQuestionMediaDownloadBegin = "starting question media Download"
+	LogQuestionMediaDeleteBegin   = "starting question media Delete"
+
+	// translations
+	LogTranslationHandler               = "translation_handler"
+	LogTranslationService               = "translation_service"
+	LogTranslationRepository            = "translation_repository"
+	_                                   = ""
+	LogTranslationSetQuestionnaireBegin = "starting translation SetQuestionnaire"
+	LogTranslationSetQuestionBegin      = "starting translation SetQuestion"
+	LogTranslationGetBegin              = "starting translation Get"
+	LogTranslationDeleteLocaleBegin     = "starting translation DeleteLocale"
+
+	// question bank
+	LogQuestionBankHandler     = "question_bank_handler"
+	LogQuestionBankService     = "question_bank_service"
+	LogQuestionBankRepository  = "question_bank_repository"
+	_                          = ""
+	LogQuestionBankCreateBegin = "starting question bank Create"
+	LogQuestionBankUpdateBegin = "starting question bank Update"
+	LogQuestionBankDeleteBegin = "starting question bank Delete"
+	LogQuestionBankGetBegin    = "starting question bank Get"
+	LogQuestionBankListBegin   = "starting question bank List"
+	LogQuestionBankUseBegin    = "starting question bank Use"
+	LogQuestionBankSyncBegin   = "starting question bank Sync"
+	LogQuestionBankResultBegin = "starting question bank Results"
+
+	// question pools
+	LogQuestionPoolHandler      = "question_pool_handler"
+	LogQuestionPoolService      = "question_pool_service"
+	LogQuestionPoolRepository   = "question_pool_repository"
+	_                           = ""
+	LogQuestionPoolCreateBegin  = "starting question pool Create"
+	LogQuestionPoolUpdateBegin  = "starting question pool Update"
+	LogQuestionPoolDeleteBegin  = "starting question pool Delete"
+	LogQuestionPoolListBegin    = "starting question pool List"
+	LogQuestionPoolResultsBegin = "starting question pool ExamResults"
+
+	// sections
+	LogSectionHandler        = "section_handler"
+	LogSectionService        = "section_service"
+	LogSectionRepository     = "section_repository"
+	_                        = ""
+	LogSectionCreateBegin    = "starting section Create"
+	LogSectionUpdateBegin    = "starting section Update"
+	LogSectionDeleteBegin    = "starting section Delete"
+	LogSectionListBegin      = "starting section List"
+	LogSectionBranchingBegin = "starting section SetBranching"
+
+	// submission history
+	LogSubmissionHistoryHandler   = "submission_history_handler"
+	_                             = ""
+	LogSubmissionHistoryListBegin = "starting submission history List"
+	LogSubmissionHistoryGetBegin  = "starting submission history Get"
+
+	// submission view
+	LogSubmissionViewHandler   = "submission_view_handler"
+	_                          = ""
+	LogSubmissionViewListBegin = "starting submission view List"
+	LogSubmissionViewGetBegin  = "starting submission view Get"
+
+	// account
+	LogAccountHandler          = "account_handler"
+	LogAccountService          = "account_service"
+	_                          = ""
+	LogAccountExportBegin      = "starting account Export"
+	LogAccountDeleteBegin      = "starting account Delete"
+	LogAccountDeleteSuccessful = "account deleted"
+	LogAccountArchiveBegin     = "starting questionnaire Archive"
+	LogAccountTransferBegin    = "starting questionnaire Transfer"
+
+	// trash
+	LogTrashHandler      = "trash_handler"
+	LogTrashService      = "trash_service"
+	_                    = ""
+	LogTrashListBegin    = "starting trash List"
+	LogTrashRestoreBegin = "starting trash Restore"
+
+	// privilege audit
+	LogPrivilegeAuditHandler     = "privilege_audit_handler"
+	_                            = ""
+	LogPrivilegeAuditListBegin   = "starting privilege audit List"
+	LogPrivilegeAuditExportBegin = "starting privilege audit Export"
+
+	// privilege
+	LogPrivilegeHandler   = "privilege_handler"
+	_                     = ""
+	LogPrivilegeListBegin = "starting privilege List"
+
 	// Add more as needed
 )
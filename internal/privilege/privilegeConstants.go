@@ -17,4 +17,14 @@ const (
 	SeeResultsOnInstance         string = "SeeResultsOnInstance"
 	SeeVoteOnInstance            string = "SeeVoteOnInstance"
 	GiveAccessToOthersOnInstance string = "GiveAccessToOthersOnInstance"
+	ManageQuestionBank           string = "ManageQuestionBank"
+
+	// admin privileges, held by the super-admin role
+	AdminViewUsers          string = "AdminViewUsers"
+	AdminViewQuestionnaires string = "AdminViewQuestionnaires"
+	AdminViewQuestions      string = "AdminViewQuestions"
+	AdminViewRoles          string = "AdminViewRoles"
+	AdminViewAnswers        string = "AdminViewAnswers"
+	AdminViewWallet         string = "AdminViewWallet"
+	AdminViewAuditLogs      string = "AdminViewAuditLogs"
 )
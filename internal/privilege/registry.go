@@ -0,0 +1,70 @@
+package privilegeconstants
+
+// Default roles privileges are granted to. RoleSuperAdmin is the role seeded for
+// the admin user, RoleSignup the personal role every user gets at signup.
+const (
+	RoleSuperAdmin string = "super-admin"
+	RoleSignup     string = "signup"
+)
+
+// Definition describes a privilege. CanSetOnInstance marks privileges that can be
+// granted on a single questionnaire instead of globally.
+type Definition struct {
+	ID               string
+	Description      string
+	CanSetOnInstance bool
+	DefaultRoles     []string
+}
+
+// Registry is the single list of privileges, the database is reconciled with it
+// on startup.
+var Registry = []Definition{
+	{ID: CreateQuestionnaire, Description: "Create questionnaires", DefaultRoles: []string{RoleSuperAdmin, RoleSignup}},
+	{ID: EditQuestionnaire, Description: "Edit any questionnaire", DefaultRoles: []string{RoleSuperAdmin}},
+	{ID: DeleteQuestionnaire, Description: "Delete any questionnaire", DefaultRoles: []string{RoleSuperAdmin}},
+	{ID: ViewQuestionnaire, Description: "View any questionnaire", DefaultRoles: []string{RoleSuperAdmin}},
+	{ID: CreateQuestion, Description: "Add questions to a questionnaire", CanSetOnInstance: true, DefaultRoles: []string{RoleSuperAdmin}},
+	{ID: EditQuestion, Description: "Edit the questions of a questionnaire", CanSetOnInstance: true, DefaultRoles: []string{RoleSuperAdmin}},
+	{ID: DeleteQuestion, Description: "Delete the questions of a questionnaire", CanSetOnInstance: true, DefaultRoles: []string{RoleSuperAdmin}},
+	{ID: ViewQuestion, Description: "View the questions of a questionnaire", CanSetOnInstance: true, DefaultRoles: []string{RoleSuperAdmin}},
+	{ID: AssignRole, Description: "Move users between roles", DefaultRoles: []string{RoleSuperAdmin}},
+	{ID: ManagePrivileges, Description: "Create roles and change their privileges", DefaultRoles: []string{RoleSuperAdmin}},
+	{ID: StartQuestionnariInsance, Description: "Answer a questionnaire", CanSetOnInstance: true, DefaultRoles: []string{RoleSuperAdmin}},
+	{ID: ViewQuestionnaireInstances, Description: "View the details of a questionnaire", CanSetOnInstance: true, DefaultRoles: []string{RoleSuperAdmin}},
+	{ID: UpdateQuestionnaireInstance, Description: "Update the settings of a questionnaire", CanSetOnInstance: true, DefaultRoles: []string{RoleSuperAdmin}},
+	{ID: SeeResultsOnInstance, Description: "See and export the results of a questionnaire", CanSetOnInstance: true, DefaultRoles: []string{RoleSuperAdmin}},
+	{ID: SeeVoteOnInstance, Description: "See the individual answers given to a questionnaire", CanSetOnInstance: true, DefaultRoles: []string{RoleSuperAdmin}},
+	{ID: GiveAccessToOthersOnInstance, Description: "Grant and revoke privileges on a questionnaire", CanSetOnInstance: true, DefaultRoles: []string{RoleSuperAdmin}},
+	{ID: ManageQuestionBank, Description: "Manage the shared question bank", DefaultRoles: []string{RoleSuperAdmin}},
+	{ID: AdminViewUsers, Description: "List all users", DefaultRoles: []string{RoleSuperAdmin}},
+	{ID: AdminViewQuestionnaires, Description: "List all questionnaires", DefaultRoles: []string{RoleSuperAdmin}},
+	{ID: AdminViewQuestions, Description: "List all questions", DefaultRoles: []string{RoleSuperAdmin}},
+	{ID: AdminViewRoles, Description: "List all roles", DefaultRoles: []string{RoleSuperAdmin}},
+	{ID: AdminViewAnswers, Description: "Read the answers of any user, every access is logged", DefaultRoles: []string{RoleSuperAdmin}},
+	{ID: AdminViewWallet, Description: "See the wallet reconciliation report", DefaultRoles: []string{RoleSuperAdmin}},
+	{ID: AdminViewAuditLogs, Description: "Read the privilege and admin access audit logs", DefaultRoles: []string{RoleSuperAdmin}},
+}
+
+// Lookup returns the definition of a privilege.
+func Lookup(id string) (Definition, bool) {
+	for _, definition := range Registry {
+		if definition.ID == id {
+			return definition, true
+		}
+	}
+	return Definition{}, false
+}
+
+// DefaultPrivileges returns the IDs of the privileges a default role gets.
+func DefaultPrivileges(role string) []string {
+	var privileges []string
+	for _, definition := range Registry {
+		for _, defaultRole := range definition.DefaultRoles {
+			if defaultRole == role {
+				privileges = append(privileges, definition.ID)
+				break
+			}
+		}
+	}
+	return privileges
+}